@@ -0,0 +1,407 @@
+package actions
+
+import (
+	"context"
+	"crypto/md5"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	minio "github.com/minio/minio-go/v7"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+	"go.clever-cloud.com/terraform-provider/pkg/s3"
+)
+
+//go:embed cellar_sync_doc.md
+var actionCellarSyncDoc string
+
+const defaultSyncParallelism = 8
+
+func CellarSync() action.Action {
+	return &ActionCellarSync{}
+}
+
+type ActionCellarSync struct {
+	provider.Provider
+}
+
+type cellarSync struct {
+	CellarID     types.String `tfsdk:"cellar_id"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Source       types.String `tfsdk:"source"`
+	Prefix       types.String `tfsdk:"prefix"`
+	Delete       types.Bool   `tfsdk:"delete"`
+	Parallelism  types.Int64  `tfsdk:"parallelism"`
+	CacheControl types.List   `tfsdk:"cache_control"`
+}
+
+type CacheControlRule struct {
+	Pattern types.String `tfsdk:"pattern"`
+	Value   types.String `tfsdk:"value"`
+}
+
+// SyncOptions are the settings of a directory sync
+type SyncOptions struct {
+	Source      string
+	Prefix      string
+	Delete      bool
+	Parallelism int
+	// the first matching pattern applies
+	CacheControl []SyncCacheControl
+}
+
+// SyncCacheControl is the Cache-Control header of the files matching a glob pattern
+type SyncCacheControl struct {
+	Pattern string
+	Value   string
+}
+
+// SyncFile is a local file to mirror in the bucket
+type SyncFile struct {
+	Path         string
+	Key          string
+	ContentType  string
+	CacheControl string
+}
+
+// SyncTarget is the bucket a directory is mirrored in
+type SyncTarget interface {
+	// ETags returns the etag of the objects under the prefix, by key
+	ETags(ctx context.Context, prefix string) (map[string]string, error)
+	Upload(ctx context.Context, file SyncFile) error
+	Remove(ctx context.Context, keys []string) error
+}
+
+func (a *ActionCellarSync) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	tflog.Debug(ctx, "Configure()")
+
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+func (a *ActionCellarSync) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_cellar_sync"
+}
+
+func (a *ActionCellarSync) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionCellarSyncDoc,
+		Attributes: map[string]schema.Attribute{
+			"cellar_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Cellar addon ID of the bucket",
+			},
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the bucket to sync",
+			},
+			"source": schema.StringAttribute{
+				Required:    true,
+				Description: "Local directory to mirror in the bucket",
+			},
+			"prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix of the object keys, i.e. 'site/' (default: bucket root)",
+			},
+			"delete": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Delete the objects under the prefix which do not exist in the source directory (default: false)",
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of concurrent uploads (default: %d)", defaultSyncParallelism),
+				Validators:  []validator.Int64{int64validator.Between(1, 64)},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"cache_control": schema.ListNestedBlock{
+				Description: "Cache-Control header of the uploaded files, the first matching rule applies. Unchanged files are not uploaded again and keep their previous header",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"pattern": schema.StringAttribute{
+							Required:    true,
+							Description: "Glob pattern of the files, a pattern without '/' matches the file name, i.e. '*.html', otherwise the path relative to the source, i.e. 'assets/*'",
+						},
+						"value": schema.StringAttribute{
+							Required:    true,
+							Description: "Cache-Control header, i.e. 'public, max-age=31536000, immutable'",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *ActionCellarSync) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[cellarSync](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Invoke cellar_sync", map[string]any{"config": req.Config})
+	progress := ProgressWrapper(res)
+
+	opts := cfg.Options(ctx, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	progress("Connecting to Cellar addon")
+	minioClient, _, err := s3.Connect(ctx, a.Client(), a.Organization(), cfg.CellarID.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to connect to Cellar addon", err.Error())
+		return
+	}
+
+	target := &cellarSyncTarget{client: minioClient, bucket: cfg.Bucket.ValueString()}
+	RunSync(ctx, target, opts, progress, &res.Diagnostics)
+}
+
+// Options reads the sync settings, the prefix is a directory
+func (cfg *cellarSync) Options(ctx context.Context, diags *diag.Diagnostics) SyncOptions {
+	opts := SyncOptions{
+		Source:      cfg.Source.ValueString(),
+		Prefix:      strings.TrimPrefix(cfg.Prefix.ValueString(), "/"),
+		Delete:      cfg.Delete.ValueBool(),
+		Parallelism: defaultSyncParallelism,
+	}
+	if opts.Prefix != "" && !strings.HasSuffix(opts.Prefix, "/") {
+		opts.Prefix += "/"
+	}
+	if !cfg.Parallelism.IsNull() {
+		opts.Parallelism = int(cfg.Parallelism.ValueInt64())
+	}
+
+	rules := []CacheControlRule{}
+	diags.Append(cfg.CacheControl.ElementsAs(ctx, &rules, false)...)
+	for _, rule := range rules {
+		if _, err := path.Match(rule.Pattern.ValueString(), ""); err != nil {
+			diags.AddError("invalid cache_control pattern", fmt.Sprintf("%s: %s", rule.Pattern.ValueString(), err.Error()))
+			continue
+		}
+		opts.CacheControl = append(opts.CacheControl, SyncCacheControl{Pattern: rule.Pattern.ValueString(), Value: rule.Value.ValueString()})
+	}
+
+	return opts
+}
+
+// RunSync uploads the new and changed files of the source directory, and deletes the remote extras if asked
+func RunSync(ctx context.Context, target SyncTarget, opts SyncOptions, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	progress("Listing local files")
+	files, err := LocalFiles(opts)
+	if err != nil {
+		diags.AddError("failed to list local files", err.Error())
+		return
+	}
+
+	progress("Listing remote objects")
+	etags, err := target.ETags(ctx, opts.Prefix)
+	if err != nil {
+		diags.AddError("failed to list remote objects", err.Error())
+		return
+	}
+
+	toUpload := []SyncFile{}
+	for _, file := range files {
+		etag, err := fileMD5(file.Path)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to read %s", file.Path), err.Error())
+			return
+		}
+		if etags[file.Key] != etag {
+			toUpload = append(toUpload, file)
+		}
+	}
+	progress("%d file(s) to upload, %d unchanged", len(toUpload), len(files)-len(toUpload))
+
+	// uploads are concurrent, progress and diagnostics are not
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan SyncFile)
+	uploaded := 0
+	for range min(opts.Parallelism, len(toUpload)) {
+		wg.Go(func() {
+			for file := range queue {
+				err := target.Upload(ctx, file)
+
+				mu.Lock()
+				if err != nil {
+					diags.AddError(fmt.Sprintf("failed to upload %s", file.Key), err.Error())
+				} else {
+					uploaded++
+					progress("Uploaded %s (%d/%d)", file.Key, uploaded, len(toUpload))
+				}
+				mu.Unlock()
+			}
+		})
+	}
+	for _, file := range toUpload {
+		queue <- file
+	}
+	close(queue)
+	wg.Wait()
+
+	if diags.HasError() {
+		return
+	}
+
+	deleted := 0
+	if opts.Delete {
+		local := make(map[string]struct{}, len(files))
+		for _, file := range files {
+			local[file.Key] = struct{}{}
+		}
+
+		extras := []string{}
+		for key := range etags {
+			if _, ok := local[key]; !ok {
+				extras = append(extras, key)
+			}
+		}
+		slices.Sort(extras)
+
+		if len(extras) > 0 {
+			progress("Deleting %d remote object(s)", len(extras))
+			if err := target.Remove(ctx, extras); err != nil {
+				diags.AddError("failed to delete remote objects", err.Error())
+				return
+			}
+			deleted = len(extras)
+		}
+	}
+
+	progress("Sync done: %d uploaded, %d unchanged, %d deleted", uploaded, len(files)-len(toUpload), deleted)
+}
+
+// LocalFiles lists the regular files of the source directory, with their object key and headers
+func LocalFiles(opts SyncOptions) ([]SyncFile, error) {
+	files := []SyncFile{}
+
+	err := filepath.WalkDir(opts.Source, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(opts.Source, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		files = append(files, SyncFile{
+			Path:         filePath,
+			Key:          opts.Prefix + relPath,
+			ContentType:  contentTypeOf(relPath),
+			CacheControl: cacheControlOf(relPath, opts.CacheControl),
+		})
+		return nil
+	})
+
+	return files, err
+}
+
+func contentTypeOf(name string) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+// cacheControlOf returns the value of the first rule matching the file,
+// a pattern without / only applies to the file name
+func cacheControlOf(relPath string, rules []SyncCacheControl) string {
+	for _, rule := range rules {
+		name := relPath
+		if !strings.Contains(rule.Pattern, "/") {
+			name = path.Base(relPath)
+		}
+
+		if matched, _ := path.Match(rule.Pattern, name); matched {
+			return rule.Value
+		}
+	}
+	return ""
+}
+
+func fileMD5(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type cellarSyncTarget struct {
+	client *minio.Client
+	bucket string
+}
+
+func (t *cellarSyncTarget) ETags(ctx context.Context, prefix string) (map[string]string, error) {
+	etags := map[string]string{}
+	for object := range t.client.ListObjectsIter(ctx, t.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		etags[object.Key] = strings.Trim(object.ETag, `"`)
+	}
+	return etags, nil
+}
+
+// Upload puts the file in a single request, so its etag is the MD5 of the content
+func (t *cellarSyncTarget) Upload(ctx context.Context, file SyncFile) error {
+	_, err := t.client.FPutObject(ctx, t.bucket, file.Key, file.Path, minio.PutObjectOptions{
+		ContentType:      file.ContentType,
+		CacheControl:     file.CacheControl,
+		DisableMultipart: true,
+	})
+	return err
+}
+
+func (t *cellarSyncTarget) Remove(ctx context.Context, keys []string) error {
+	objects := make(chan minio.ObjectInfo, len(keys))
+	for _, key := range keys {
+		objects <- minio.ObjectInfo{Key: key}
+	}
+	close(objects)
+
+	errs := []error{}
+	for err := range t.client.RemoveObjects(ctx, t.bucket, objects, minio.RemoveObjectsOptions{}) {
+		errs = append(errs, fmt.Errorf("%s: %w", err.ObjectName, err.Err))
+	}
+	return errors.Join(errs...)
+}
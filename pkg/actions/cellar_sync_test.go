@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSyncTarget struct {
+	etags  map[string]string
+	failOn string
+
+	mu       sync.Mutex
+	uploaded map[string]SyncFile
+	removed  []string
+}
+
+func (t *fakeSyncTarget) ETags(ctx context.Context, prefix string) (map[string]string, error) {
+	return t.etags, nil
+}
+
+func (t *fakeSyncTarget) Upload(ctx context.Context, file SyncFile) error {
+	if file.Key == t.failOn {
+		return errors.New("access denied")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.uploaded[file.Key] = file
+	return nil
+}
+
+func (t *fakeSyncTarget) Remove(ctx context.Context, keys []string) error {
+	t.removed = keys
+	return nil
+}
+
+// newSyncSource writes a small static site
+func newSyncSource(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.html":     "<h1>home</h1>",
+		"assets/app.js":  "console.log('app')",
+		"assets/app.css": "body {}",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestRunSync(t *testing.T) {
+	source := newSyncSource(t)
+	unchanged, err := fileMD5(filepath.Join(source, "assets", "app.css"))
+	require.NoError(t, err)
+
+	// app.js is new, index.html changed and old.html was removed
+	target := &fakeSyncTarget{
+		etags: map[string]string{
+			"site/index.html":     "d41d8cd98f00b204e9800998ecf8427e",
+			"site/assets/app.css": unchanged,
+			"site/old.html":       "d41d8cd98f00b204e9800998ecf8427e",
+		},
+		uploaded: map[string]SyncFile{},
+	}
+
+	diags := diag.Diagnostics{}
+	RunSync(context.Background(), target, SyncOptions{
+		Source:       source,
+		Prefix:       "site/",
+		Delete:       true,
+		Parallelism:  2,
+		CacheControl: []SyncCacheControl{{Pattern: "*.html", Value: "no-cache"}, {Pattern: "assets/*", Value: "max-age=31536000"}},
+	}, noProgress, &diags)
+
+	require.False(t, diags.HasError(), diags)
+	keys := []string{}
+	for key := range target.uploaded {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	assert.Equal(t, []string{"site/assets/app.js", "site/index.html"}, keys)
+	assert.Equal(t, "no-cache", target.uploaded["site/index.html"].CacheControl)
+	assert.Equal(t, "max-age=31536000", target.uploaded["site/assets/app.js"].CacheControl)
+	assert.Equal(t, []string{"site/old.html"}, target.removed)
+}
+
+func TestRunSync_WithoutDelete(t *testing.T) {
+	target := &fakeSyncTarget{etags: map[string]string{"old.html": ""}, uploaded: map[string]SyncFile{}}
+	diags := diag.Diagnostics{}
+
+	RunSync(context.Background(), target, SyncOptions{Source: newSyncSource(t), Parallelism: 8}, noProgress, &diags)
+
+	require.False(t, diags.HasError(), diags)
+	assert.Len(t, target.uploaded, 3)
+	assert.Empty(t, target.removed)
+}
+
+func TestRunSync_Failure(t *testing.T) {
+	target := &fakeSyncTarget{etags: map[string]string{"old.html": ""}, failOn: "index.html", uploaded: map[string]SyncFile{}}
+	diags := diag.Diagnostics{}
+
+	RunSync(context.Background(), target, SyncOptions{Source: newSyncSource(t), Delete: true, Parallelism: 1}, noProgress, &diags)
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, "failed to upload index.html", diags.Errors()[0].Summary())
+	assert.Empty(t, target.removed, "nothing must be deleted after a failed upload")
+}
+
+func TestCacheControlOf(t *testing.T) {
+	rules := []SyncCacheControl{
+		{Pattern: "*.html", Value: "no-cache"},
+		{Pattern: "assets/*", Value: "immutable"},
+		{Pattern: "*", Value: "max-age=60"},
+	}
+
+	assert.Equal(t, "no-cache", cacheControlOf("index.html", rules))
+	assert.Equal(t, "no-cache", cacheControlOf("docs/index.html", rules))
+	assert.Equal(t, "immutable", cacheControlOf("assets/app.js", rules))
+	assert.Equal(t, "max-age=60", cacheControlOf("assets/img/logo.png", rules))
+	assert.Empty(t, cacheControlOf("robots.txt", nil))
+}
+
+func TestContentTypeOf(t *testing.T) {
+	assert.Equal(t, "text/html; charset=utf-8", contentTypeOf("index.html"))
+	assert.Equal(t, "image/png", contentTypeOf("img/logo.png"))
+	assert.Equal(t, "application/octet-stream", contentTypeOf("LICENSE"))
+}
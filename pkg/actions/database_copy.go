@@ -0,0 +1,388 @@
+package actions
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+)
+
+func DatabaseCopy() action.Action {
+	return &ActionDatabaseCopy{}
+}
+
+type ActionDatabaseCopy struct {
+	provider.Provider
+}
+
+type databaseCopy struct {
+	SourceID      types.String `tfsdk:"source_id"`
+	TargetID      types.String `tfsdk:"target_id"`
+	IncludeTables types.List   `tfsdk:"include_tables"`
+	ExcludeTables types.List   `tfsdk:"exclude_tables"`
+	Truncate      types.Bool   `tfsdk:"truncate"`
+	Anonymize     types.List   `tfsdk:"anonymize"`
+}
+
+type AnonymizeRule struct {
+	Table      types.String `tfsdk:"table"`
+	Column     types.String `tfsdk:"column"`
+	Expression types.String `tfsdk:"expression"`
+}
+
+// CopyOptions are the engine agnostic settings of a copy
+type CopyOptions struct {
+	Include  []string
+	Exclude  []string
+	Truncate bool
+	// table => column => SQL expression
+	Anonymize map[string]map[string]string
+}
+
+// Table is a table of the source database, Schema is empty on MySQL
+type Table struct {
+	Schema string
+	Name   string
+}
+
+func (t Table) String() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// Matches reports whether the table matches a glob pattern,
+// a pattern without a dot only applies to the table name (any schema)
+func (t Table) Matches(pattern string) bool {
+	name := t.String()
+	if !strings.Contains(pattern, ".") {
+		name = t.Name
+	}
+
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
+
+// Copier copies tables between two databases of the same engine
+type Copier interface {
+	// Tables lists the source tables, referenced tables first
+	Tables(ctx context.Context) ([]Table, error)
+	// Columns lists the copied columns of a source table (generated columns are skipped)
+	Columns(ctx context.Context, table Table) ([]string, error)
+	// CreateSchema creates the tables missing on the target, without their constraints
+	CreateSchema(ctx context.Context, tables []Table) error
+	Truncate(ctx context.Context, tables []Table) error
+	// CopyData streams the rows, expressions replace the value of some columns
+	CopyData(ctx context.Context, table Table, columns []string, expressions map[string]string) (int64, error)
+	// Finish restores constraints, indexes and sequences once the data is copied
+	Finish(ctx context.Context, tables []Table) error
+	Close(ctx context.Context) error
+}
+
+var databaseIDRegex = regexp.MustCompile(`^(postgresql|mysql)_`)
+
+func (a *ActionDatabaseCopy) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	tflog.Debug(ctx, "Configure()")
+
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+//go:embed database_copy_doc.md
+var actionDatabaseCopyDoc string
+
+func (a *ActionDatabaseCopy) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionDatabaseCopyDoc,
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Required:    true,
+				Description: "PostgreSQL or MySQL database ID to read the data from",
+				Validators: []validator.String{
+					pkg.NewValidatorRegex("must be a PostgreSQL or MySQL addon ID", databaseIDRegex),
+				},
+			},
+			"target_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Database ID to write the data into, of the same engine as source_id",
+				Validators: []validator.String{
+					pkg.NewValidatorRegex("must be a PostgreSQL or MySQL addon ID", databaseIDRegex),
+				},
+			},
+			"include_tables": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Glob patterns of the tables to copy (schema.table on PostgreSQL, a pattern without a dot matches the table name in any schema), all tables by default",
+			},
+			"exclude_tables": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Glob patterns of the tables to skip, same syntax as include_tables",
+			},
+			"truncate": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Empty the copied tables on the target before copying the rows (default: true)",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"anonymize": schema.ListNestedBlock{
+				Description: "Replace the values of a column by an SQL expression evaluated on the source",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"table": schema.StringAttribute{
+							Required:    true,
+							Description: "Table of the column, schema.table on PostgreSQL (public schema when omitted)",
+						},
+						"column": schema.StringAttribute{
+							Required:    true,
+							Description: "Column to anonymize",
+						},
+						"expression": schema.StringAttribute{
+							Required:    true,
+							Description: "SQL expression used instead of the column value, it can reference the other columns of the row, i.e. 'user_' || id || '@example.com'",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (a *ActionDatabaseCopy) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_database_copy"
+}
+
+func (a *ActionDatabaseCopy) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, res *action.ValidateConfigResponse) {
+	cfg := helper.From[databaseCopy](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if cfg.SourceID.IsUnknown() || cfg.TargetID.IsUnknown() {
+		return
+	}
+
+	source := databaseIDRegex.FindString(cfg.SourceID.ValueString())
+	target := databaseIDRegex.FindString(cfg.TargetID.ValueString())
+	if source != target {
+		res.Diagnostics.AddAttributeError(tfpath.Root("target_id"), "engines mismatch", "source and target databases must use the same engine")
+	}
+	if cfg.SourceID.ValueString() == cfg.TargetID.ValueString() {
+		res.Diagnostics.AddAttributeError(tfpath.Root("target_id"), "same database", "source and target databases must be different")
+	}
+}
+
+func (a *ActionDatabaseCopy) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[databaseCopy](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Invoke database_copy", map[string]any{"config": req.Config})
+	progress := ProgressWrapper(res)
+
+	isPostgreSQL := strings.HasPrefix(cfg.SourceID.ValueString(), "postgresql_")
+	opts := cfg.Options(ctx, isPostgreSQL, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	var copier Copier
+	if isPostgreSQL {
+		copier = a.pgCopier(ctx, cfg, progress, &res.Diagnostics)
+	} else {
+		copier = a.mysqlCopier(ctx, cfg, progress, &res.Diagnostics)
+	}
+	if res.Diagnostics.HasError() || copier == nil {
+		return
+	}
+	defer func() {
+		progress("Closing database connections")
+		if err := copier.Close(ctx); err != nil {
+			res.Diagnostics.AddWarning("failed to close database connections", err.Error())
+		}
+	}()
+
+	RunCopy(ctx, copier, opts, progress, &res.Diagnostics)
+}
+
+// Options reads the filters and anonymization rules,
+// rules tables are qualified with the public schema on PostgreSQL
+func (cfg *databaseCopy) Options(ctx context.Context, isPostgreSQL bool, diags *diag.Diagnostics) CopyOptions {
+	opts := CopyOptions{
+		Truncate:  cfg.Truncate.IsNull() || cfg.Truncate.ValueBool(),
+		Anonymize: map[string]map[string]string{},
+	}
+	rules := []AnonymizeRule{}
+	diags.Append(cfg.IncludeTables.ElementsAs(ctx, &opts.Include, false)...)
+	diags.Append(cfg.ExcludeTables.ElementsAs(ctx, &opts.Exclude, false)...)
+	diags.Append(cfg.Anonymize.ElementsAs(ctx, &rules, false)...)
+
+	for _, rule := range rules {
+		table := rule.Table.ValueString()
+		if isPostgreSQL && !strings.Contains(table, ".") {
+			table = "public." + table
+		}
+
+		if opts.Anonymize[table] == nil {
+			opts.Anonymize[table] = map[string]string{}
+		}
+		if _, ok := opts.Anonymize[table][rule.Column.ValueString()]; ok {
+			diags.AddError("duplicated anonymization rule", fmt.Sprintf("column %s of %s has several rules", rule.Column.ValueString(), table))
+		}
+		opts.Anonymize[table][rule.Column.ValueString()] = rule.Expression.ValueString()
+	}
+
+	return opts
+}
+
+// FilterTables keeps the tables matching an include pattern (all when there is none)
+// and no exclude pattern
+func FilterTables(tables []Table, include, exclude []string) []Table {
+	return pkg.Filter(tables, func(table Table) bool {
+		included := len(include) == 0 || slices.ContainsFunc(include, table.Matches)
+		return included && !slices.ContainsFunc(exclude, table.Matches)
+	})
+}
+
+// RunCopy copies the schema then the data of the selected tables.
+// Nothing is written if an anonymization rule targets a table or a column which is not copied,
+// to avoid leaking the data it was meant to hide.
+func RunCopy(ctx context.Context, copier Copier, opts CopyOptions, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	progress("Listing source tables")
+	tables, err := copier.Tables(ctx)
+	if err != nil {
+		diags.AddError("failed to list source tables", err.Error())
+		return
+	}
+
+	tables = FilterTables(tables, opts.Include, opts.Exclude)
+	if len(tables) == 0 {
+		diags.AddError("no table to copy", "no source table matches the include and exclude patterns")
+		return
+	}
+
+	columns := map[Table][]string{}
+	for _, table := range tables {
+		if columns[table], err = copier.Columns(ctx, table); err != nil {
+			diags.AddError(fmt.Sprintf("failed to list columns of %s", table), err.Error())
+			return
+		}
+	}
+
+	for name, rules := range opts.Anonymize {
+		idx := slices.IndexFunc(tables, func(table Table) bool { return table.String() == name })
+		if idx == -1 {
+			diags.AddError("anonymized table is not copied", fmt.Sprintf("table %s does not exist or is filtered out", name))
+			continue
+		}
+
+		for column := range rules {
+			if !slices.Contains(columns[tables[idx]], column) {
+				diags.AddError("anonymized column not found", fmt.Sprintf("table %s has no column %s", name, column))
+			}
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	progress("Creating schema")
+	if err := copier.CreateSchema(ctx, tables); err != nil {
+		diags.AddError("failed to create schema", err.Error())
+		return
+	}
+
+	if opts.Truncate {
+		progress("Truncating target tables")
+		if err := copier.Truncate(ctx, tables); err != nil {
+			diags.AddError("failed to truncate target tables", err.Error())
+			return
+		}
+	}
+
+	total := int64(0)
+	for i, table := range tables {
+		progress("Copying %s (%d/%d)", table, i+1, len(tables))
+
+		rows, err := copier.CopyData(ctx, table, columns[table], opts.Anonymize[table.String()])
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to copy %s", table), err.Error())
+			return
+		}
+		total += rows
+	}
+
+	progress("Restoring constraints, indexes and sequences")
+	if err := copier.Finish(ctx, tables); err != nil {
+		diags.AddError("failed to finish copy", err.Error())
+		return
+	}
+
+	progress("%d row(s) copied in %d table(s)", total, len(tables))
+}
+
+// SortTables orders the tables so that referenced tables come first,
+// references to other tables and cycles are ignored
+func SortTables(tables []Table, references map[Table][]Table) []Table {
+	sorted := make([]Table, 0, len(tables))
+	visited := map[Table]bool{}
+	selected := map[Table]bool{}
+	for _, table := range tables {
+		selected[table] = true
+	}
+
+	var visit func(table Table)
+	visit = func(table Table) {
+		if visited[table] || !selected[table] {
+			return
+		}
+		visited[table] = true
+
+		for _, referenced := range references[table] {
+			visit(referenced)
+		}
+		sorted = append(sorted, table)
+	}
+
+	for _, table := range tables {
+		visit(table)
+	}
+
+	return sorted
+}
+
+// selectList builds the source SELECT columns, using the expression of anonymized columns
+func selectList(columns []string, expressions map[string]string, ident func(...string) string) string {
+	selects := make([]string, len(columns))
+	for i, column := range columns {
+		if expression, ok := expressions[column]; ok {
+			selects[i] = fmt.Sprintf("(%s) AS %s", expression, ident(column))
+		} else {
+			selects[i] = ident(column)
+		}
+	}
+	return strings.Join(selects, ", ")
+}
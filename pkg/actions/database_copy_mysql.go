@@ -0,0 +1,203 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+const (
+	// rows sent per INSERT statement
+	mysqlCopyBatch = 500
+	// placeholders limit of a prepared statement
+	mysqlMaxPlaceholders = 65535
+)
+
+func (a *ActionDatabaseCopy) mysqlCopier(ctx context.Context, cfg *databaseCopy, progress func(msg string, args ...any), diags *diag.Diagnostics) Copier {
+	progress("Opening source database connection")
+	source, err := mysqldb.Connect(ctx, a.Client(), a.Organization(), cfg.SourceID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to source database", err.Error())
+		return nil
+	}
+
+	progress("Opening target database connection")
+	target, err := mysqldb.Connect(ctx, a.Client(), a.Organization(), cfg.TargetID.ValueString())
+	if err != nil {
+		_ = source.Close()
+		diags.AddError("failed to connect to target database", err.Error())
+		return nil
+	}
+
+	// session settings need a single connection
+	conn, err := target.Conn(ctx)
+	if err == nil {
+		if _, err = conn.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`); err != nil {
+			_ = conn.Close()
+		}
+	}
+	if err != nil {
+		_ = source.Close()
+		_ = target.Close()
+		diags.AddError("failed to prepare target database", err.Error())
+		return nil
+	}
+
+	return &mysqlCopier{source: source.DB, targetDB: target.DB, target: conn}
+}
+
+type mysqlCopier struct {
+	source   *sql.DB
+	targetDB *sql.DB
+	// connection without foreign key checks, tables can be filled in any order
+	target *sql.Conn
+}
+
+func (c *mysqlCopier) Tables(ctx context.Context) ([]Table, error) {
+	rows, err := c.source.QueryContext(ctx, `
+		SELECT TABLE_NAME FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'
+		ORDER BY TABLE_NAME`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := []Table{}
+	for rows.Next() {
+		table := Table{}
+		if err := rows.Scan(&table.Name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, rows.Err()
+}
+
+func (c *mysqlCopier) Columns(ctx context.Context, table Table) ([]string, error) {
+	rows, err := c.source.QueryContext(ctx, `
+		SELECT COLUMN_NAME FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND EXTRA NOT LIKE '%GENERATED%'
+		ORDER BY ORDINAL_POSITION`, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := []string{}
+	for rows.Next() {
+		column := ""
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
+
+// CreateSchema replays SHOW CREATE TABLE, keys and indexes included:
+// foreign keys are not checked on the copy connection
+func (c *mysqlCopier) CreateSchema(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		var name, statement string
+		if err := c.source.QueryRowContext(ctx, `SHOW CREATE TABLE `+mysqldb.Ident(table.Name)).Scan(&name, &statement); err != nil {
+			return err
+		}
+
+		statement = strings.Replace(statement, "CREATE TABLE ", "CREATE TABLE IF NOT EXISTS ", 1)
+		if _, err := c.target.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *mysqlCopier) Truncate(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		if _, err := c.target.ExecContext(ctx, `TRUNCATE TABLE `+mysqldb.Ident(table.Name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *mysqlCopier) CopyData(ctx context.Context, table Table, columns []string, expressions map[string]string) (int64, error) {
+	rows, err := c.source.QueryContext(ctx, fmt.Sprintf(
+		`SELECT %s FROM %s`, selectList(columns, expressions, mysqldb.Ident), mysqldb.Ident(table.Name),
+	))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	batch := min(mysqlCopyBatch, mysqlMaxPlaceholders/len(columns))
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	insert := fmt.Sprintf(`INSERT INTO %s (%s) VALUES `, mysqldb.Ident(table.Name), mysqldb.Idents(columns))
+
+	copied := int64(0)
+	args := make([]any, 0, batch*len(columns))
+	flush := func() error {
+		if len(args) == 0 {
+			return nil
+		}
+
+		count := len(args) / len(columns)
+		statement := insert + strings.TrimSuffix(strings.Repeat(placeholders+", ", count), ", ")
+		if _, err := c.target.ExecContext(ctx, statement, args...); err != nil {
+			return err
+		}
+
+		copied += int64(count)
+		args = args[:0]
+		return nil
+	}
+
+	raw := make([]sql.RawBytes, len(columns))
+	dest := make([]any, len(columns))
+	for i := range raw {
+		dest[i] = &raw[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return copied, err
+		}
+
+		// RawBytes are reused by the next row
+		for _, value := range raw {
+			if value == nil {
+				args = append(args, nil)
+			} else {
+				args = append(args, bytes.Clone(value))
+			}
+		}
+
+		if len(args) >= batch*len(columns) {
+			if err := flush(); err != nil {
+				return copied, err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return copied, err
+	}
+
+	return copied, flush()
+}
+
+// Finish has nothing to restore: keys come with the tables
+// and AUTO_INCREMENT counters follow the inserted values
+func (c *mysqlCopier) Finish(ctx context.Context, tables []Table) error {
+	return nil
+}
+
+func (c *mysqlCopier) Close(ctx context.Context) error {
+	return errors.Join(c.target.Close(), c.targetDB.Close(), c.source.Close())
+}
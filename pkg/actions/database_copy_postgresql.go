@@ -0,0 +1,353 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+// nextval('users_id_seq'::regclass) default of serial columns
+var nextvalRegex = regexp.MustCompile(`^nextval\('(.+)'::regclass\)$`)
+
+func (a *ActionDatabaseCopy) pgCopier(ctx context.Context, cfg *databaseCopy, progress func(msg string, args ...any), diags *diag.Diagnostics) Copier {
+	progress("Opening source database connection")
+	source, err := pgsql.Connect(ctx, a.Client(), a.Organization(), cfg.SourceID.ValueString(), "")
+	if err != nil {
+		diags.AddError("failed to connect to source database", err.Error())
+		return nil
+	}
+
+	progress("Opening target database connection")
+	target, err := pgsql.Connect(ctx, a.Client(), a.Organization(), cfg.TargetID.ValueString(), "")
+	if err != nil {
+		_ = source.Close(ctx)
+		diags.AddError("failed to connect to target database", err.Error())
+		return nil
+	}
+
+	return &pgCopier{source: source.Conn, target: target.Conn, created: map[Table]bool{}}
+}
+
+type pgCopier struct {
+	source *pgx.Conn
+	target *pgx.Conn
+	// tables created by CreateSchema, which need their constraints and indexes
+	created map[Table]bool
+}
+
+type pgColumn struct {
+	Name       string
+	Type       string
+	NotNull    bool
+	Default    *string
+	Identity   string
+	Generated  string
+	Expression *string
+}
+
+func (c *pgCopier) Tables(ctx context.Context) ([]Table, error) {
+	rows, err := c.source.Query(ctx, `
+		SELECT n.nspname, c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r' AND NOT c.relispartition
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg\_%'
+		ORDER BY n.nspname, c.relname`)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Table, error) {
+		table := Table{}
+		err := row.Scan(&table.Schema, &table.Name)
+		return table, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err = c.source.Query(ctx, `
+		SELECT cn.nspname, cc.relname, fn.nspname, fc.relname
+		FROM pg_constraint k
+		JOIN pg_class cc ON cc.oid = k.conrelid
+		JOIN pg_namespace cn ON cn.oid = cc.relnamespace
+		JOIN pg_class fc ON fc.oid = k.confrelid
+		JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+		WHERE k.contype = 'f'`)
+	if err != nil {
+		return nil, err
+	}
+
+	references := map[Table][]Table{}
+	var table, referenced Table
+	_, err = pgx.ForEachRow(rows, []any{&table.Schema, &table.Name, &referenced.Schema, &referenced.Name}, func() error {
+		references[table] = append(references[table], referenced)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return SortTables(tables, references), nil
+}
+
+func (c *pgCopier) columns(ctx context.Context, table Table) ([]pgColumn, error) {
+	rows, err := c.source.Query(ctx, `
+		SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+			CASE WHEN a.attgenerated = '' THEN pg_get_expr(d.adbin, d.adrelid) END,
+			a.attidentity::text, a.attgenerated::text,
+			CASE WHEN a.attgenerated <> '' THEN pg_get_expr(d.adbin, d.adrelid) END
+		FROM pg_attribute a
+		LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = $1::regclass AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum`, pgsql.Ident(table.Schema, table.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (pgColumn, error) {
+		column := pgColumn{}
+		err := row.Scan(&column.Name, &column.Type, &column.NotNull, &column.Default, &column.Identity, &column.Generated, &column.Expression)
+		return column, err
+	})
+}
+
+func (c *pgCopier) Columns(ctx context.Context, table Table) ([]string, error) {
+	columns, err := c.columns(ctx, table)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, column := range columns {
+		if column.Generated == "" {
+			names = append(names, column.Name)
+		}
+	}
+	return names, nil
+}
+
+func (c *pgCopier) CreateSchema(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		exists := false
+		if err := c.target.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, pgsql.Ident(table.Schema, table.Name)).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		columns, err := c.columns(ctx, table)
+		if err != nil {
+			return err
+		}
+
+		statements := []string{fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, pgsql.Ident(table.Schema))}
+		definitions := make([]string, len(columns))
+		owned := []string{}
+		for i, column := range columns {
+			definition := pgsql.Ident(column.Name) + " " + column.Type
+			switch {
+			case column.Identity == "a":
+				definition += " GENERATED ALWAYS AS IDENTITY"
+			case column.Identity == "d":
+				definition += " GENERATED BY DEFAULT AS IDENTITY"
+			case column.Expression != nil:
+				definition += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", *column.Expression)
+			case column.Default != nil:
+				// serial columns need their sequence before the table
+				if matches := nextvalRegex.FindStringSubmatch(*column.Default); matches != nil {
+					statements = append(statements, fmt.Sprintf(`CREATE SEQUENCE IF NOT EXISTS %s`, matches[1]))
+					owned = append(owned, fmt.Sprintf(`ALTER SEQUENCE %s OWNED BY %s.%s`, matches[1], pgsql.Ident(table.Schema, table.Name), pgsql.Ident(column.Name)))
+				}
+				definition += " DEFAULT " + *column.Default
+			}
+			if column.NotNull {
+				definition += " NOT NULL"
+			}
+			definitions[i] = definition
+		}
+		statements = append(statements, fmt.Sprintf(`CREATE TABLE %s (%s)`, pgsql.Ident(table.Schema, table.Name), strings.Join(definitions, ", ")))
+		statements = append(statements, owned...)
+
+		err = pgx.BeginFunc(ctx, c.target, func(tx pgx.Tx) error {
+			for _, statement := range statements {
+				if _, err := tx.Exec(ctx, statement); err != nil {
+					return fmt.Errorf("failed to create %s: %w", table, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		c.created[table] = true
+	}
+
+	return nil
+}
+
+func (c *pgCopier) Truncate(ctx context.Context, tables []Table) error {
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = pgsql.Ident(table.Schema, table.Name)
+	}
+
+	// a single statement for the tables referencing each other
+	_, err := c.target.Exec(ctx, `TRUNCATE TABLE `+strings.Join(names, ", "))
+	return err
+}
+
+func (c *pgCopier) CopyData(ctx context.Context, table Table, columns []string, expressions map[string]string) (int64, error) {
+	name := pgsql.Ident(table.Schema, table.Name)
+	reader, writer := io.Pipe()
+
+	copied := make(chan error, 1)
+	go func() {
+		_, err := c.source.PgConn().CopyTo(ctx, writer, fmt.Sprintf(
+			`COPY (SELECT %s FROM %s) TO STDOUT`, selectList(columns, expressions, pgsql.Ident), name,
+		))
+		writer.CloseWithError(err)
+		copied <- err
+	}()
+
+	tag, err := c.target.PgConn().CopyFrom(ctx, reader, fmt.Sprintf(`COPY %s (%s) FROM STDIN`, name, pgsql.Idents(columns)))
+	// unblock the source when the target fails
+	reader.CloseWithError(err)
+	if sourceErr := <-copied; sourceErr != nil && err == nil {
+		err = sourceErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (c *pgCopier) Finish(ctx context.Context, tables []Table) error {
+	statements := []string{}
+	foreignKeys := []string{}
+
+	for _, table := range tables {
+		if !c.created[table] {
+			continue
+		}
+		name := pgsql.Ident(table.Schema, table.Name)
+
+		rows, err := c.source.Query(ctx, `
+			SELECT k.conname, k.contype::text, pg_get_constraintdef(k.oid), fn.nspname, fc.relname
+			FROM pg_constraint k
+			LEFT JOIN pg_class fc ON fc.oid = k.confrelid
+			LEFT JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+			WHERE k.conrelid = $1::regclass AND k.contype IN ('p', 'u', 'c', 'x', 'f')
+			ORDER BY k.conname`, name)
+		if err != nil {
+			return err
+		}
+
+		var constraint, kind, definition string
+		var referencedSchema, referencedName *string
+		_, err = pgx.ForEachRow(rows, []any{&constraint, &kind, &definition, &referencedSchema, &referencedName}, func() error {
+			statement := fmt.Sprintf(`ALTER TABLE %s ADD CONSTRAINT %s %s`, name, pgsql.Ident(constraint), definition)
+			if kind != "f" {
+				statements = append(statements, statement)
+				return nil
+			}
+
+			// keys referencing a table which is not copied would fail
+			referenced := Table{Schema: *referencedSchema, Name: *referencedName}
+			for _, t := range tables {
+				if t == referenced {
+					foreignKeys = append(foreignKeys, statement)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// indexes backing a constraint are created with it
+		rows, err = c.source.Query(ctx, `
+			SELECT pg_get_indexdef(i.indexrelid)
+			FROM pg_index i
+			WHERE i.indrelid = $1::regclass
+			AND NOT EXISTS (SELECT 1 FROM pg_constraint k WHERE k.conindid = i.indexrelid)`, name)
+		if err != nil {
+			return err
+		}
+		indexes, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return err
+		}
+		for _, index := range indexes {
+			statements = append(statements, indexIfNotExists(index))
+		}
+	}
+
+	for _, statement := range append(statements, foreignKeys...) {
+		if _, err := c.target.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("%s: %w", statement, err)
+		}
+	}
+
+	return c.syncSequences(ctx, tables)
+}
+
+// syncSequences moves the sequences of serial and identity columns after the copied values
+func (c *pgCopier) syncSequences(ctx context.Context, tables []Table) error {
+	for _, table := range tables {
+		name := pgsql.Ident(table.Schema, table.Name)
+
+		rows, err := c.target.Query(ctx, `
+			SELECT a.attname, pg_get_serial_sequence($1, a.attname)
+			FROM pg_attribute a
+			WHERE a.attrelid = $1::text::regclass AND a.attnum > 0 AND NOT a.attisdropped
+			AND pg_get_serial_sequence($1, a.attname) IS NOT NULL`, name)
+		if err != nil {
+			return err
+		}
+
+		type sequence struct{ column, name string }
+		sequences, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (sequence, error) {
+			s := sequence{}
+			err := row.Scan(&s.column, &s.name)
+			return s, err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range sequences {
+			_, err := c.target.Exec(ctx, fmt.Sprintf(
+				`SELECT setval(%s, COALESCE(MAX(%s), 0) + 1, false) FROM %s`,
+				pgsql.Literal(s.name), pgsql.Ident(s.column), name,
+			))
+			if err != nil {
+				return fmt.Errorf("failed to update sequence %s: %w", s.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *pgCopier) Close(ctx context.Context) error {
+	return errors.Join(c.source.Close(ctx), c.target.Close(ctx))
+}
+
+// indexIfNotExists makes a pg_get_indexdef statement idempotent
+func indexIfNotExists(definition string) string {
+	for _, prefix := range []string{"CREATE UNIQUE INDEX ", "CREATE INDEX "} {
+		if strings.HasPrefix(definition, prefix) {
+			return prefix + "IF NOT EXISTS " + strings.TrimPrefix(definition, prefix)
+		}
+	}
+	return definition
+}
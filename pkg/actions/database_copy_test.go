@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCopier struct {
+	tables  []Table
+	columns map[Table][]string
+	failOn  Table
+	// calls in order
+	calls []string
+	// anonymized columns per copied table
+	copied map[Table]map[string]string
+}
+
+func (c *fakeCopier) Tables(ctx context.Context) ([]Table, error) { return c.tables, nil }
+
+func (c *fakeCopier) Columns(ctx context.Context, table Table) ([]string, error) {
+	return c.columns[table], nil
+}
+
+func (c *fakeCopier) CreateSchema(ctx context.Context, tables []Table) error {
+	c.calls = append(c.calls, "schema")
+	return nil
+}
+
+func (c *fakeCopier) Truncate(ctx context.Context, tables []Table) error {
+	c.calls = append(c.calls, "truncate")
+	return nil
+}
+
+func (c *fakeCopier) CopyData(ctx context.Context, table Table, columns []string, expressions map[string]string) (int64, error) {
+	if table == c.failOn {
+		return 0, errors.New("permission denied")
+	}
+	c.calls = append(c.calls, "copy "+table.String())
+	c.copied[table] = expressions
+	return 10, nil
+}
+
+func (c *fakeCopier) Finish(ctx context.Context, tables []Table) error {
+	c.calls = append(c.calls, "finish")
+	return nil
+}
+
+func (c *fakeCopier) Close(ctx context.Context) error { return nil }
+
+var (
+	usersTable    = Table{Schema: "public", Name: "users"}
+	ordersTable   = Table{Schema: "public", Name: "orders"}
+	auditLogTable = Table{Schema: "public", Name: "audit_log"}
+	appUsersTable = Table{Schema: "app", Name: "users"}
+)
+
+func newFakeCopier() *fakeCopier {
+	return &fakeCopier{
+		tables: []Table{usersTable, ordersTable, auditLogTable},
+		columns: map[Table][]string{
+			usersTable:    {"id", "email"},
+			ordersTable:   {"id", "user_id"},
+			auditLogTable: {"id", "event"},
+		},
+		copied: map[Table]map[string]string{},
+	}
+}
+
+func noProgress(msg string, args ...any) {}
+
+func TestTableMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   Table
+		pattern string
+		matches bool
+	}{
+		{"exact name", usersTable, "users", true},
+		{"name in any schema", appUsersTable, "users", true},
+		{"qualified", appUsersTable, "app.users", true},
+		{"other schema", usersTable, "app.*", false},
+		{"glob", auditLogTable, "audit_*", true},
+		{"mysql table", Table{Name: "users"}, "user?", true},
+		{"invalid pattern", usersTable, "[", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.matches, tt.table.Matches(tt.pattern))
+		})
+	}
+}
+
+func TestFilterTables(t *testing.T) {
+	tables := []Table{usersTable, ordersTable, auditLogTable, appUsersTable}
+
+	assert.Equal(t, tables, FilterTables(tables, nil, nil))
+	assert.Equal(t, []Table{usersTable, appUsersTable}, FilterTables(tables, []string{"users"}, nil))
+	assert.Equal(t, []Table{usersTable, ordersTable}, FilterTables(tables, []string{"public.*"}, []string{"audit_*"}))
+	assert.Empty(t, FilterTables(tables, []string{"missing"}, nil))
+}
+
+func TestSortTables(t *testing.T) {
+	itemsTable := Table{Schema: "public", Name: "items"}
+	references := map[Table][]Table{
+		itemsTable:  {ordersTable},
+		ordersTable: {usersTable, auditLogTable},
+		// cycle
+		usersTable: {itemsTable},
+	}
+
+	sorted := SortTables([]Table{itemsTable, ordersTable, usersTable}, references)
+	require.Len(t, sorted, 3)
+	assert.Equal(t, []Table{usersTable, ordersTable, itemsTable}, sorted)
+}
+
+func TestRunCopy(t *testing.T) {
+	ctx := context.Background()
+	copier := newFakeCopier()
+	diags := diag.Diagnostics{}
+
+	RunCopy(ctx, copier, CopyOptions{
+		Exclude:   []string{"audit_*"},
+		Truncate:  true,
+		Anonymize: map[string]map[string]string{"public.users": {"email": "md5(email)"}},
+	}, noProgress, &diags)
+
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"schema", "truncate", "copy public.users", "copy public.orders", "finish"}, copier.calls)
+	assert.Equal(t, map[string]string{"email": "md5(email)"}, copier.copied[usersTable])
+	assert.Nil(t, copier.copied[ordersTable])
+}
+
+func TestRunCopy_WithoutTruncate(t *testing.T) {
+	copier := newFakeCopier()
+	diags := diag.Diagnostics{}
+
+	RunCopy(context.Background(), copier, CopyOptions{Include: []string{"users"}}, noProgress, &diags)
+
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, []string{"schema", "copy public.users", "finish"}, copier.calls)
+}
+
+func TestRunCopy_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymize map[string]map[string]string
+	}{
+		{"excluded table", map[string]map[string]string{"public.audit_log": {"event": "NULL"}}},
+		{"unknown table", map[string]map[string]string{"public.customers": {"email": "NULL"}}},
+		{"unknown column", map[string]map[string]string{"public.users": {"phone": "NULL"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copier := newFakeCopier()
+			diags := diag.Diagnostics{}
+
+			RunCopy(context.Background(), copier, CopyOptions{
+				Exclude:   []string{"audit_*"},
+				Truncate:  true,
+				Anonymize: tt.anonymize,
+			}, noProgress, &diags)
+
+			assert.True(t, diags.HasError())
+			assert.Empty(t, copier.calls, "nothing must be written")
+		})
+	}
+}
+
+func TestRunCopy_NoTable(t *testing.T) {
+	copier := newFakeCopier()
+	diags := diag.Diagnostics{}
+
+	RunCopy(context.Background(), copier, CopyOptions{Include: []string{"missing"}}, noProgress, &diags)
+
+	assert.True(t, diags.HasError())
+	assert.Empty(t, copier.calls)
+}
+
+func TestRunCopy_Failure(t *testing.T) {
+	copier := newFakeCopier()
+	copier.failOn = ordersTable
+	diags := diag.Diagnostics{}
+
+	RunCopy(context.Background(), copier, CopyOptions{Truncate: true}, noProgress, &diags)
+
+	require.True(t, diags.HasError())
+	assert.Equal(t, "failed to copy public.orders", diags.Errors()[0].Summary())
+	assert.NotContains(t, copier.calls, "finish")
+}
+
+func TestSelectList(t *testing.T) {
+	assert.Equal(t,
+		`"id", (md5(email)) AS "email"`,
+		selectList([]string{"id", "email"}, map[string]string{"email": "md5(email)"}, func(parts ...string) string { return `"` + parts[0] + `"` }),
+	)
+}
+
+func TestIndexIfNotExists(t *testing.T) {
+	assert.Equal(t,
+		"CREATE UNIQUE INDEX IF NOT EXISTS users_email ON public.users USING btree (email)",
+		indexIfNotExists("CREATE UNIQUE INDEX users_email ON public.users USING btree (email)"),
+	)
+	assert.Equal(t,
+		"CREATE INDEX IF NOT EXISTS orders_user ON public.orders USING btree (user_id)",
+		indexIfNotExists("CREATE INDEX orders_user ON public.orders USING btree (user_id)"),
+	)
+}
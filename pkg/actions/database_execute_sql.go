@@ -3,24 +3,28 @@ package actions
 import (
 	"context"
 	_ "embed"
-	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
-	"time"
 
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	_ "github.com/lib/pq"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 	"go.clever-cloud.com/terraform-provider/pkg/provider"
+	"go.clever-cloud.com/terraform-provider/pkg/retry"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
 )
 
@@ -33,11 +37,86 @@ type ActionExecuteDatabaseSQL struct {
 }
 
 type DatabaseQuery struct {
-	DatabaseID types.String `tfsdk:"database_id"`
-	Query      types.String `tfsdk:"query"`
-	OutputJSON types.String `tfsdk:"output_json"`
+	DatabaseID   types.String `tfsdk:"database_id"`
+	Query        types.String `tfsdk:"query"`
+	Parameters   types.List   `tfsdk:"parameters"`
+	Transaction  types.Bool   `tfsdk:"transaction"`
+	Collection   types.String `tfsdk:"collection"`
+	OutputJSON   types.String `tfsdk:"output_json"`
+	Output       types.String `tfsdk:"output"`
+	OutputFormat types.String `tfsdk:"output_format"`
+	ExpectRows   types.Int64  `tfsdk:"expect_rows"`
 }
 
+// Args returns the query parameters, null elements are sent as NULL
+func (q *DatabaseQuery) Args(ctx context.Context, diags *diag.Diagnostics) []any {
+	if q.Parameters.IsNull() || q.Parameters.IsUnknown() {
+		return nil
+	}
+
+	parameters := []types.String{}
+	diags.Append(q.Parameters.ElementsAs(ctx, &parameters, false)...)
+
+	args := make([]any, len(parameters))
+	for i, parameter := range parameters {
+		if !parameter.IsNull() {
+			args[i] = parameter.ValueString()
+		}
+	}
+	return args
+}
+
+// OutputPath returns the file to write the result into, if any
+func (q *DatabaseQuery) OutputPath() (string, bool) {
+	if !q.Output.IsNull() && !q.Output.IsUnknown() {
+		return q.Output.ValueString(), true
+	}
+	if !q.OutputJSON.IsNull() && !q.OutputJSON.IsUnknown() {
+		return q.OutputJSON.ValueString(), true
+	}
+	return "", false
+}
+
+// Format returns the output format, output_json is always JSON
+func (q *DatabaseQuery) Format() string {
+	if q.OutputFormat.IsNull() || q.OutputFormat.IsUnknown() || q.Output.IsNull() {
+		return OutputFormatJSON
+	}
+	return q.OutputFormat.ValueString()
+}
+
+// CheckRows reports an error when expect_rows is set and does not match
+func (q *DatabaseQuery) CheckRows(rows int64, diags *diag.Diagnostics) {
+	if q.ExpectRows.IsNull() || q.ExpectRows.IsUnknown() || q.ExpectRows.ValueInt64() == rows {
+		return
+	}
+
+	diags.AddAttributeError(
+		path.Root("expect_rows"),
+		"unexpected number of rows",
+		fmt.Sprintf("expect %d rows, query returned or affected %d rows", q.ExpectRows.ValueInt64(), rows),
+	)
+}
+
+// WriteResult encodes the result in the configured format and writes it
+func (q *DatabaseQuery) WriteResult(result *QueryResult, diags *diag.Diagnostics) {
+	output, ok := q.OutputPath()
+	if !ok {
+		return
+	}
+
+	data, err := result.Encode(q.Format())
+	if err != nil {
+		diags.AddError("failed to serialize result", err.Error())
+		return
+	}
+
+	WriteOutput(output, data, diags)
+}
+
+// prefixes of the database IDs supported by the action
+var databaseQueryPrefixes = []string{"postgresql_", "mysql_", "mongodb_"}
+
 func (a *ActionExecuteDatabaseSQL) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
 	tflog.Debug(ctx, "Configure()")
 
@@ -73,36 +152,96 @@ func (a *ActionExecuteDatabaseSQL) Schema(ctx context.Context, req action.Schema
 								return
 							}
 
-							if !strings.HasPrefix(req.ConfigValue.ValueString(), "postgresql") {
-								res.Diagnostics.AddError("expect a valid addon ID", "ID doesn't start with 'postgres_'")
+							if !slices.ContainsFunc(databaseQueryPrefixes, func(prefix string) bool {
+								return strings.HasPrefix(req.ConfigValue.ValueString(), prefix)
+							}) {
+								res.Diagnostics.AddError("expect a valid addon ID", fmt.Sprintf("ID doesn't start with one of %s", strings.Join(databaseQueryPrefixes, ", ")))
 							}
 						},
 					),
 				},
 			},
-			"query": schema.StringAttribute{Required: true, Description: "SQL query to execute"},
+			"query": schema.StringAttribute{Required: true, Description: "SQL query to execute, for MongoDB a command document or an aggregation pipeline (extended JSON)"},
+			"collection": schema.StringAttribute{
+				Optional:    true,
+				Description: "MongoDB only: run query as an aggregation pipeline on this collection instead of a database command",
+			},
 			"output_json": schema.StringAttribute{
+				Optional:           true,
+				Description:        "file path and name to write query result into, starts with file://",
+				DeprecationMessage: "use output instead",
+				Validators:         []validator.String{fileOutputValidator},
+			},
+			"output": schema.StringAttribute{
 				Optional:    true,
 				Description: "file path and name to write query result into, starts with file://",
-				Validators: []validator.String{pkg.NewStringValidator("must starts with file://", func(ctx context.Context, req validator.StringRequest, res *validator.StringResponse) {
-					if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
-						return
-					}
-
-					if !strings.HasPrefix(req.ConfigValue.ValueString(), "file://") {
-						res.Diagnostics.AddAttributeError(req.Path, "expect file:// as prefix", "unexpected prefix")
-					}
-				})},
+				Validators:  []validator.String{fileOutputValidator},
+			},
+			"output_format": schema.StringAttribute{
+				Optional:    true,
+				Description: "format of the output file: json (default), csv or ndjson",
+				Validators:  []validator.String{stringvalidator.OneOf(outputFormats...)},
+			},
+			"parameters": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "SQL only: values bound to the query placeholders ($1, $2... on PostgreSQL, ? on MySQL), a null element is sent as NULL. The query must be a single statement",
+			},
+			"transaction": schema.BoolAttribute{
+				Optional:    true,
+				Description: "SQL only: run the query in a transaction, rolled back when a statement or expect_rows fails",
+			},
+			"expect_rows": schema.Int64Attribute{
+				Optional:    true,
+				Description: "fail the action unless the query returns (with an output) or affects this number of rows, or documents on MongoDB",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
 			},
 		},
 	}
 }
 
+var fileOutputValidator = pkg.NewStringValidator("must starts with file://", func(ctx context.Context, req validator.StringRequest, res *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !strings.HasPrefix(req.ConfigValue.ValueString(), "file://") {
+		res.Diagnostics.AddAttributeError(req.Path, "expect file:// as prefix", "unexpected prefix")
+	}
+})
+
 func (a *ActionExecuteDatabaseSQL) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
 	res.TypeName = req.ProviderTypeName + "_database_query"
 }
 
-//func (a *ActionExecuteDatabaseSQL) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, res *action.ValidateConfigResponse) {}
+func (a *ActionExecuteDatabaseSQL) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, res *action.ValidateConfigResponse) {
+	cfg := helper.From[DatabaseQuery](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if !cfg.Output.IsNull() && !cfg.OutputJSON.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("output_json"), "conflicting outputs", "output_json is deprecated, only set output")
+	}
+	if !cfg.OutputFormat.IsNull() && cfg.Output.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("output_format"), "output_format requires output", "set the file to write the result into with output")
+	}
+
+	if cfg.DatabaseID.IsUnknown() {
+		return
+	}
+	isMongoDB := strings.HasPrefix(cfg.DatabaseID.ValueString(), "mongodb_")
+
+	if !cfg.Collection.IsNull() && !isMongoDB {
+		res.Diagnostics.AddAttributeError(path.Root("collection"), "collection is only supported on MongoDB", "remove collection or use a mongodb_ database ID")
+	}
+	if !cfg.Parameters.IsNull() && isMongoDB {
+		res.Diagnostics.AddAttributeError(path.Root("parameters"), "parameters are not supported on MongoDB", "write values in the extended JSON query")
+	}
+	if !cfg.Transaction.IsNull() && isMongoDB {
+		res.Diagnostics.AddAttributeError(path.Root("transaction"), "transaction is not supported on MongoDB", "remove transaction")
+	}
+}
 
 func (a *ActionExecuteDatabaseSQL) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
 	cfg := helper.From[DatabaseQuery](ctx, req.Config, &res.Diagnostics)
@@ -114,6 +253,10 @@ func (a *ActionExecuteDatabaseSQL) Invoke(ctx context.Context, req action.Invoke
 	switch {
 	case strings.HasPrefix(cfg.DatabaseID.ValueString(), "postgresql_"):
 		a.InvokePG(ctx, cfg, ProgressWrapper(res), &res.Diagnostics)
+	case strings.HasPrefix(cfg.DatabaseID.ValueString(), "mysql_"):
+		a.InvokeMySQL(ctx, cfg, ProgressWrapper(res), &res.Diagnostics)
+	case strings.HasPrefix(cfg.DatabaseID.ValueString(), "mongodb_"):
+		a.InvokeMongoDB(ctx, cfg, ProgressWrapper(res), &res.Diagnostics)
 	}
 }
 
@@ -150,59 +293,101 @@ func (a *ActionExecuteDatabaseSQL) InvokePG(ctx context.Context, cfg *DatabaseQu
 		}
 	}()
 
-	if cfg.OutputJSON.IsNull() || cfg.OutputJSON.IsUnknown() {
-		// no output, multiple statements allowed
-		progress("Executing database query")
-		result, err := conn.Exec(ctx, query)
+	args := cfg.Args(ctx, diags)
+	if diags.HasError() {
+		return
+	}
+
+	var db pgQuerier = conn
+	if cfg.Transaction.ValueBool() {
+		progress("Starting transaction")
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			diags.AddError("failed to start transaction", err.Error())
+			return
+		}
+		// no-op once committed
+		defer func() { _ = tx.Rollback(ctx) }()
+		db = tx
+	}
+
+	result := &QueryResult{}
+	progress("Executing database query")
+	if _, withOutput := cfg.OutputPath(); !withOutput {
+		// without parameters, multiple statements allowed
+		tag, err := db.Exec(ctx, query, args...)
 		if err != nil {
 			diags.AddError("failed execute query", err.Error())
 			return
 		}
 		tflog.Debug(ctx, "executed statement", map[string]any{
-			"rows": result.RowsAffected(),
-			"str":  result.String(),
+			"rows": tag.RowsAffected(),
+			"str":  tag.String(),
 		})
-		return
+		cfg.CheckRows(tag.RowsAffected(), diags)
 	} else {
-		progress("Executing database query")
-		result, err := conn.Query(ctx, query)
+		rows, err := db.Query(ctx, query, args...)
 		if err != nil {
 			diags.AddError("failed execute query", err.Error())
 			return
 		}
-		defer result.Close()
 
-		progress("Serializing databse query results")
-		data, err := PgSqlRowsToJson(result)
+		result, err = ReadPgRows(rows)
 		if err != nil {
-			diags.AddError("failed to serialize result", err.Error())
+			diags.AddError("failed to read query results", err.Error())
 			return
 		}
+		cfg.CheckRows(int64(len(result.Rows)), diags)
+	}
+	if diags.HasError() {
+		return
+	}
 
-		progress("Writing databse query results")
-		file, err := os.OpenFile(
-			strings.TrimPrefix(cfg.OutputJSON.ValueString(), "file://"),
-			os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.ModePerm,
-		)
-		if err != nil {
-			diags.AddError("failed to open output file", err.Error())
-		}
-		defer func() {
-			if err := file.Close(); err != nil {
-				diags.AddWarning("failed to close output file", err.Error())
-			}
-		}()
-
-		_, err = file.Write(data)
-		if err != nil {
-			diags.AddError("failed to write result", err.Error())
+	if tx, ok := db.(pgx.Tx); ok {
+		progress("Committing transaction")
+		if err := tx.Commit(ctx); err != nil {
+			diags.AddError("failed to commit transaction", err.Error())
 			return
 		}
+	}
 
-		if err := file.Sync(); err != nil {
-			diags.AddError("failed to sync file", err.Error())
-			return
+	if _, withOutput := cfg.OutputPath(); withOutput {
+		progress("Writing databse query results")
+		cfg.WriteResult(result, diags)
+	}
+}
+
+// pgQuerier is implemented by both connections and transactions
+type pgQuerier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// WriteOutput writes a query result into a file:// output path
+func WriteOutput(output string, data []byte, diags *diag.Diagnostics) {
+	file, err := os.OpenFile(
+		strings.TrimPrefix(output, "file://"),
+		os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.ModePerm,
+	)
+	if err != nil {
+		diags.AddError("failed to open output file", err.Error())
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			diags.AddWarning("failed to close output file", err.Error())
 		}
+	}()
+
+	_, err = file.Write(data)
+	if err != nil {
+		diags.AddError("failed to write result", err.Error())
+		return
+	}
+
+	if err := file.Sync(); err != nil {
+		diags.AddError("failed to sync file", err.Error())
+		return
 	}
 }
 
@@ -210,45 +395,28 @@ func (a *ActionExecuteDatabaseSQL) InvokePG(ctx context.Context, cfg *DatabaseQu
 // It retries every second until the connection succeeds or the context expires.
 // Returns the last error encountered if the context expires.
 func Connect(ctx context.Context, dsn string) (*pgx.Conn, error) {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	var lastErr error
-	var conn *pgx.Conn
-	i := 0
-
-	conn, lastErr = pgx.Connect(ctx, dsn)
-	if lastErr == nil {
-		return conn, nil
-	}
-	i++
-
-	for {
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("context expired: %w", lastErr)
-		case <-ticker.C:
-			conn, lastErr = pgx.Connect(ctx, dsn)
-			if lastErr == nil {
-				return conn, nil
-			}
-			tflog.Debug(ctx, "retrying connection to PG", map[string]any{
-				"error": lastErr.Error(),
-				"retry": i,
-			})
-			i++
-		}
-	}
+	return retry.Connect(ctx, "PG", func() (*pgx.Conn, error) {
+		return pgx.Connect(ctx, dsn)
+	})
 }
 
 func PgSqlRowsToJson(rows pgx.Rows) ([]byte, error) {
-	fieldDescriptions := rows.FieldDescriptions()
-	var columns []string
-	for _, col := range fieldDescriptions {
-		columns = append(columns, col.Name)
+	result, err := ReadPgRows(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	tableData := make([]map[string]any, 0)
+	return result.Encode(OutputFormatJSON)
+}
+
+// ReadPgRows reads and closes rows
+func ReadPgRows(rows pgx.Rows) (*QueryResult, error) {
+	defer rows.Close()
+
+	result := &QueryResult{Rows: []map[string]any{}}
+	for _, col := range rows.FieldDescriptions() {
+		result.AddColumn(col.Name)
+	}
 
 	for rows.Next() {
 		values, err := rows.Values()
@@ -257,14 +425,14 @@ func PgSqlRowsToJson(rows pgx.Rows) ([]byte, error) {
 		}
 
 		entry := make(map[string]any)
-		for i, col := range columns {
-			entry[col] = values[i]
+		for i, col := range rows.FieldDescriptions() {
+			entry[col.Name] = values[i]
 		}
-		tableData = append(tableData, entry)
+		result.Rows = append(result.Rows, entry)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return json.Marshal(tableData)
+	return result, nil
 }
@@ -0,0 +1,190 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg/retry"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// InvokeMongoDB runs the query as a database command, or as an aggregation
+// pipeline when a collection is given.
+func (a *ActionExecuteDatabaseSQL) InvokeMongoDB(ctx context.Context, cfg *DatabaseQuery, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	progress("Parsing database query")
+
+	var command bson.D
+	var pipeline bson.A
+	if cfg.Collection.IsNull() || cfg.Collection.IsUnknown() {
+		if err := bson.UnmarshalExtJSON([]byte(cfg.Query.ValueString()), false, &command); err != nil {
+			diags.AddError("failed to parse MongoDB command", err.Error())
+			return
+		}
+	} else {
+		var err error
+		if pipeline, err = ParseMongoPipeline(cfg.Query.ValueString()); err != nil {
+			diags.AddError("failed to parse MongoDB aggregation pipeline", err.Error())
+			return
+		}
+	}
+
+	progress("Resolving database ID")
+
+	addonID, err := tmp.RealIDToAddonID(ctx, a.Client(), a.Organization(), cfg.DatabaseID.ValueString())
+	if err != nil {
+		diags.AddError("failed to resolve database ID", err.Error())
+		return
+	}
+
+	progress("Fetching database credentials")
+	mongoRes := tmp.GetMongoDB(ctx, a.Client(), addonID)
+	if mongoRes.HasError() {
+		diags.AddError("failed to get database credentials", mongoRes.Error().Error())
+		return
+	}
+	mg := mongoRes.Payload()
+
+	progress("Opening database connection")
+	client, err := ConnectMongoDB(ctx, mg.Uri())
+	if err != nil {
+		diags.AddError("failed to connect to databse", err.Error())
+		return
+	}
+	defer func() {
+		progress("Closing database connection")
+		if err := client.Disconnect(ctx); err != nil {
+			diags.AddWarning("failed to close database connection", err.Error())
+		}
+	}()
+
+	db := client.Database(mg.Database)
+	documents := []bson.Raw{}
+
+	progress("Executing database query")
+	if pipeline == nil {
+		result, err := db.RunCommand(ctx, command).Raw()
+		if err != nil {
+			diags.AddError("failed execute query", err.Error())
+			return
+		}
+		documents = append(documents, result)
+	} else {
+		cursor, err := db.Collection(cfg.Collection.ValueString()).Aggregate(ctx, pipeline)
+		if err != nil {
+			diags.AddError("failed execute query", err.Error())
+			return
+		}
+		defer func() {
+			if err := cursor.Close(ctx); err != nil {
+				diags.AddWarning("failed to close cursor", err.Error())
+			}
+		}()
+
+		for cursor.Next(ctx) {
+			documents = append(documents, bson.Raw(bytes.Clone(cursor.Current)))
+		}
+		if err := cursor.Err(); err != nil {
+			diags.AddError("failed to read query results", err.Error())
+			return
+		}
+	}
+	tflog.Debug(ctx, "executed MongoDB query", map[string]any{"documents": len(documents)})
+
+	cfg.CheckRows(int64(len(documents)), diags)
+	if diags.HasError() {
+		return
+	}
+
+	if _, withOutput := cfg.OutputPath(); !withOutput {
+		return
+	}
+
+	progress("Serializing databse query results")
+	result, err := ReadMongoDocuments(documents)
+	if err != nil {
+		diags.AddError("failed to serialize result", err.Error())
+		return
+	}
+
+	progress("Writing databse query results")
+	cfg.WriteResult(result, diags)
+}
+
+// ConnectMongoDB connects to MongoDB and waits for the server to answer,
+// see Connect for the retry logic.
+func ConnectMongoDB(ctx context.Context, uri string) (*mongo.Client, error) {
+	return retry.Connect(ctx, "MongoDB", func() (*mongo.Client, error) {
+		client, err := mongo.Connect(options.Client().ApplyURI(uri))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := client.Ping(ctx, nil); err != nil {
+			_ = client.Disconnect(ctx)
+			return nil, err
+		}
+
+		return client, nil
+	})
+}
+
+// ParseMongoPipeline parses an extended JSON array of stages
+func ParseMongoPipeline(query string) (bson.A, error) {
+	// extended JSON can only be parsed as a document
+	wrapped := struct {
+		Pipeline bson.A `bson:"pipeline"`
+	}{}
+	if err := bson.UnmarshalExtJSON(fmt.Appendf(nil, `{"pipeline": %s}`, query), false, &wrapped); err != nil {
+		return nil, err
+	}
+	if wrapped.Pipeline == nil {
+		return nil, fmt.Errorf("expect an array of stages")
+	}
+
+	return wrapped.Pipeline, nil
+}
+
+// ReadMongoDocuments converts documents using relaxed extended JSON:
+// numbers stay numbers, ObjectIDs and dates are wrapped ({"$oid": ...}, {"$date": ...}).
+func ReadMongoDocuments(documents []bson.Raw) (*QueryResult, error) {
+	result := &QueryResult{Rows: []map[string]any{}}
+
+	for _, document := range documents {
+		data, err := bson.MarshalExtJSON(document, false, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize document: %w", err)
+		}
+
+		// walk the keys to keep the document order for the columns
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		row := map[string]any{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value := json.RawMessage{}
+			if err := decoder.Decode(&value); err != nil {
+				return nil, err
+			}
+
+			result.AddColumn(key.(string))
+			row[key.(string)] = value
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,174 @@
+package actions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+func (a *ActionExecuteDatabaseSQL) InvokeMySQL(ctx context.Context, cfg *DatabaseQuery, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	_, withOutput := cfg.OutputPath()
+
+	args := cfg.Args(ctx, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// no output, multiple statements allowed
+	options := []mysqldb.Option{}
+	if !withOutput {
+		options = append(options, mysqldb.WithMultiStatements)
+	}
+
+	progress("Opening database connection")
+	conn, err := mysqldb.Connect(ctx, a.Client(), a.Organization(), cfg.DatabaseID.ValueString(), options...)
+	if err != nil {
+		diags.AddError("failed to connect to databse", err.Error())
+		return
+	}
+	defer func() {
+		progress("Closing database connection")
+		if err := conn.Close(); err != nil {
+			diags.AddWarning("failed to close database connection", err.Error())
+		}
+	}()
+
+	var db sqlQuerier = conn
+	if cfg.Transaction.ValueBool() {
+		progress("Starting transaction")
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			diags.AddError("failed to start transaction", err.Error())
+			return
+		}
+		// no-op once committed
+		defer func() { _ = tx.Rollback() }()
+		db = tx
+	}
+
+	query := cfg.Query.ValueString()
+	result := &QueryResult{}
+	progress("Executing database query")
+
+	if !withOutput {
+		res, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			diags.AddError("failed execute query", err.Error())
+			return
+		}
+		rows, _ := res.RowsAffected()
+		tflog.Debug(ctx, "executed statement", map[string]any{"rows": rows})
+		cfg.CheckRows(rows, diags)
+	} else {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			diags.AddError("failed execute query", err.Error())
+			return
+		}
+
+		result, err = ReadMySQLRows(rows)
+		if err != nil {
+			diags.AddError("failed to read query results", err.Error())
+			return
+		}
+		cfg.CheckRows(int64(len(result.Rows)), diags)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	if tx, ok := db.(*sql.Tx); ok {
+		progress("Committing transaction")
+		if err := tx.Commit(); err != nil {
+			diags.AddError("failed to commit transaction", err.Error())
+			return
+		}
+	}
+
+	if withOutput {
+		progress("Writing databse query results")
+		cfg.WriteResult(result, diags)
+	}
+}
+
+// sqlQuerier is implemented by both *sql.DB and *sql.Tx
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ReadMySQLRows reads and closes rows
+func ReadMySQLRows(rows *sql.Rows) (*QueryResult, error) {
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	result := &QueryResult{Rows: []map[string]any{}}
+	for _, col := range columns {
+		result.AddColumn(col.Name())
+	}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to read row values: %w", err)
+		}
+
+		entry := make(map[string]any)
+		for i, col := range columns {
+			entry[col.Name()] = MySQLValue(col.DatabaseTypeName(), values[i])
+		}
+		result.Rows = append(result.Rows, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// MySQLValue maps a value returned by the driver to a JSON friendly value.
+// The text protocol returns every column as bytes, numbers are parsed
+// back according to the column type.
+func MySQLValue(typeName string, value any) any {
+	raw, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+
+	switch typeName {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT", "YEAR":
+		if i, err := strconv.ParseInt(string(raw), 10, 64); err == nil {
+			return i
+		}
+	case "UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT", "UNSIGNED INT", "UNSIGNED BIGINT":
+		if i, err := strconv.ParseUint(string(raw), 10, 64); err == nil {
+			return i
+		}
+	case "FLOAT", "DOUBLE":
+		if f, err := strconv.ParseFloat(string(raw), 64); err == nil {
+			return f
+		}
+	case "DECIMAL":
+		// keep the exact value
+		return json.Number(raw)
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		return raw
+	}
+
+	return string(raw)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 func setupPostgresContainer(t *testing.T, ctx context.Context) (string, func()) {
@@ -173,3 +174,54 @@ func TestPgSqlRowsToJson_NullValues(t *testing.T) {
 	assert.Nil(t, result[0]["name"], "name should be nil")
 	assert.Equal(t, "test", result[0]["description"], "description should be test")
 }
+
+func TestMySQLValue(t *testing.T) {
+	assert.Equal(t, int64(-3), MySQLValue("INT", []byte("-3")))
+	assert.Equal(t, uint64(18446744073709551615), MySQLValue("UNSIGNED BIGINT", []byte("18446744073709551615")))
+	assert.Equal(t, 1.5, MySQLValue("DOUBLE", []byte("1.5")))
+	assert.Equal(t, json.Number("19.99"), MySQLValue("DECIMAL", []byte("19.99")))
+	assert.Equal(t, "Product A", MySQLValue("VARCHAR", []byte("Product A")))
+	assert.Equal(t, []byte{0x01}, MySQLValue("BLOB", []byte{0x01}))
+	assert.Nil(t, MySQLValue("INT", nil))
+
+	data, err := json.Marshal(map[string]any{"price": MySQLValue("DECIMAL", []byte("19.99"))})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"price": 19.99}`, string(data))
+}
+
+func TestParseMongoPipeline(t *testing.T) {
+	pipeline, err := ParseMongoPipeline(`[{"$match": {"active": true}}, {"$count": "total"}]`)
+	require.NoError(t, err)
+	assert.Len(t, pipeline, 2)
+
+	_, err = ParseMongoPipeline(`{"$match": {}}`)
+	assert.Error(t, err, "a document is not a pipeline")
+
+	_, err = ParseMongoPipeline(`null`)
+	assert.Error(t, err)
+}
+
+func TestReadMongoDocuments(t *testing.T) {
+	first, err := bson.Marshal(bson.D{{Key: "name", Value: "Product A"}, {Key: "count", Value: int32(3)}})
+	require.NoError(t, err)
+	second, err := bson.Marshal(bson.D{{Key: "name", Value: nil}, {Key: "tags", Value: bson.A{"a"}}})
+	require.NoError(t, err)
+
+	result, err := ReadMongoDocuments([]bson.Raw{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "count", "tags"}, result.Columns)
+
+	data, err := result.Encode(OutputFormatJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name": "Product A", "count": 3}, {"name": null, "tags": ["a"]}]`, string(data))
+
+	data, err = result.Encode(OutputFormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, "name,count,tags\nProduct A,3,\n,,\"[\"\"a\"\"]\"\n", string(data))
+
+	result, err = ReadMongoDocuments([]bson.Raw{})
+	require.NoError(t, err)
+	data, err = result.Encode(OutputFormatJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(data))
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/export"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+)
+
+func DatabaseExport() action.Action {
+	return &ActionDatabaseExport{}
+}
+
+type ActionDatabaseExport struct {
+	provider.Provider
+}
+
+type databaseExport struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	CellarID   types.String `tfsdk:"cellar_id"`
+	Bucket     types.String `tfsdk:"bucket"`
+	Prefix     types.String `tfsdk:"prefix"`
+}
+
+func (a *ActionDatabaseExport) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	tflog.Debug(ctx, "Configure()")
+
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+//go:embed database_export_doc.md
+var actionDatabaseExportDoc string
+
+func (a *ActionDatabaseExport) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionDatabaseExportDoc,
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.StringAttribute{
+				Required:    true,
+				Description: "PostgreSQL, MySQL or MongoDB database ID to export",
+				Validators: []validator.String{
+					pkg.NewValidatorRegex("must be a PostgreSQL, MySQL or MongoDB addon ID", regexp.MustCompile(`^(postgresql|mysql|mongodb)_`)),
+				},
+			},
+			"cellar_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Cellar addon ID of the bucket",
+			},
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the bucket receiving the dump",
+			},
+			"prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Prefix of the object key, the dump is written to <prefix><database ID>/<date>.<extension>",
+			},
+		},
+	}
+}
+
+func (a *ActionDatabaseExport) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_database_export"
+}
+
+func (a *ActionDatabaseExport) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[databaseExport](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Invoke database_export", map[string]any{"config": req.Config})
+	progress := ProgressWrapper(res)
+
+	destination := export.Destination{
+		CellarID: cfg.CellarID.ValueString(),
+		Bucket:   cfg.Bucket.ValueString(),
+		Prefix:   cfg.Prefix.ValueString(),
+	}
+
+	key, err := export.Export(ctx, a.Client(), a.Organization(), cfg.DatabaseID.ValueString(), destination, progress)
+	if err != nil {
+		res.Diagnostics.AddError("failed to export database", err.Error())
+		return
+	}
+
+	progress("Database exported to %s/%s", destination.Bucket, key)
+}
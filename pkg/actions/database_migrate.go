@@ -0,0 +1,415 @@
+package actions
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+)
+
+// table created in the database to keep track of the applied migrations
+const MigrationsTable = "schema_migrations"
+
+// NNN_name.up.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_-]+)\.up\.sql$`)
+
+func DatabaseMigrate() action.Action {
+	return &ActionDatabaseMigrate{}
+}
+
+type ActionDatabaseMigrate struct {
+	provider.Provider
+}
+
+type DatabaseMigration struct {
+	DatabaseID types.String `tfsdk:"database_id"`
+	Directory  types.String `tfsdk:"directory"`
+}
+
+// Migration is a migration file found in the migrations directory
+type Migration struct {
+	Version  int64
+	Name     string
+	File     string
+	Content  string
+	Checksum string
+}
+
+// AppliedMigration is a row of the migrations table
+type AppliedMigration struct {
+	Version  int64
+	Name     string
+	Checksum string
+}
+
+// Migrator applies migrations on a database engine
+type Migrator interface {
+	// Init creates the migrations table if needed
+	Init(ctx context.Context) error
+	Applied(ctx context.Context) ([]AppliedMigration, error)
+	// Apply runs the migration and records it in a single transaction
+	Apply(ctx context.Context, migration Migration) error
+	Close(ctx context.Context) error
+}
+
+func (a *ActionDatabaseMigrate) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	tflog.Debug(ctx, "Configure()")
+
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+//go:embed database_migrate_doc.md
+var actionDatabaseMigrateDoc string
+
+func (a *ActionDatabaseMigrate) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionDatabaseMigrateDoc,
+		Attributes: map[string]schema.Attribute{
+			"database_id": schema.StringAttribute{
+				Required:    true,
+				Description: "PostgreSQL or MySQL database ID to migrate",
+				Validators: []validator.String{
+					pkg.NewValidatorRegex("must be a PostgreSQL or MySQL addon ID", regexp.MustCompile(`^(postgresql|mysql)_`)),
+				},
+			},
+			"directory": schema.StringAttribute{
+				Required:    true,
+				Description: "Local directory containing the NNN_name.up.sql migration files",
+			},
+		},
+	}
+}
+
+func (a *ActionDatabaseMigrate) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_database_migrate"
+}
+
+func (a *ActionDatabaseMigrate) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[DatabaseMigration](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	progress := ProgressWrapper(res)
+
+	progress("Reading migrations from %s", cfg.Directory.ValueString())
+	migrations, err := LoadMigrations(cfg.Directory.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to read migrations", err.Error())
+		return
+	}
+
+	progress("Resolving database ID")
+	addonID, err := tmp.RealIDToAddonID(ctx, a.Client(), a.Organization(), cfg.DatabaseID.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to resolve database ID", err.Error())
+		return
+	}
+
+	var migrator Migrator
+	switch {
+	case strings.HasPrefix(cfg.DatabaseID.ValueString(), "postgresql_"):
+		migrator = a.pgMigrator(ctx, addonID, progress, &res.Diagnostics)
+	case strings.HasPrefix(cfg.DatabaseID.ValueString(), "mysql_"):
+		migrator = a.mysqlMigrator(ctx, addonID, progress, &res.Diagnostics)
+	}
+	if res.Diagnostics.HasError() || migrator == nil {
+		return
+	}
+	defer func() {
+		progress("Closing database connection")
+		if err := migrator.Close(ctx); err != nil {
+			res.Diagnostics.AddWarning("failed to close database connection", err.Error())
+		}
+	}()
+
+	RunMigrations(ctx, migrator, migrations, progress, &res.Diagnostics)
+}
+
+func (a *ActionDatabaseMigrate) pgMigrator(ctx context.Context, addonID string, progress func(msg string, args ...any), diags *diag.Diagnostics) Migrator {
+	progress("Fetching database credentials")
+	pgRes := tmp.GetPostgreSQL(ctx, a.Client(), addonID)
+	if pgRes.HasError() {
+		diags.AddError("failed to get database credentials", pgRes.Error().Error())
+		return nil
+	}
+	pg := pgRes.Payload()
+
+	progress("Opening database connection")
+	conn, err := Connect(ctx, fmt.Sprintf("postgres://%s:%s@%s:%d/%s", pg.User, pg.Password, pg.Host, pg.Port, pg.Database))
+	if err != nil {
+		diags.AddError("failed to connect to databse", err.Error())
+		return nil
+	}
+
+	return &pgMigrator{conn: conn}
+}
+
+func (a *ActionDatabaseMigrate) mysqlMigrator(ctx context.Context, addonID string, progress func(msg string, args ...any), diags *diag.Diagnostics) Migrator {
+	progress("Opening database connection")
+	addon, err := mysqldb.Connect(ctx, a.Client(), a.Organization(), addonID, mysqldb.WithMultiStatements)
+	if err != nil {
+		diags.AddError("failed to connect to databse", err.Error())
+		return nil
+	}
+
+	return &mysqlMigrator{db: addon.DB}
+}
+
+// LoadMigrations reads the migration files of a directory, sorted by version.
+// Other files (down migrations, README...) are ignored.
+func LoadMigrations(directory string) ([]Migration, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := []Migration{}
+	versions := map[int64]string{}
+	for _, entry := range entries {
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version in %s: %w", entry.Name(), err)
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("version %d is used by both %s and %s", version, other, entry.Name())
+		}
+		versions[version] = entry.Name()
+
+		content, err := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		checksum := sha256.Sum256(content)
+
+		migrations = append(migrations, Migration{
+			Version:  version,
+			Name:     matches[2],
+			File:     entry.Name(),
+			Content:  string(content),
+			Checksum: hex.EncodeToString(checksum[:]),
+		})
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// RunMigrations applies the pending migrations in order.
+// Nothing is applied if an applied migration file has been modified.
+func RunMigrations(ctx context.Context, migrator Migrator, migrations []Migration, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	if err := migrator.Init(ctx); err != nil {
+		diags.AddError("failed to create migrations table", err.Error())
+		return
+	}
+
+	applied, err := migrator.Applied(ctx)
+	if err != nil {
+		diags.AddError("failed to list applied migrations", err.Error())
+		return
+	}
+
+	files := map[int64]Migration{}
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+
+	appliedVersions := map[int64]bool{}
+	lastApplied := int64(-1)
+	for _, done := range applied {
+		appliedVersions[done.Version] = true
+		lastApplied = max(lastApplied, done.Version)
+
+		migration, ok := files[done.Version]
+		if !ok {
+			diags.AddWarning(
+				"applied migration file not found",
+				fmt.Sprintf("migration %d (%s) is applied on the database but has no file", done.Version, done.Name),
+			)
+			continue
+		}
+		if migration.Checksum != done.Checksum {
+			diags.AddError(
+				"applied migration has been modified",
+				fmt.Sprintf("%s checksum changed since it has been applied, restore it and write a new migration instead", migration.File),
+			)
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	pending := []Migration{}
+	for _, migration := range migrations {
+		if !appliedVersions[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	if len(pending) == 0 {
+		progress("Database is up to date")
+		return
+	}
+
+	for i, migration := range pending {
+		if migration.Version < lastApplied {
+			diags.AddWarning(
+				"migration applied out of order",
+				fmt.Sprintf("%s is older than the last applied migration (%d)", migration.File, lastApplied),
+			)
+		}
+
+		progress("Applying %s (%d/%d)", migration.File, i+1, len(pending))
+		if err := migrator.Apply(ctx, migration); err != nil {
+			diags.AddError(fmt.Sprintf("failed to apply %s", migration.File), err.Error())
+			return
+		}
+	}
+
+	progress("%d migration(s) applied", len(pending))
+}
+
+type pgMigrator struct {
+	conn *pgx.Conn
+}
+
+func (m *pgMigrator) Init(ctx context.Context) error {
+	_, err := m.conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+MigrationsTable+` (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	return err
+}
+
+func (m *pgMigrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	rows, err := m.conn.Query(ctx, `SELECT version, name, checksum FROM `+MigrationsTable+` ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (AppliedMigration, error) {
+		applied := AppliedMigration{}
+		err := row.Scan(&applied.Version, &applied.Name, &applied.Checksum)
+		return applied, err
+	})
+}
+
+func (m *pgMigrator) Apply(ctx context.Context, migration Migration) error {
+	return pgx.BeginFunc(ctx, m.conn, func(tx pgx.Tx) error {
+		// without arguments, the simple protocol allows multiple statements
+		if _, err := tx.Exec(ctx, migration.Content); err != nil {
+			return err
+		}
+
+		_, err := tx.Exec(ctx,
+			`INSERT INTO `+MigrationsTable+` (version, name, checksum) VALUES ($1, $2, $3)`,
+			migration.Version, migration.Name, migration.Checksum,
+		)
+		return err
+	})
+}
+
+func (m *pgMigrator) Close(ctx context.Context) error {
+	return m.conn.Close(ctx)
+}
+
+type mysqlMigrator struct {
+	db *sql.DB
+}
+
+func (m *mysqlMigrator) Init(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+MigrationsTable+` (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	return err
+}
+
+func (m *mysqlMigrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum FROM `+MigrationsTable+` ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := []AppliedMigration{}
+	for rows.Next() {
+		migration := AppliedMigration{}
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.Checksum); err != nil {
+			return nil, err
+		}
+		applied = append(applied, migration)
+	}
+
+	return applied, rows.Err()
+}
+
+// Apply runs the migration in a transaction, MySQL commits DDL statements
+// (CREATE, ALTER, DROP...) implicitly so only data changes are rolled back.
+func (m *mysqlMigrator) Apply(ctx context.Context, migration Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, migration.Content); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO `+MigrationsTable+` (version, name, checksum) VALUES (?, ?, ?)`,
+		migration.Version, migration.Name, migration.Checksum,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *mysqlMigrator) Close(ctx context.Context) error {
+	return m.db.Close()
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMigrator struct {
+	applied []AppliedMigration
+	failOn  int64
+}
+
+func (m *fakeMigrator) Init(ctx context.Context) error { return nil }
+
+func (m *fakeMigrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	return m.applied, nil
+}
+
+func (m *fakeMigrator) Apply(ctx context.Context, migration Migration) error {
+	if migration.Version == m.failOn {
+		return errors.New("syntax error")
+	}
+	m.applied = append(m.applied, AppliedMigration{Version: migration.Version, Name: migration.Name, Checksum: migration.Checksum})
+	return nil
+}
+
+func (m *fakeMigrator) Close(ctx context.Context) error { return nil }
+
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoadMigrations(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"010_add_index.up.sql":   "CREATE INDEX users_name ON users (name);",
+		"002_users.up.sql":       "CREATE TABLE users (name TEXT);",
+		"002_users.down.sql":     "DROP TABLE users;",
+		"README.md":              "migrations",
+		"001_init_schema.up.sql": "CREATE SCHEMA app;",
+	})
+
+	migrations, err := LoadMigrations(dir)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "init_schema", migrations[0].Name)
+	assert.Equal(t, int64(2), migrations[1].Version)
+	assert.Equal(t, int64(10), migrations[2].Version)
+	assert.Len(t, migrations[0].Checksum, 64)
+
+	dir = writeMigrations(t, map[string]string{
+		"1_users.up.sql":   "",
+		"001_other.up.sql": "",
+	})
+	_, err = LoadMigrations(dir)
+	assert.Error(t, err, "duplicated versions must be rejected")
+}
+
+func TestRunMigrations(t *testing.T) {
+	ctx := context.Background()
+	progress := func(string, ...any) {}
+
+	dir := writeMigrations(t, map[string]string{
+		"001_users.up.sql":  "CREATE TABLE users (name TEXT);",
+		"002_orders.up.sql": "CREATE TABLE orders (id INT);",
+		"003_index.up.sql":  "CREATE INDEX users_name ON users (name);",
+	})
+	migrations, err := LoadMigrations(dir)
+	require.NoError(t, err)
+
+	t.Run("apply pending migrations only", func(t *testing.T) {
+		migrator := &fakeMigrator{applied: []AppliedMigration{
+			{Version: 1, Name: "users", Checksum: migrations[0].Checksum},
+		}}
+		diags := diag.Diagnostics{}
+
+		RunMigrations(ctx, migrator, migrations, progress, &diags)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Len(t, migrator.applied, 3)
+
+		// nothing left to apply
+		RunMigrations(ctx, migrator, migrations, progress, &diags)
+		assert.Len(t, migrator.applied, 3)
+	})
+
+	t.Run("refuse modified migrations", func(t *testing.T) {
+		migrator := &fakeMigrator{applied: []AppliedMigration{
+			{Version: 1, Name: "users", Checksum: "0000"},
+		}}
+		diags := diag.Diagnostics{}
+
+		RunMigrations(ctx, migrator, migrations, progress, &diags)
+		assert.True(t, diags.HasError())
+		assert.Len(t, migrator.applied, 1, "no migration must be applied")
+	})
+
+	t.Run("stop on failure", func(t *testing.T) {
+		migrator := &fakeMigrator{failOn: 2}
+		diags := diag.Diagnostics{}
+
+		RunMigrations(ctx, migrator, migrations, progress, &diags)
+		assert.True(t, diags.HasError())
+		assert.Len(t, migrator.applied, 1)
+	})
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	OutputFormatJSON   = "json"
+	OutputFormatCSV    = "csv"
+	OutputFormatNDJSON = "ndjson"
+)
+
+var outputFormats = []string{OutputFormatJSON, OutputFormatCSV, OutputFormatNDJSON}
+
+// QueryResult holds rows returned by a query, whatever the database
+type QueryResult struct {
+	// column names, in the order they first appear
+	Columns []string
+	// a row does not have all columns on schemaless databases
+	Rows []map[string]any
+}
+
+func (r *QueryResult) AddColumn(name string) {
+	if !slices.Contains(r.Columns, name) {
+		r.Columns = append(r.Columns, name)
+	}
+}
+
+// Encode serializes the rows with the given output format
+func (r *QueryResult) Encode(format string) ([]byte, error) {
+	switch format {
+	case OutputFormatJSON, "":
+		rows := r.Rows
+		if rows == nil {
+			rows = []map[string]any{}
+		}
+		return json.Marshal(rows)
+
+	case OutputFormatNDJSON:
+		buf := bytes.Buffer{}
+		encoder := json.NewEncoder(&buf)
+		for _, row := range r.Rows {
+			if err := encoder.Encode(row); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+
+	case OutputFormatCSV:
+		buf := bytes.Buffer{}
+		writer := csv.NewWriter(&buf)
+		if err := writer.Write(r.Columns); err != nil {
+			return nil, err
+		}
+
+		for _, row := range r.Rows {
+			record := make([]string, len(r.Columns))
+			for i, column := range r.Columns {
+				value, err := csvValue(row[column])
+				if err != nil {
+					return nil, fmt.Errorf("column %s: %w", column, err)
+				}
+				record[i] = value
+			}
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+		}
+
+		writer.Flush()
+		return buf.Bytes(), writer.Error()
+
+	default:
+		return nil, fmt.Errorf("unknown output format '%s'", format)
+	}
+}
+
+// csvValue formats a value as in JSON, without quotes around strings
+func csvValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s, nil
+	}
+	return string(data), nil
+}
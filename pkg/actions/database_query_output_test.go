@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestQueryResultEncode(t *testing.T) {
+	result := &QueryResult{
+		Columns: []string{"id", "name", "price", "created_at"},
+		Rows: []map[string]any{
+			{"id": int64(1), "name": "Product, A", "price": json.Number("19.99"), "created_at": time.Date(2024, 3, 12, 13, 38, 33, 0, time.UTC)},
+			{"id": int64(2), "name": nil, "price": 9.5, "created_at": nil},
+		},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{{
+		format: OutputFormatJSON,
+		want:   `[{"created_at":"2024-03-12T13:38:33Z","id":1,"name":"Product, A","price":19.99},{"created_at":null,"id":2,"name":null,"price":9.5}]`,
+	}, {
+		format: OutputFormatNDJSON,
+		want: `{"created_at":"2024-03-12T13:38:33Z","id":1,"name":"Product, A","price":19.99}
+{"created_at":null,"id":2,"name":null,"price":9.5}
+`,
+	}, {
+		format: OutputFormatCSV,
+		want: `id,name,price,created_at
+1,"Product, A",19.99,2024-03-12T13:38:33Z
+2,,9.5,
+`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			data, err := result.Encode(tt.format)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Encode() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+
+	if _, err := result.Encode("xml"); err == nil {
+		t.Error("expect an error for unknown formats")
+	}
+
+	empty := &QueryResult{}
+	if data, _ := empty.Encode(OutputFormatJSON); string(data) != "[]" {
+		t.Errorf("expect an empty JSON array, got %s", data)
+	}
+}
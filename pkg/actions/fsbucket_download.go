@@ -0,0 +1,188 @@
+package actions
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlaffaye/ftp"
+	"go.clever-cloud.com/terraform-provider/pkg/fsbucket"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+)
+
+//go:embed fsbucket_download_doc.md
+var actionFSBucketDownloadDoc string
+
+func FSBucketDownload() action.Action {
+	return &ActionFSBucketDownload{}
+}
+
+type ActionFSBucketDownload struct {
+	provider.Provider
+}
+
+type fsbucketDownload struct {
+	FSBucketID types.String `tfsdk:"fsbucket_id"`
+	RemotePath types.String `tfsdk:"remote_path"`
+	LocalPath  types.String `tfsdk:"local_path"`
+}
+
+func (a *ActionFSBucketDownload) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+func (a *ActionFSBucketDownload) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_fsbucket_download"
+}
+
+func (a *ActionFSBucketDownload) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionFSBucketDownloadDoc,
+		Attributes: map[string]schema.Attribute{
+			"fsbucket_id": schema.StringAttribute{
+				Required:    true,
+				Description: "FSBucket ID to download from",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^bucket_`), "must be a valid FSBucket addon ID (format: bucket_xxx)"),
+				},
+			},
+			"remote_path": schema.StringAttribute{
+				Required:    true,
+				Description: "File or directory to download from the FSBucket",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local destination: the file path, or the directory the remote directory is downloaded in",
+			},
+		},
+	}
+}
+
+func (a *ActionFSBucketDownload) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[fsbucketDownload](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	remotePath := path.Clean("/" + cfg.RemotePath.ValueString())
+	localPath := cfg.LocalPath.ValueString()
+	progress := ProgressWrapper(res)
+
+	progress("Connecting to FSBucket FTP...")
+	conn, err := fsbucket.Connect(ctx, a.Client(), a.Organization(), cfg.FSBucketID.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to connect to FSBucket", err.Error())
+		return
+	}
+	defer func() {
+		if err := conn.Quit(); err != nil {
+			res.Diagnostics.AddWarning("failed to close FTP connection", err.Error())
+		}
+	}()
+
+	if !fsbucket.IsDir(conn, remotePath) {
+		// a file downloaded in an existing directory keeps its name
+		if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+			localPath = filepath.Join(localPath, path.Base(remotePath))
+		}
+
+		progress("Downloading '%s' -> '%s'", remotePath, localPath)
+		if err := downloadFile(conn, remotePath, localPath, time.Time{}); err != nil {
+			res.Diagnostics.AddError(fmt.Sprintf("failed to download '%s'", remotePath), err.Error())
+			return
+		}
+		progress("Downloaded '%s'", remotePath)
+		return
+	}
+
+	progress("Listing files of '%s'", remotePath)
+	files, err := fsbucket.List(conn, remotePath, true)
+	if err != nil {
+		res.Diagnostics.AddError("failed to list remote files", err.Error())
+		return
+	}
+
+	for i, file := range files {
+		target, err := DownloadTarget(localPath, file.Path)
+		if err != nil {
+			res.Diagnostics.AddError(fmt.Sprintf("failed to download '%s'", file.Path), err.Error())
+			return
+		}
+
+		progress("Downloading '%s' (%d/%d)", file.Path, i+1, len(files))
+		if err := downloadFile(conn, path.Join(remotePath, file.Path), target, file.ModTime); err != nil {
+			res.Diagnostics.AddError(fmt.Sprintf("failed to download '%s'", file.Path), err.Error())
+			return
+		}
+	}
+
+	progress("Successfully downloaded %d file(s) to '%s'", len(files), localPath)
+}
+
+// DownloadTarget returns the local path of a file of the downloaded directory.
+// The remote path must stay inside the local directory
+func DownloadTarget(localDir, relPath string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(relPath)) {
+		return "", fmt.Errorf("'%s' is outside of the downloaded directory", relPath)
+	}
+
+	return filepath.Join(localDir, filepath.FromSlash(relPath)), nil
+}
+
+// downloadFile writes the remote file to localPath, with the remote modification time when known
+func downloadFile(conn *ftp.ServerConn, remotePath, localPath string, modTime time.Time) error {
+	content, err := conn.Retr(remotePath)
+	if err != nil {
+		if fsbucket.IsNotFound(err) {
+			return fmt.Errorf("'%s' does not exist", remotePath)
+		}
+		return err
+	}
+	defer func() { _ = content.Close() }()
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0o755); err != nil {
+		return fmt.Errorf("failed to create local directory: %w", err)
+	}
+
+	file, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write '%s': %w", localPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if !modTime.IsZero() {
+		if err := os.Chtimes(localPath, modTime, modTime); err != nil {
+			return fmt.Errorf("failed to set modification time: %w", err)
+		}
+	}
+
+	return nil
+}
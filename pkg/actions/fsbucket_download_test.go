@@ -0,0 +1,20 @@
+package actions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadTarget(t *testing.T) {
+	target, err := DownloadTarget("reports", "2025/03/summary.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("reports", "2025", "03", "summary.pdf"), target)
+
+	for _, relPath := range []string{"../escape.txt", "a/../../escape.txt", "/etc/passwd"} {
+		_, err := DownloadTarget("reports", relPath)
+		assert.Error(t, err, relPath)
+	}
+}
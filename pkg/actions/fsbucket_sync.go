@@ -0,0 +1,353 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jlaffaye/ftp"
+	"go.clever-cloud.com/terraform-provider/pkg/fsbucket"
+)
+
+const (
+	SyncCompareMtime    = "mtime"
+	SyncCompareChecksum = "checksum"
+
+	// checksums of the uploaded files, stored at the root of the synced directory
+	syncManifestName = ".fsbucket-sync.json"
+
+	defaultFTPParallelism = 4
+	// files listed in a dry-run summary
+	dryRunListSize = 50
+)
+
+var SyncCompareModes = []string{SyncCompareMtime, SyncCompareChecksum}
+
+type fsbucketSync struct {
+	LocalPath   types.String `tfsdk:"local_path"`
+	RemotePath  types.String `tfsdk:"remote_path"`
+	Compare     types.String `tfsdk:"compare"`
+	Delete      types.Bool   `tfsdk:"delete"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
+}
+
+// FTPSyncOptions are the settings of a directory sync over FTP
+type FTPSyncOptions struct {
+	Compare string
+	Delete  bool
+	// remote times are only precise to the minute with LIST
+	PreciseTime bool
+}
+
+// SyncEntry describes a file of the local or the remote directory
+type SyncEntry struct {
+	Size    int64
+	ModTime time.Time
+	// only set on local files in checksum mode
+	Checksum string
+}
+
+// PlanFTPSync returns the relative paths to upload and to delete, sorted.
+// A file is uploaded when it is missing remotely, or when its size or mtime (checksum in checksum mode) changed
+func PlanFTPSync(local, remote map[string]SyncEntry, manifest map[string]string, opts FTPSyncOptions) (toUpload []string, toDelete []string) {
+	for name, file := range local {
+		remoteFile, exists := remote[name]
+
+		changed := !exists
+		switch {
+		case changed:
+		case opts.Compare == SyncCompareChecksum:
+			changed = manifest[name] != file.Checksum
+		default:
+			modTime := file.ModTime
+			if !opts.PreciseTime {
+				modTime = modTime.Truncate(time.Minute)
+			}
+			changed = file.Size != remoteFile.Size || modTime.After(remoteFile.ModTime)
+		}
+
+		if changed {
+			toUpload = append(toUpload, name)
+		}
+	}
+
+	if opts.Delete {
+		for name := range remote {
+			if _, exists := local[name]; !exists && name != syncManifestName {
+				toDelete = append(toDelete, name)
+			}
+		}
+	}
+
+	slices.Sort(toUpload)
+	slices.Sort(toDelete)
+	return toUpload, toDelete
+}
+
+// localSyncEntries lists the regular files of the directory, by path relative to it
+func localSyncEntries(dir string, withChecksum bool) (map[string]SyncEntry, error) {
+	entries := map[string]SyncEntry{}
+
+	err := filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path: %w", err)
+		}
+
+		entry := SyncEntry{Size: info.Size(), ModTime: info.ModTime()}
+		if withChecksum {
+			if entry.Checksum, err = fileSHA256(filePath); err != nil {
+				return err
+			}
+		}
+
+		entries[filepath.ToSlash(relPath)] = entry
+		return nil
+	})
+
+	return entries, err
+}
+
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// remoteSyncEntries lists the files of the remote directory, empty when it does not exist yet
+func remoteSyncEntries(conn *ftp.ServerConn, dir string) (map[string]SyncEntry, error) {
+	files, err := fsbucket.List(conn, dir, true)
+	if fsbucket.IsNotFound(err) {
+		return map[string]SyncEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]SyncEntry, len(files))
+	for _, file := range files {
+		entries[file.Path] = SyncEntry{Size: file.Size, ModTime: file.ModTime}
+	}
+	return entries, nil
+}
+
+// readSyncManifest returns the checksums of the last sync, empty when there is none
+func readSyncManifest(conn *ftp.ServerConn, dir string) (map[string]string, error) {
+	manifest := map[string]string{}
+
+	res, err := conn.Retr(path.Join(dir, syncManifestName))
+	if fsbucket.IsNotFound(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Close() }()
+
+	if err := json.NewDecoder(res).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid sync manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeSyncManifest(conn *ftp.ServerConn, dir string, local map[string]SyncEntry) error {
+	manifest := map[string]string{}
+	for name, entry := range local {
+		manifest[name] = entry.Checksum
+	}
+
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	return conn.Stor(path.Join(dir, syncManifestName), bytes.NewReader(content))
+}
+
+// runSync mirrors a local directory in the FSBucket, over a pool of FTP connections
+func (a *ActionFSBucketUpload) runSync(ctx context.Context, cfg *fsbucketSync, conn *ftp.ServerConn, dial func() (*ftp.ServerConn, error), progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	localURL, err := url.Parse(cfg.LocalPath.ValueString())
+	if err != nil || localURL.Scheme != SchemeFile {
+		diags.AddError("invalid sync local_path", "expect a file:// URL of a local directory")
+		return
+	}
+	localDir := localURL.Host + localURL.Path
+	remoteDir := path.Clean("/" + cfg.RemotePath.ValueString())
+
+	opts := FTPSyncOptions{
+		Compare:     SyncCompareMtime,
+		Delete:      cfg.Delete.ValueBool(),
+		PreciseTime: conn.IsTimePreciseInList(),
+	}
+	if !cfg.Compare.IsNull() {
+		opts.Compare = cfg.Compare.ValueString()
+	}
+	parallelism := defaultFTPParallelism
+	if !cfg.Parallelism.IsNull() {
+		parallelism = int(cfg.Parallelism.ValueInt64())
+	}
+
+	progress("Listing local files of '%s'", localDir)
+	local, err := localSyncEntries(localDir, opts.Compare == SyncCompareChecksum)
+	if err != nil {
+		diags.AddError("failed to list local files", err.Error())
+		return
+	}
+
+	progress("Listing remote files of '%s'", remoteDir)
+	remote, err := remoteSyncEntries(conn, remoteDir)
+	if err != nil {
+		diags.AddError("failed to list remote files", err.Error())
+		return
+	}
+
+	manifest := map[string]string{}
+	if opts.Compare == SyncCompareChecksum {
+		if manifest, err = readSyncManifest(conn, remoteDir); err != nil {
+			diags.AddError("failed to read sync manifest", err.Error())
+			return
+		}
+	}
+
+	toUpload, toDelete := PlanFTPSync(local, remote, manifest, opts)
+
+	if cfg.DryRun.ValueBool() {
+		progress("Dry run: %d file(s) to upload, %d unchanged, %d to delete", len(toUpload), len(local)-len(toUpload), len(toDelete))
+		for _, name := range toUpload[:min(len(toUpload), dryRunListSize)] {
+			progress("Would upload '%s'", path.Join(remoteDir, name))
+		}
+		for _, name := range toDelete[:min(len(toDelete), dryRunListSize)] {
+			progress("Would delete '%s'", path.Join(remoteDir, name))
+		}
+		if len(toUpload) > dryRunListSize || len(toDelete) > dryRunListSize {
+			progress("Only the first %d files of each list are shown", dryRunListSize)
+		}
+		return
+	}
+
+	progress("%d file(s) to upload, %d unchanged", len(toUpload), len(local)-len(toUpload))
+
+	// directories are created once, before the parallel uploads
+	dirs := map[string]bool{}
+	for _, name := range toUpload {
+		dirs[path.Dir(path.Join(remoteDir, name))] = true
+	}
+	for _, dir := range slices.Sorted(maps.Keys(dirs)) {
+		if err := createRemoteDir(conn, dir); err != nil {
+			diags.AddError(fmt.Sprintf("failed to create remote directory '%s'", dir), err.Error())
+			return
+		}
+	}
+
+	a.uploadParallel(ctx, localDir, remoteDir, toUpload, conn, dial, parallelism, progress, diags)
+	if diags.HasError() {
+		return
+	}
+
+	for i, name := range toDelete {
+		progress("Deleting '%s' (%d/%d)", path.Join(remoteDir, name), i+1, len(toDelete))
+		if err := conn.Delete(path.Join(remoteDir, name)); err != nil {
+			diags.AddError(fmt.Sprintf("failed to delete '%s'", name), err.Error())
+			return
+		}
+	}
+
+	if opts.Compare == SyncCompareChecksum {
+		if err := writeSyncManifest(conn, remoteDir, local); err != nil {
+			diags.AddError("failed to write sync manifest", err.Error())
+			return
+		}
+	}
+
+	progress("Sync done: %d uploaded, %d unchanged, %d deleted", len(toUpload), len(local)-len(toUpload), len(toDelete))
+}
+
+// uploadParallel uploads the files over the main connection and parallelism-1 additional ones
+func (a *ActionFSBucketUpload) uploadParallel(ctx context.Context, localDir, remoteDir string, files []string, conn *ftp.ServerConn, dial func() (*ftp.ServerConn, error), parallelism int, progress func(msg string, args ...any), diags *diag.Diagnostics) {
+	conns := []*ftp.ServerConn{conn}
+	defer func() {
+		for _, extra := range conns[1:] {
+			_ = extra.Quit()
+		}
+	}()
+	for len(conns) < min(parallelism, len(files)) {
+		extra, err := dial()
+		if err != nil {
+			tflog.Warn(ctx, "failed to open an additional FTP connection", map[string]any{"error": err.Error()})
+			break
+		}
+		conns = append(conns, extra)
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan string)
+	uploaded := 0
+	for _, worker := range conns {
+		wg.Go(func() {
+			for name := range queue {
+				err := storeFile(worker, filepath.Join(localDir, filepath.FromSlash(name)), path.Join(remoteDir, name))
+
+				mu.Lock()
+				if err != nil {
+					diags.AddError(fmt.Sprintf("failed to upload '%s'", name), err.Error())
+				} else {
+					uploaded++
+					progress("Uploaded '%s' (%d/%d)", name, uploaded, len(files))
+				}
+				mu.Unlock()
+			}
+		})
+	}
+	for _, name := range files {
+		queue <- name
+	}
+	close(queue)
+	wg.Wait()
+}
+
+func storeFile(conn *ftp.ServerConn, localPath, remotePath string) error {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	return conn.Stor(remotePath, file)
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFTPSync_Mtime(t *testing.T) {
+	deployedAt := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	local := map[string]SyncEntry{
+		"index.html":    {Size: 10, ModTime: deployedAt.Add(-time.Hour)},
+		"app.js":        {Size: 20, ModTime: deployedAt.Add(time.Hour)},
+		"style.css":     {Size: 31, ModTime: deployedAt.Add(-time.Hour)},
+		"img/logo.png":  {Size: 40, ModTime: deployedAt.Add(-time.Hour)},
+		"img/new.png":   {Size: 50, ModTime: deployedAt.Add(-time.Hour)},
+		"fonts/a.woff2": {Size: 60, ModTime: deployedAt.Add(42 * time.Second)},
+	}
+	remote := map[string]SyncEntry{
+		"index.html":    {Size: 10, ModTime: deployedAt},
+		"app.js":        {Size: 20, ModTime: deployedAt},
+		"style.css":     {Size: 30, ModTime: deployedAt},
+		"img/logo.png":  {Size: 40, ModTime: deployedAt},
+		"fonts/a.woff2": {Size: 60, ModTime: deployedAt},
+		"old.html":      {Size: 70, ModTime: deployedAt},
+	}
+
+	toUpload, toDelete := PlanFTPSync(local, remote, nil, FTPSyncOptions{Compare: SyncCompareMtime, Delete: true})
+
+	assert.Equal(t, []string{"app.js", "img/new.png", "style.css"}, toUpload, "a change within the listing minute is ignored")
+	assert.Equal(t, []string{"old.html"}, toDelete)
+
+	toUpload, _ = PlanFTPSync(local, remote, nil, FTPSyncOptions{Compare: SyncCompareMtime, PreciseTime: true})
+	assert.Equal(t, []string{"app.js", "fonts/a.woff2", "img/new.png", "style.css"}, toUpload)
+}
+
+func TestPlanFTPSync_Checksum(t *testing.T) {
+	local := map[string]SyncEntry{
+		"index.html": {Checksum: "aaa"},
+		"app.js":     {Checksum: "bbb"},
+		"new.js":     {Checksum: "ccc"},
+	}
+	remote := map[string]SyncEntry{
+		"index.html":      {},
+		"app.js":          {},
+		"old.js":          {},
+		syncManifestName:  {},
+		"not-in-manifest": {},
+	}
+	manifest := map[string]string{"index.html": "aaa", "app.js": "old"}
+
+	toUpload, toDelete := PlanFTPSync(local, remote, manifest, FTPSyncOptions{Compare: SyncCompareChecksum, Delete: true})
+
+	assert.Equal(t, []string{"app.js", "new.js"}, toUpload)
+	assert.Equal(t, []string{"not-in-manifest", "old.js"}, toDelete, "the manifest is never deleted")
+}
+
+func TestPlanFTPSync_WithoutDelete(t *testing.T) {
+	_, toDelete := PlanFTPSync(map[string]SyncEntry{}, map[string]SyncEntry{"old.html": {}}, nil, FTPSyncOptions{})
+
+	assert.Empty(t, toDelete)
+}
+
+func TestLocalSyncEntries(t *testing.T) {
+	dir := newSyncSource(t)
+
+	entries, err := localSyncEntries(dir, true)
+
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, int64(len("body {}")), entries["assets/app.css"].Size)
+	// sha256("body {}")
+	assert.Equal(t, "62368a1a29259b30bac235c0e75dc700c9b3bacf1513ad5708e4fe4a6c0d6560", entries["assets/app.css"].Checksum)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "index.html")))
+	entries, err = localSyncEntries(dir, false)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Empty(t, entries["assets/app.js"].Checksum)
+}
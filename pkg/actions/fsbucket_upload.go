@@ -10,18 +10,23 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/action/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/jlaffaye/ftp"
 	"github.com/miton18/helper/set"
 	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/fsbucket"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 	"go.clever-cloud.com/terraform-provider/pkg/provider"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
@@ -44,8 +49,9 @@ type (
 	}
 
 	fsbucketUpload struct {
-		FSBucketID types.String `tfsdk:"fsbucket_id"`
-		Files      types.List   `tfsdk:"file"`
+		FSBucketID types.String  `tfsdk:"fsbucket_id"`
+		Files      types.List    `tfsdk:"file"`
+		Sync       *fsbucketSync `tfsdk:"sync"`
 	}
 )
 
@@ -160,10 +166,59 @@ func (a *ActionFSBucketUpload) Schema(ctx context.Context, req action.SchemaRequ
 					},
 				},
 			},
+			"sync": schema.SingleNestedBlock{
+				MarkdownDescription: "Mirror a local directory, only the new and changed files are uploaded",
+				Attributes: map[string]schema.Attribute{
+					"local_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "Local directory to sync, with the file:// scheme",
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^file://`), "must use the file:// scheme"),
+						},
+					},
+					"remote_path": schema.StringAttribute{
+						Optional:    true,
+						Description: "Remote directory in the FSBucket, defaults to the root",
+					},
+					"compare": schema.StringAttribute{
+						Optional:    true,
+						Description: "How changed files are detected: 'mtime' (size and modification time, default) or 'checksum' (SHA-256 manifest stored in the bucket)",
+						Validators: []validator.String{
+							stringvalidator.OneOf(SyncCompareModes...),
+						},
+					},
+					"delete": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Delete the remote files which do not exist locally",
+					},
+					"parallelism": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Number of FTP connections used to upload, defaults to 4",
+						Validators: []validator.Int64{
+							int64validator.Between(1, 16),
+						},
+					},
+					"dry_run": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Only report the files which would be uploaded and deleted",
+					},
+				},
+			},
 		},
 	}
 }
 
+func (a *ActionFSBucketUpload) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, res *action.ValidateConfigResponse) {
+	cfg := helper.From[fsbucketUpload](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() || cfg.Sync == nil {
+		return
+	}
+
+	if cfg.Sync.LocalPath.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("sync").AtName("local_path"), "missing local_path", "the sync block requires a local directory")
+	}
+}
+
 func (a *ActionFSBucketUpload) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
 	tflog.Debug(ctx, "Invoke fsbucket_upload", map[string]any{"config": req.Config})
 
@@ -174,7 +229,7 @@ func (a *ActionFSBucketUpload) Invoke(ctx context.Context, req action.InvokeRequ
 
 	files := cfg.GetFiles(ctx, &res.Diagnostics)
 
-	if len(files) == 0 {
+	if len(files) == 0 && cfg.Sync == nil {
 		res.Diagnostics.AddWarning("no files specified", "skipping action")
 		return
 	}
@@ -185,26 +240,22 @@ func (a *ActionFSBucketUpload) Invoke(ctx context.Context, req action.InvokeRequ
 		res.Diagnostics.AddError("failed to get FSBucket credentials", fsbucketEnvRes.Error().Error())
 		return
 	}
-	fsbucketEnv := fsbucketEnvRes.Payload()
-
-	envMap := pkg.Reduce(*fsbucketEnv, map[string]string{}, func(m map[string]string, v tmp.EnvVar) map[string]string {
-		m[v.Name] = v.Value
-		return m
-	})
+	creds := fsbucket.FromEnvVars(*fsbucketEnvRes.Payload())
 
-	host := envMap["BUCKET_HOST"]
-	username := envMap["BUCKET_FTP_USERNAME"]
-	password := envMap["BUCKET_FTP_PASSWORD"]
-
-	if host == "" || username == "" || password == "" {
+	if creds.Host == "" || creds.Username == "" || creds.Password == "" {
 		res.Diagnostics.AddError("missing FTP credentials", "BUCKET_HOST, BUCKET_FTP_USERNAME, or BUCKET_FTP_PASSWORD not found")
 		return
 	}
 
+	// the sync mode opens additional connections
+	dial := func() (*ftp.ServerConn, error) {
+		return dialWithRetry(ctx, creds, res)
+	}
+
 	Progress(res, "Connecting to FSBucket FTP...")
-	conn, err := ftp.Dial(host+":21", ftp.DialWithContext(ctx), ftp.DialWithTimeout(30*time.Second), ftp.DialWithDebugOutput(os.Stdout))
+	conn, err := dial()
 	if err != nil {
-		res.Diagnostics.AddError("failed to connect to FTP server", err.Error())
+		res.Diagnostics.AddError("failed to connect to FSBucket", err.Error())
 		return
 	}
 	defer func() {
@@ -213,13 +264,6 @@ func (a *ActionFSBucketUpload) Invoke(ctx context.Context, req action.InvokeRequ
 		}
 	}()
 
-	Progress(res, "Login to FTP server...")
-
-	if err := loginWithRetry(ctx, conn, username, password, res); err != nil {
-		res.Diagnostics.AddError("failed to login to FTP server after 3 attempts", err.Error())
-		return
-	}
-
 	uploadCount := 0
 	for i, fileBlock := range files {
 		remotePath := fileBlock.RemotePath.ValueString()
@@ -279,7 +323,13 @@ func (a *ActionFSBucketUpload) Invoke(ctx context.Context, req action.InvokeRequ
 		}
 	}
 
-	Progress(res, "Successfully uploaded %d file(s)", uploadCount)
+	if len(files) > 0 {
+		Progress(res, "Successfully uploaded %d file(s)", uploadCount)
+	}
+
+	if cfg.Sync != nil && !res.Diagnostics.HasError() {
+		a.runSync(ctx, cfg.Sync, conn, dial, ProgressWrapper(res), &res.Diagnostics)
+	}
 }
 
 // resolveFileSource resolves file:// URLs to one or more files to upload
@@ -400,11 +450,14 @@ func downloadHTTPContent(ctx context.Context, url string) (io.ReadCloser, error)
 	return resp.Body, nil
 }
 
-// loginWithRetry attempts to login to FTP server with retry logic
+// dialWithRetry opens a logged in FTP connection with retry logic
 // FTP accounts might not be available immediately after creation
-func loginWithRetry(ctx context.Context, conn *ftp.ServerConn, username, password string, res *action.InvokeResponse) error {
-	return retry(ctx, 3, 4*time.Second, func() error {
-		return conn.Login(username, password)
+func dialWithRetry(ctx context.Context, creds *fsbucket.FTPCreds, res *action.InvokeResponse) (*ftp.ServerConn, error) {
+	var conn *ftp.ServerConn
+	err := withRetry(ctx, 3, 4*time.Second, func() error {
+		var err error
+		conn, err = creds.Dial(ctx)
+		return err
 	}, func(attempt int, err error) {
 		tflog.Warn(ctx, "FTP login failed, retrying...", map[string]any{
 			"attempt": attempt,
@@ -412,6 +465,11 @@ func loginWithRetry(ctx context.Context, conn *ftp.ServerConn, username, passwor
 		})
 		Progress(res, "FTP login failed (attempt %d/3), retrying in 4s...", attempt)
 	})
+	if err != nil {
+		return nil, fmt.Errorf("%w (after 3 attempts)", err)
+	}
+
+	return conn, nil
 }
 
 func uploadFileFromReader(ctx context.Context, conn *ftp.ServerConn, content io.ReadCloser, remotePath string) error {
@@ -467,12 +525,12 @@ func createRemoteDir(conn *ftp.ServerConn, path string) error {
 	return nil
 }
 
-// retry executes a function with retry logic
+// withRetry executes a function with retry logic
 // maxAttempts: maximum number of attempts
 // interval: delay between retries
 // fn: function to execute
 // onRetry: optional callback called before each retry (not called on first attempt or after last failure)
-func retry(_ context.Context, maxAttempts int, interval time.Duration, fn func() error, onRetry func(attempt int, err error)) error {
+func withRetry(_ context.Context, maxAttempts int, interval time.Duration, fn func() error, onRetry func(attempt int, err error)) error {
 	var lastErr error
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
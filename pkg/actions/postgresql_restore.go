@@ -0,0 +1,137 @@
+package actions
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+	"go.clever-cloud.com/terraform-provider/pkg/provider"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+func PostgreSQLRestore() action.Action {
+	return &ActionPostgreSQLRestore{}
+}
+
+type ActionPostgreSQLRestore struct {
+	provider.Provider
+}
+
+type postgresqlRestore struct {
+	PostgreSQLID       types.String `tfsdk:"postgresql_id"`
+	SourcePostgreSQLID types.String `tfsdk:"source_postgresql_id"`
+	Backup             types.String `tfsdk:"backup"`
+	Clean              types.Bool   `tfsdk:"clean"`
+}
+
+var postgresqlIDValidator = pkg.NewValidatorRegex("must be a PostgreSQL addon ID", regexp.MustCompile(`^postgresql_`))
+
+func (a *ActionPostgreSQLRestore) Configure(ctx context.Context, req action.ConfigureRequest, res *action.ConfigureResponse) {
+	tflog.Debug(ctx, "Configure()")
+
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	if provider, ok := req.ProviderData.(provider.Provider); ok {
+		a.Provider = provider
+	}
+
+	tflog.Debug(ctx, "Configured", map[string]any{"org": a.Organization()})
+}
+
+//go:embed postgresql_restore_doc.md
+var actionPostgreSQLRestoreDoc string
+
+func (a *ActionPostgreSQLRestore) Schema(ctx context.Context, req action.SchemaRequest, res *action.SchemaResponse) {
+	res.Schema = schema.Schema{
+		MarkdownDescription: actionPostgreSQLRestoreDoc,
+		Attributes: map[string]schema.Attribute{
+			"postgresql_id": schema.StringAttribute{
+				Required:    true,
+				Description: "PostgreSQL addon ID to restore the backup into",
+				Validators:  []validator.String{postgresqlIDValidator},
+			},
+			"source_postgresql_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "PostgreSQL addon ID the backup comes from, defaults to postgresql_id",
+				Validators:  []validator.String{postgresqlIDValidator},
+			},
+			"backup": schema.StringAttribute{
+				Optional: true,
+				Description: "Backup to restore, same selector as the clevercloud_postgresql_backup data source: " +
+					"a backup UUID, an RFC3339 date to take the most recent backup before it, or 'latest' (default)",
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"clean": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Drop the objects of the backup before recreating them (default: true)",
+			},
+		},
+	}
+}
+
+func (a *ActionPostgreSQLRestore) Metadata(ctx context.Context, req action.MetadataRequest, res *action.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_postgresql_restore"
+}
+
+func (a *ActionPostgreSQLRestore) Invoke(ctx context.Context, req action.InvokeRequest, res *action.InvokeResponse) {
+	cfg := helper.From[postgresqlRestore](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	progress := ProgressWrapper(res)
+
+	source := cfg.PostgreSQLID.ValueString()
+	pkg.IfIsSetStr(cfg.SourcePostgreSQLID, func(s string) { source = s })
+	selector := "latest"
+	pkg.IfIsSetStr(cfg.Backup, func(s string) { selector = s })
+	clean := cfg.Clean.IsNull() || cfg.Clean.ValueBool()
+
+	progress("Looking for backup '%s' of %s", selector, source)
+	backup := pgsql.FindBackup(ctx, a.Client(), a.Organization(), source, selector, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	progress("Fetching database credentials")
+	target := postgresqlCredentials(ctx, a.Client(), a.Organization(), cfg.PostgreSQLID.ValueString(), &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if err := pgsql.RestoreBackup(ctx, backup, target, clean, progress); err != nil {
+		res.Diagnostics.AddError("failed to restore backup", err.Error())
+		return
+	}
+
+	progress("Backup %s restored", backup.BackupID)
+}
+
+// postgresqlCredentials returns the owner credentials of the addon (real or addon ID)
+func postgresqlCredentials(ctx context.Context, cc *client.Client, organisation, postgresqlID string, diags *diag.Diagnostics) *tmp.PostgreSQL {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, postgresqlID)
+	if err != nil {
+		diags.AddError("failed to resolve database ID", err.Error())
+		return nil
+	}
+
+	pgRes := tmp.GetPostgreSQL(ctx, cc, addonID)
+	if pgRes.HasError() {
+		diags.AddError("failed to get database credentials", pgRes.Error().Error())
+		return nil
+	}
+	return pgRes.Payload()
+}
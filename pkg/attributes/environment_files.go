@@ -0,0 +1,373 @@
+package attributes
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg/envfile"
+	"go.clever-cloud.com/terraform-provider/pkg/sops"
+)
+
+// EnvironmentFiles loads environment variables from local files (dotenv, JSON, YAML).
+// Files are read at plan time, the resolved values are kept in two computed maps:
+// the sensitive one holds values coming from files only, the other one holds the
+// values of keys declared as non sensitive, so they show up in plan diffs.
+//
+// A SOPS encrypted file can also be given, it is decrypted with an age identity.
+// Its values are kept in their own sensitive map, alongside keyed digests which
+// let plans show which variables changed without disclosing them.
+//
+// Merge order: files in list order, then the SOPS file, then the inline `environment` map, the last wins.
+type EnvironmentFiles struct {
+	Files                   types.List   `tfsdk:"environment_files"`
+	SopsFile                types.String `tfsdk:"environment_sops_file"`
+	NonsensitiveKeys        types.Set    `tfsdk:"nonsensitive_keys"`
+	FromFiles               types.Map    `tfsdk:"environment_from_files"`
+	FromSops                types.Map    `tfsdk:"environment_from_sops"`
+	SopsDigests             types.Map    `tfsdk:"environment_sops_digests"`
+	NonsensitiveEnvironment types.Map    `tfsdk:"nonsensitive_environment"`
+}
+
+// NullEnvironmentFiles is used by state upgraders, for states written before those attributes
+var NullEnvironmentFiles = EnvironmentFiles{
+	Files:                   types.ListNull(types.StringType),
+	SopsFile:                types.StringNull(),
+	NonsensitiveKeys:        types.SetNull(types.StringType),
+	FromFiles:               types.MapNull(types.StringType),
+	FromSops:                types.MapNull(types.StringType),
+	SopsDigests:             types.MapNull(types.StringType),
+	NonsensitiveEnvironment: types.MapNull(types.StringType),
+}
+
+var EnvironmentFilesAttributes = map[string]schema.Attribute{
+	"environment_files": schema.ListAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Local files to load environment variables from, read at plan time. The format is guessed from the extension: `.json`, `.yaml`/`.yml`, otherwise dotenv (`KEY=value`, `export` prefix, single/double quotes and multiline quoted values are supported). Files are merged in order, then the `environment` map is applied on top. Relative paths are relative to the working directory, prefer `${path.module}/...`",
+	},
+	"environment_sops_file": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Local SOPS file encrypted with age, read and decrypted at plan time (dotenv, `.json` or `.yaml`/`.yml`, flat values only). It is applied after `environment_files` and before `environment`. The age identity comes from the provider `sops_age_key`, `SOPS_AGE_KEY`, `SOPS_AGE_KEY_FILE` or the sops default keys file",
+	},
+	"nonsensitive_keys": schema.SetAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Environment variable names whose values are not secret (`LOG_LEVEL`, `NODE_ENV`...). Their values are exposed in `nonsensitive_environment` and show up in plan diffs",
+	},
+	"environment_from_files": schema.MapAttribute{
+		Computed:            true,
+		Sensitive:           true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Environment variables loaded from `environment_files`, except the ones overridden by `environment` or listed in `nonsensitive_keys`",
+	},
+	"environment_from_sops": schema.MapAttribute{
+		Computed:            true,
+		Sensitive:           true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Environment variables decrypted from `environment_sops_file`, except the ones overridden by `environment` or listed in `nonsensitive_keys`",
+	},
+	"environment_sops_digests": schema.MapAttribute{
+		Computed:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Keyed digest of each `environment_from_sops` value, shows which variables changed in plans without disclosing them",
+	},
+	"nonsensitive_environment": schema.MapAttribute{
+		Computed:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Values of the `nonsensitive_keys` variables, whatever their source",
+	},
+}
+
+func (ef EnvironmentFiles) isUsed() bool {
+	return !ef.Files.IsNull() || !ef.SopsFile.IsNull() || !ef.NonsensitiveKeys.IsNull()
+}
+
+func (ef *EnvironmentFiles) setNull() {
+	ef.FromFiles = types.MapNull(types.StringType)
+	ef.FromSops = types.MapNull(types.StringType)
+	ef.SopsDigests = types.MapNull(types.StringType)
+	ef.NonsensitiveEnvironment = types.MapNull(types.StringType)
+}
+
+// decryptSops decrypts the SOPS file, nil when not set or on error
+func (ef EnvironmentFiles) decryptSops(ageKey string, diags *diag.Diagnostics) *sops.File {
+	if ef.SopsFile.IsNull() {
+		return nil
+	}
+
+	identities, err := sops.Identities(ageKey)
+	if err != nil {
+		diags.AddAttributeError(path.Root("environment_sops_file"), "failed to load age identity", err.Error())
+		return nil
+	}
+
+	file, err := sops.DecryptFile(ef.SopsFile.ValueString(), identities)
+	if err != nil {
+		diags.AddAttributeError(path.Root("environment_sops_file"), "failed to decrypt SOPS file", err.Error())
+		return nil
+	}
+
+	return file
+}
+
+// sources reads every file and the decrypted SOPS values, then append the inline environment as the last source
+func (ef EnvironmentFiles) sources(ctx context.Context, sopsFile *sops.File, inline types.Map, diags *diag.Diagnostics) ([]envfile.Source, map[string]string) {
+	sources := []envfile.Source{}
+
+	files := []string{}
+	if !ef.Files.IsNull() {
+		diags.Append(ef.Files.ElementsAs(ctx, &files, false)...)
+	}
+
+	for i, file := range files {
+		values, err := envfile.ReadFile(file)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("environment_files").AtListIndex(i),
+				"failed to read environment file",
+				err.Error(),
+			)
+			continue
+		}
+
+		sources = append(sources, envfile.Source{Name: file, Values: values})
+	}
+
+	if sopsFile != nil {
+		sources = append(sources, envfile.Source{Name: ef.SopsFile.ValueString(), Values: sopsFile.Values})
+	}
+
+	// do not use the real map since ElementAs can nullish it
+	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
+	inlineEnv := map[string]string{}
+	if !inline.IsNull() {
+		diags.Append(inline.ElementsAs(ctx, &inlineEnv, false)...)
+	}
+	sources = append(sources, envfile.Source{Name: "environment", Values: inlineEnv})
+
+	return sources, inlineEnv
+}
+
+func (ef EnvironmentFiles) nonsensitiveKeys(ctx context.Context, diags *diag.Diagnostics) map[string]bool {
+	keys := []string{}
+	if !ef.NonsensitiveKeys.IsNull() && !ef.NonsensitiveKeys.IsUnknown() {
+		diags.Append(ef.NonsensitiveKeys.ElementsAs(ctx, &keys, false)...)
+	}
+
+	set := map[string]bool{}
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// ModifyPlan reads the environment files, decrypts the SOPS one with ageKey
+// and set the computed maps in the plan.
+// Variables defined more than once are reported as warnings
+func (ef *EnvironmentFiles) ModifyPlan(ctx context.Context, ageKey string, inline types.Map, plan *tfsdk.Plan, diags *diag.Diagnostics) {
+	switch {
+	case !ef.isUsed():
+		ef.setNull()
+
+	case !isFullyKnown(ef.Files) || ef.SopsFile.IsUnknown() || ef.NonsensitiveKeys.IsUnknown() || inline.IsUnknown():
+		// will be resolved during apply
+		ef.FromFiles = types.MapUnknown(types.StringType)
+		ef.FromSops = types.MapUnknown(types.StringType)
+		ef.SopsDigests = types.MapUnknown(types.StringType)
+		ef.NonsensitiveEnvironment = types.MapUnknown(types.StringType)
+
+	default:
+		sopsFile := ef.decryptSops(ageKey, diags)
+		sources, inlineEnv := ef.sources(ctx, sopsFile, inline, diags)
+		if diags.HasError() {
+			return
+		}
+
+		merged, duplicates := envfile.Merge(sources...)
+		for _, duplicate := range duplicates {
+			diags.AddAttributeWarning(path.Root("environment_files"), "environment variable defined more than once", duplicate.String())
+		}
+
+		nonsensitive := ef.nonsensitiveKeys(ctx, diags)
+		fromFiles := map[string]string{}
+		fromSops := map[string]string{}
+		digests := map[string]string{}
+		public := map[string]string{}
+		for key, value := range merged {
+			if nonsensitive[key] {
+				public[key] = value
+			} else if _, isInline := inlineEnv[key]; isInline {
+				continue
+			} else if _, isSops := sopsValues(sopsFile)[key]; isSops {
+				fromSops[key] = value
+				digests[key] = sopsFile.Digest(key, value)
+			} else {
+				fromFiles[key] = value
+			}
+		}
+
+		m, d := types.MapValueFrom(ctx, types.StringType, fromFiles)
+		diags.Append(d...)
+		ef.FromFiles = m
+
+		if sopsFile == nil {
+			ef.FromSops = types.MapNull(types.StringType)
+			ef.SopsDigests = types.MapNull(types.StringType)
+		} else {
+			m, d = types.MapValueFrom(ctx, types.StringType, fromSops)
+			diags.Append(d...)
+			ef.FromSops = m
+
+			m, d = types.MapValueFrom(ctx, types.StringType, digests)
+			diags.Append(d...)
+			ef.SopsDigests = m
+		}
+
+		m, d = types.MapValueFrom(ctx, types.StringType, public)
+		diags.Append(d...)
+		ef.NonsensitiveEnvironment = m
+	}
+
+	diags.Append(plan.SetAttribute(ctx, path.Root("environment_from_files"), ef.FromFiles)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("environment_from_sops"), ef.FromSops)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("environment_sops_digests"), ef.SopsDigests)...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("nonsensitive_environment"), ef.NonsensitiveEnvironment)...)
+}
+
+// MergeEnvironment returns the variables from files merged with the inline environment,
+// ageKey is the provider age identity, see ModifyPlan
+func (ef EnvironmentFiles) MergeEnvironment(ctx context.Context, ageKey string, inline types.Map, diags *diag.Diagnostics) map[string]string {
+	if ef.FromFiles.IsUnknown() || ef.FromSops.IsUnknown() || ef.NonsensitiveEnvironment.IsUnknown() {
+		// not resolved by the plan, read files now
+		sources, _ := ef.sources(ctx, ef.decryptSops(ageKey, diags), inline, diags)
+		env, _ := envfile.Merge(sources...)
+		return env
+	}
+
+	env := map[string]string{}
+	for _, m := range []types.Map{ef.FromFiles, ef.FromSops, ef.NonsensitiveEnvironment, inline} {
+		if m.IsNull() {
+			continue
+		}
+
+		values := map[string]string{}
+		diags.Append(m.ElementsAs(ctx, &values, false)...)
+		maps.Copy(env, values)
+	}
+
+	return env
+}
+
+// ReadEnvironment pops from env the variables owned by the environment files,
+// variables also set inline are left in env to be reflected in `environment`
+func (ef *EnvironmentFiles) ReadEnvironment(ctx context.Context, env *helperMaps.Map[string, string], inline types.Map, diags *diag.Diagnostics) {
+	if !ef.isUsed() {
+		ef.setNull()
+		return
+	}
+
+	inlineKeys := map[string]attr.Value{}
+	if !inline.IsNull() && !inline.IsUnknown() {
+		inlineKeys = inline.Elements()
+	}
+
+	public := map[string]string{}
+	for key := range ef.nonsensitiveKeys(ctx, diags) {
+		value := env.PopPtr(key)
+		if value == nil {
+			continue
+		}
+
+		public[key] = *value
+		if _, isInline := inlineKeys[key]; isInline {
+			env.Set(key, *value)
+		}
+	}
+
+	fromFiles := map[string]string{}
+	if !ef.FromFiles.IsNull() && !ef.FromFiles.IsUnknown() {
+		for key := range ef.FromFiles.Elements() {
+			if _, isInline := inlineKeys[key]; isInline {
+				continue
+			}
+
+			if value := env.PopPtr(key); value != nil {
+				fromFiles[key] = *value
+			}
+		}
+	}
+
+	m, d := types.MapValueFrom(ctx, types.StringType, fromFiles)
+	diags.Append(d...)
+	ef.FromFiles = m
+
+	// the file cannot be decrypted here: digests of unchanged values are kept,
+	// the others are emptied so the next plan shows them as changed
+	if !ef.FromSops.IsNull() && !ef.FromSops.IsUnknown() {
+		known := map[string]string{}
+		diags.Append(ef.FromSops.ElementsAs(ctx, &known, false)...)
+		knownDigests := map[string]string{}
+		if !ef.SopsDigests.IsNull() && !ef.SopsDigests.IsUnknown() {
+			diags.Append(ef.SopsDigests.ElementsAs(ctx, &knownDigests, false)...)
+		}
+
+		fromSops := map[string]string{}
+		digests := map[string]string{}
+		for key, knownValue := range known {
+			if _, isInline := inlineKeys[key]; isInline {
+				continue
+			}
+
+			value := env.PopPtr(key)
+			if value == nil {
+				continue
+			}
+
+			fromSops[key] = *value
+			digests[key] = ""
+			if *value == knownValue {
+				digests[key] = knownDigests[key]
+			}
+		}
+
+		m, d = types.MapValueFrom(ctx, types.StringType, fromSops)
+		diags.Append(d...)
+		ef.FromSops = m
+
+		m, d = types.MapValueFrom(ctx, types.StringType, digests)
+		diags.Append(d...)
+		ef.SopsDigests = m
+	}
+
+	m, d = types.MapValueFrom(ctx, types.StringType, public)
+	diags.Append(d...)
+	ef.NonsensitiveEnvironment = m
+}
+
+func sopsValues(file *sops.File) map[string]string {
+	if file == nil {
+		return nil
+	}
+	return file.Values
+}
+
+func isFullyKnown(list types.List) bool {
+	if list.IsUnknown() {
+		return false
+	}
+
+	for _, item := range list.Elements() {
+		if item.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,261 @@
+package attributes
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+const (
+	CC_WORKER_COMMAND_PREFIX    = "CC_WORKER_COMMAND_"
+	CC_WORKER_RESTART           = "CC_WORKER_RESTART"
+	CC_HEALTH_CHECK_PATH_PREFIX = "CC_HEALTH_CHECK_PATH_"
+)
+
+var WorkerRestartPolicies = []string{"always", "on-failure", "no"}
+
+// Worker is a background process started next to the application (CC_WORKER_COMMAND_N)
+type Worker struct {
+	Command types.String `tfsdk:"command"`
+	Restart types.String `tfsdk:"restart"`
+}
+
+var WorkerSchema = map[string]attr.Type{
+	"command": types.StringType,
+	"restart": types.StringType,
+}
+
+var NullWorkers = types.ListNull(types.ObjectType{AttrTypes: WorkerSchema})
+
+// HealthCheck lists the paths the deployer must get a 2xx response from (CC_HEALTH_CHECK_PATH_N)
+type HealthCheck struct {
+	Paths types.List `tfsdk:"paths"`
+}
+
+var WorkersAttribute = schema.ListNestedAttribute{
+	Optional:            true,
+	MarkdownDescription: "Background processes started with the application, the list index is the worker number ([CC_WORKER_COMMAND_N](https://www.clever.cloud/developers/doc/develop/workers/)). Do not set `CC_WORKER_*` variables in `environment` when this attribute is used",
+	NestedObject: schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"command": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Command to run",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"restart": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Restart policy: `always`, `on-failure` or `no` ([CC_WORKER_RESTART](https://www.clever.cloud/developers/doc/develop/workers/)). The policy applies to every worker of the application, all workers setting it must agree. Default: `on-failure`",
+				Validators:          []validator.String{stringvalidator.OneOf(WorkerRestartPolicies...)},
+			},
+		},
+	},
+}
+
+var HealthCheckAttribute = schema.SingleNestedAttribute{
+	Optional:            true,
+	MarkdownDescription: "Deployment health check, the deployment succeeds once every path answers with a 2xx status ([CC_HEALTH_CHECK_PATH_N](https://www.clever.cloud/developers/doc/develop/healthcheck/)). Do not set `CC_HEALTH_CHECK_PATH_*` variables in `environment` when this attribute is used",
+	Attributes: map[string]schema.Attribute{
+		"paths": schema.ListAttribute{
+			Required:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Paths to check, the list index is the variable number",
+			Validators: []validator.List{
+				pkg.NewListValidator("paths must start with /", func(ctx context.Context, req validator.ListRequest, res *validator.ListResponse) {
+					for i, item := range req.ConfigValue.Elements() {
+						value, ok := item.(types.String)
+						if !ok || value.IsNull() || value.IsUnknown() {
+							continue
+						}
+
+						if !strings.HasPrefix(value.ValueString(), "/") {
+							res.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "invalid health check path", fmt.Sprintf("path must start with '/' (got: '%s')", value.ValueString()))
+						}
+					}
+				}),
+			},
+		},
+	},
+}
+
+// WorkersToEnv serializes workers to CC_WORKER_COMMAND_N and CC_WORKER_RESTART
+func WorkersToEnv(ctx context.Context, workers types.List, diags *diag.Diagnostics) map[string]string {
+	m := map[string]string{}
+	if workers.IsNull() || workers.IsUnknown() {
+		return m
+	}
+
+	items := []Worker{}
+	diags.Append(workers.ElementsAs(ctx, &items, false)...)
+
+	for i, worker := range items {
+		pkg.IfIsSetStr(worker.Command, func(command string) {
+			m[fmt.Sprintf("%s%d", CC_WORKER_COMMAND_PREFIX, i)] = command
+		})
+		pkg.IfIsSetStr(worker.Restart, func(restart string) {
+			m[CC_WORKER_RESTART] = restart
+		})
+	}
+
+	return m
+}
+
+// FromEnvWorkers extracts CC_WORKER_* variables, only when workers are managed
+// with the typed attribute: users who set them in `environment` keep them there
+func FromEnvWorkers(ctx context.Context, env *helperMaps.Map[string, string], oldValue types.List, diags *diag.Diagnostics) types.List {
+	if oldValue.IsNull() {
+		return NullWorkers
+	}
+
+	commands := popIndexed(env, CC_WORKER_COMMAND_PREFIX)
+	restart := pkg.FromStrPtr(env.PopPtr(CC_WORKER_RESTART))
+
+	// keep the restart policy on the workers where the user set it
+	oldWorkers := []Worker{}
+	if !oldValue.IsUnknown() {
+		diags.Append(oldValue.ElementsAs(ctx, &oldWorkers, false)...)
+	}
+
+	workers := make([]Worker, len(commands))
+	for i, command := range commands {
+		workers[i] = Worker{Command: types.StringValue(command), Restart: types.StringNull()}
+		if i < len(oldWorkers) && !oldWorkers[i].Restart.IsNull() {
+			workers[i].Restart = restart
+		}
+	}
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: WorkerSchema}, workers)
+	diags.Append(d...)
+	return list
+}
+
+func (hc *HealthCheck) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
+	m := map[string]string{}
+	if hc == nil || hc.Paths.IsNull() || hc.Paths.IsUnknown() {
+		return m
+	}
+
+	paths := []string{}
+	diags.Append(hc.Paths.ElementsAs(ctx, &paths, false)...)
+	for i, p := range paths {
+		m[fmt.Sprintf("%s%d", CC_HEALTH_CHECK_PATH_PREFIX, i)] = p
+	}
+
+	return m
+}
+
+// FromEnvHealthCheck extracts CC_HEALTH_CHECK_PATH_N variables, same rules as FromEnvWorkers
+func FromEnvHealthCheck(ctx context.Context, env *helperMaps.Map[string, string], oldValue *HealthCheck, diags *diag.Diagnostics) *HealthCheck {
+	if oldValue == nil {
+		return nil
+	}
+
+	paths, d := types.ListValueFrom(ctx, types.StringType, popIndexed(env, CC_HEALTH_CHECK_PATH_PREFIX))
+	diags.Append(d...)
+	return &HealthCheck{Paths: paths}
+}
+
+// popIndexed pops PREFIX_N variables, ordered by index
+func popIndexed(env *helperMaps.Map[string, string], prefix string) []string {
+	indexes := []int{}
+	for key := range env.All {
+		if index, ok := IndexOf(key, prefix); ok {
+			indexes = append(indexes, index)
+		}
+	}
+	slices.Sort(indexes)
+
+	values := []string{}
+	for _, index := range indexes {
+		if value := env.PopPtr(prefix + strconv.Itoa(index)); value != nil {
+			values = append(values, *value)
+		}
+	}
+
+	return values
+}
+
+// IndexOf parses the N of PREFIX_N, ok is false when key has another prefix or an invalid index
+func IndexOf(key, prefix string) (int, bool) {
+	suffix, found := strings.CutPrefix(key, prefix)
+	if !found {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 0 || strconv.Itoa(index) != suffix {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// ValidateWorkers checks workers and health check against the custom environment
+// (inline map merged with the environment files): indexed keys must have a valid index.
+// Keys also managed by the typed attributes are warnings, as for the other typed attributes:
+// the typed values win the merge. sourceOf gives the attribute defining a variable
+func ValidateWorkers(ctx context.Context, workers types.List, healthCheck *HealthCheck, environment map[string]string, sourceOf func(key string) path.Path, diags *diag.Diagnostics) {
+	if !workers.IsNull() && !workers.IsUnknown() {
+		items := []Worker{}
+		diags.Append(workers.ElementsAs(ctx, &items, false)...)
+
+		restarts := map[string]bool{}
+		for _, worker := range items {
+			pkg.IfIsSetStr(worker.Restart, func(restart string) { restarts[restart] = true })
+		}
+		if len(restarts) > 1 {
+			diags.AddAttributeError(
+				path.Root("workers"),
+				"conflicting worker restart policies",
+				"Clever Cloud applies a single restart policy (CC_WORKER_RESTART) to every worker, use the same value on all workers",
+			)
+		}
+	}
+
+	for key := range environment {
+		for prefix, attribute := range map[string]string{
+			CC_WORKER_COMMAND_PREFIX:    "workers",
+			CC_HEALTH_CHECK_PATH_PREFIX: "health_check",
+		} {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+
+			if _, ok := IndexOf(key, prefix); !ok {
+				diags.AddAttributeError(
+					sourceOf(key),
+					"invalid variable index",
+					fmt.Sprintf("expect %sN, where N is a positive integer without leading zero", prefix),
+				)
+			}
+
+			isTyped := (attribute == "workers" && !workers.IsNull()) || (attribute == "health_check" && healthCheck != nil)
+			if isTyped {
+				diags.AddAttributeWarning(
+					sourceOf(key),
+					"environment variable conflicts with a typed attribute",
+					fmt.Sprintf("%s is also managed by '%s', values set by '%s' take precedence, remove it from '%s'", key, attribute, attribute, sourceOf(key)),
+				)
+			}
+		}
+
+		if key == CC_WORKER_RESTART && !workers.IsNull() {
+			diags.AddAttributeWarning(
+				sourceOf(key),
+				"environment variable conflicts with a typed attribute",
+				fmt.Sprintf("%s is also managed by 'workers', values set by 'workers' take precedence, remove it from '%s'", key, sourceOf(key)),
+			)
+		}
+	}
+}
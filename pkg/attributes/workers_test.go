@@ -0,0 +1,150 @@
+package attributes
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+)
+
+func TestIndexOf(t *testing.T) {
+	tests := []struct {
+		key       string
+		wantIndex int
+		wantOk    bool
+	}{
+		{"CC_WORKER_COMMAND_0", 0, true},
+		{"CC_WORKER_COMMAND_12", 12, true},
+		{"CC_WORKER_COMMAND_01", 0, false},
+		{"CC_WORKER_COMMAND_-1", 0, false},
+		{"CC_WORKER_COMMAND_X", 0, false},
+		{"CC_WORKER_COMMAND_", 0, false},
+		{"CC_WORKER_RESTART", 0, false},
+	}
+
+	for _, tt := range tests {
+		index, ok := IndexOf(tt.key, CC_WORKER_COMMAND_PREFIX)
+		if index != tt.wantIndex || ok != tt.wantOk {
+			t.Errorf("IndexOf(%s) = %d, %v, want %d, %v", tt.key, index, ok, tt.wantIndex, tt.wantOk)
+		}
+	}
+}
+
+func TestWorkersEnvRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	diags := diag.Diagnostics{}
+
+	workers, _ := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: WorkerSchema}, []Worker{
+		{Command: types.StringValue("./worker a"), Restart: types.StringValue("always")},
+		{Command: types.StringValue("./worker b"), Restart: types.StringNull()},
+	})
+
+	env := WorkersToEnv(ctx, workers, &diags)
+	want := map[string]string{
+		"CC_WORKER_COMMAND_0": "./worker a",
+		"CC_WORKER_COMMAND_1": "./worker b",
+		"CC_WORKER_RESTART":   "always",
+	}
+	if !reflect.DeepEqual(env, want) {
+		t.Fatalf("WorkersToEnv() = %v, want %v", env, want)
+	}
+
+	env["OTHER"] = "1"
+	m := helperMaps.NewMap(env)
+	got := FromEnvWorkers(ctx, m, workers, &diags)
+	if diags.HasError() {
+		t.Fatalf("FromEnvWorkers() diags = %v", diags)
+	}
+	if !got.Equal(workers) {
+		t.Errorf("FromEnvWorkers() = %v, want %v", got, workers)
+	}
+	if m.Size() != 1 {
+		t.Errorf("FromEnvWorkers() must pop worker variables, %d left", m.Size())
+	}
+
+	// not managed with the typed attribute, the raw variables stay in env
+	m = helperMaps.NewMap(map[string]string{"CC_WORKER_COMMAND_0": "./worker"})
+	if got := FromEnvWorkers(ctx, m, NullWorkers, &diags); !got.IsNull() || m.Size() != 1 {
+		t.Errorf("FromEnvWorkers() must ignore unmanaged workers")
+	}
+}
+
+func TestValidateWorkers(t *testing.T) {
+	ctx := context.Background()
+	workers, _ := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: WorkerSchema}, []Worker{
+		{Command: types.StringValue("./worker"), Restart: types.StringNull()},
+	})
+
+	// variables from the environment files are reported on the file attribute
+	sourceOf := func(key string) path.Path {
+		if key == "CC_WORKER_COMMAND_5" {
+			return path.Root("environment_files")
+		}
+		return path.Root("environment").AtMapKey(key)
+	}
+
+	tests := []struct {
+		name        string
+		workers     types.List
+		healthCheck *HealthCheck
+		env         map[string]string
+		expectError bool
+		expectWarn  bool
+	}{{
+		name:    "raw workers only",
+		workers: NullWorkers,
+		env:     map[string]string{"CC_WORKER_COMMAND_0": "./worker", "CC_WORKER_RESTART": "no"},
+	}, {
+		name:        "invalid index",
+		workers:     NullWorkers,
+		env:         map[string]string{"CC_WORKER_COMMAND_first": "./worker"},
+		expectError: true,
+	}, {
+		name:       "conflicting worker command",
+		workers:    workers,
+		env:        map[string]string{"CC_WORKER_COMMAND_3": "./worker"},
+		expectWarn: true,
+	}, {
+		name:       "conflicting worker command from a file",
+		workers:    workers,
+		env:        map[string]string{"CC_WORKER_COMMAND_5": "./worker"},
+		expectWarn: true,
+	}, {
+		name:       "conflicting restart policy",
+		workers:    workers,
+		env:        map[string]string{"CC_WORKER_RESTART": "always"},
+		expectWarn: true,
+	}, {
+		name:        "conflicting health check",
+		workers:     NullWorkers,
+		healthCheck: &HealthCheck{Paths: types.ListNull(types.StringType)},
+		env:         map[string]string{"CC_HEALTH_CHECK_PATH_0": "/health"},
+		expectWarn:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			ValidateWorkers(ctx, tt.workers, tt.healthCheck, tt.env, sourceOf, &diags)
+			if diags.HasError() != tt.expectError {
+				t.Errorf("ValidateWorkers() diags = %v, expectError %v", diags, tt.expectError)
+			}
+			if (diags.WarningsCount() > 0) != tt.expectWarn {
+				t.Errorf("ValidateWorkers() diags = %v, expectWarn %v", diags, tt.expectWarn)
+			}
+		})
+	}
+
+	diags := diag.Diagnostics{}
+	ValidateWorkers(ctx, workers, nil, map[string]string{"CC_WORKER_COMMAND_5": "./worker"}, sourceOf, &diags)
+	if len(diags) != 1 {
+		t.Fatalf("ValidateWorkers() diags = %v, want a single conflict", diags)
+	}
+	if got := diags[0].(diag.DiagnosticWithPath).Path(); !got.Equal(path.Root("environment_files")) {
+		t.Errorf("ValidateWorkers() path = %s, want environment_files", got)
+	}
+}
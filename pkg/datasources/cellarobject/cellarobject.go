@@ -0,0 +1,20 @@
+package cellarobject
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type DataSourceCellarObject struct {
+	helper.DataSourceConfigurer
+}
+
+func NewDataSourceCellarObject() datasource.DataSource {
+	return &DataSourceCellarObject{}
+}
+
+func (d *DataSourceCellarObject) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cellar_object"
+}
@@ -0,0 +1,73 @@
+package cellarobject_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccDataSourceCellarObject_basic(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	cellarName := acctest.RandomWithPrefix("tf-test-cellar")
+	bucketName := acctest.RandomWithPrefix("tf-test-objects")
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+
+	cellarBlock := helper.NewRessource(
+		"clevercloud_cellar",
+		"cellar_datasource",
+		helper.SetKeyValues(map[string]any{"name": cellarName}),
+	)
+	bucketBlock := helper.NewRessource(
+		"clevercloud_cellar_bucket",
+		"objects",
+		helper.SetKeyValues(map[string]any{
+			"id":        bucketName,
+			"cellar_id": "${clevercloud_cellar.cellar_datasource.id}",
+		}))
+	objectBlock := helper.NewRessource(
+		"clevercloud_cellar_object",
+		"config",
+		helper.SetKeyValues(map[string]any{
+			"cellar_id":    "${clevercloud_cellar.cellar_datasource.id}",
+			"bucket":       "${clevercloud_cellar_bucket.objects.id}",
+			"key":          "config/app.json",
+			"content":      `{"env":"test"}`,
+			"content_type": "application/json",
+			"metadata":     map[string]any{"Owner": "terraform"},
+		}))
+	dataBlock := helper.NewDataRessource(
+		"clevercloud_cellar_object",
+		"config",
+		helper.SetKeyValues(map[string]any{
+			"cellar_id": "${clevercloud_cellar_object.config.cellar_id}",
+			"bucket":    "${clevercloud_cellar_object.config.bucket}",
+			"key":       "${clevercloud_cellar_object.config.key}",
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: "data.clevercloud_cellar_object.config",
+			Config:       providerBlock.Append(cellarBlock, bucketBlock, objectBlock, dataBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("content"), knownvalue.StringExact(`{"env":"test"}`)),
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("content_type"), knownvalue.StringExact("application/json")),
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("size"), knownvalue.Int64Exact(14)),
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("metadata").AtMapKey("owner"), knownvalue.StringExact("terraform")),
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("last_modified"), knownvalue.StringRegexp(pkg.RFC3339Regexp)),
+				statecheck.ExpectKnownValue("data.clevercloud_cellar_object.config", tfjsonpath.New("id"), knownvalue.StringRegexp(regexp.MustCompile(`/config/app\.json$`))),
+			},
+		}},
+	})
+}
@@ -0,0 +1,82 @@
+package cellarobject
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	minio "github.com/minio/minio-go/v7"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/s3"
+)
+
+func (d *DataSourceCellarObject) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	config := helper.From[CellarObject](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, key := config.Bucket.ValueString(), config.Key.ValueString()
+	tflog.Debug(ctx, "Reading Cellar object", map[string]any{"bucket": bucket, "key": key})
+
+	minioClient, _, err := s3.Connect(ctx, d.Client(), d.Organization(), config.CellarID.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to connect to Cellar addon", err.Error())
+		return
+	}
+
+	object, err := minioClient.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		res.Diagnostics.AddError("failed to get object", err.Error())
+		return
+	}
+	defer func() { _ = object.Close() }()
+
+	info, err := object.Stat()
+	if err != nil {
+		res.Diagnostics.AddError("failed to get object", fmt.Sprintf("%s/%s: %s", bucket, key, err.Error()))
+		return
+	}
+
+	config.ContentType = pkg.FromStr(info.ContentType)
+	config.CacheControl = pkg.FromStr(info.Metadata.Get("Cache-Control"))
+	config.ETag = types.StringValue(info.ETag)
+	config.Size = types.Int64Value(info.Size)
+	config.LastModified = types.StringValue(info.LastModified.Format(time.RFC3339))
+
+	metadata := map[string]string{}
+	for name, value := range info.UserMetadata {
+		metadata[strings.ToLower(name)] = value
+	}
+	metadataValue, diags := types.MapValueFrom(ctx, types.StringType, metadata)
+	res.Diagnostics.Append(diags...)
+	config.Metadata = metadataValue
+
+	config.Content = types.StringNull()
+	config.ContentBase64 = types.StringNull()
+	if info.Size <= MaxContentSize {
+		content, err := io.ReadAll(object)
+		if err != nil {
+			res.Diagnostics.AddError("failed to read object", err.Error())
+			return
+		}
+
+		config.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content))
+		if utf8.Valid(content) {
+			config.Content = types.StringValue(string(content))
+		}
+	} else {
+		tflog.Debug(ctx, "Object too large, content is not read", map[string]any{"size": info.Size})
+	}
+
+	config.ID = types.StringValue(bucket + "/" + key)
+	res.Diagnostics.Append(res.State.Set(ctx, config)...)
+}
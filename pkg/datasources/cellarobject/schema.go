@@ -0,0 +1,91 @@
+package cellarobject
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type CellarObject struct {
+	CellarID types.String `tfsdk:"cellar_id"`
+	Bucket   types.String `tfsdk:"bucket"`
+	Key      types.String `tfsdk:"key"`
+
+	// Computed attributes
+	ID            types.String `tfsdk:"id"`
+	Content       types.String `tfsdk:"content"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	ContentType   types.String `tfsdk:"content_type"`
+	CacheControl  types.String `tfsdk:"cache_control"`
+	Metadata      types.Map    `tfsdk:"metadata"`
+	ETag          types.String `tfsdk:"etag"`
+	Size          types.Int64  `tfsdk:"size"`
+	LastModified  types.String `tfsdk:"last_modified"`
+}
+
+// MaxContentSize is the size above which the content is not read
+const MaxContentSize = 10 * 1024 * 1024
+
+//go:embed doc.md
+var cellarObjectDoc string
+
+func (d *DataSourceCellarObject) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Retrieves the content and metadata of a Cellar object.",
+		MarkdownDescription: cellarObjectDoc,
+		Attributes: map[string]schema.Attribute{
+			"cellar_id": schema.StringAttribute{
+				Required:    true,
+				Description: "Cellar addon ID the bucket belongs to",
+			},
+			"bucket": schema.StringAttribute{
+				Required:    true,
+				Description: "Bucket name",
+			},
+			"key": schema.StringAttribute{
+				Required:    true,
+				Description: "Object key",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Object identifier: <bucket>/<key>",
+			},
+			"content": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content of the object, null when it is not valid UTF-8 text or larger than 10MiB",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content of the object, base64 encoded, null when larger than 10MiB",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "MIME type of the object",
+			},
+			"cache_control": schema.StringAttribute{
+				Computed:    true,
+				Description: "Cache-Control header of the object",
+			},
+			"metadata": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "User metadata, with lowercase keys",
+			},
+			"etag": schema.StringAttribute{
+				Computed:    true,
+				Description: "ETag of the object, the MD5 of its content for single part uploads",
+			},
+			"size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the object, in bytes",
+			},
+			"last_modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ISO8601 timestamp of the last change of the object",
+			},
+		},
+	}
+}
@@ -0,0 +1,20 @@
+package fsbucketfiles
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type DataSourceFSBucketFiles struct {
+	helper.DataSourceConfigurer
+}
+
+func NewDataSourceFSBucketFiles() datasource.DataSource {
+	return &DataSourceFSBucketFiles{}
+}
+
+func (d *DataSourceFSBucketFiles) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_fsbucket_files"
+}
@@ -0,0 +1,57 @@
+package fsbucketfiles_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccDataSourceFSBucketFiles_basic(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	fsbucketName := acctest.RandomWithPrefix("tf-test-fsbucket")
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+
+	fsbucketBlock := helper.NewRessource(
+		"clevercloud_fsbucket",
+		"files",
+		helper.SetKeyValues(map[string]any{"name": fsbucketName}),
+	)
+	dataBlock := helper.NewDataRessource(
+		"clevercloud_fsbucket_files",
+		"root",
+		helper.SetKeyValues(map[string]any{
+			"fsbucket_id": "${clevercloud_fsbucket.files.id}",
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: "data.clevercloud_fsbucket_files.root",
+			Config:       providerBlock.Append(fsbucketBlock, dataBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("data.clevercloud_fsbucket_files.root", tfjsonpath.New("id"), knownvalue.StringRegexp(regexp.MustCompile(`^bucket_.*:/$`))),
+				statecheck.ExpectKnownValue("data.clevercloud_fsbucket_files.root", tfjsonpath.New("files"), knownvalue.NotNull()),
+			},
+		}, {
+			ResourceName: "data.clevercloud_fsbucket_files.missing",
+			Config: providerBlock.Append(fsbucketBlock, helper.NewDataRessource(
+				"clevercloud_fsbucket_files",
+				"missing",
+				helper.SetKeyValues(map[string]any{
+					"fsbucket_id": "${clevercloud_fsbucket.files.id}",
+					"path":        "/does/not/exist",
+				}))).String(),
+			ExpectError: regexp.MustCompile(`directory not found`),
+		}},
+	})
+}
@@ -0,0 +1,55 @@
+package fsbucketfiles
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg/fsbucket"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+func (d *DataSourceFSBucketFiles) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	config := helper.From[FSBucketFiles](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	fsbucketID := config.FSBucketID.ValueString()
+	dir := path.Clean("/" + config.Path.ValueString())
+	recursive := config.Recursive.IsNull() || config.Recursive.ValueBool()
+	tflog.Debug(ctx, "Listing FSBucket files", map[string]any{"fsbucket": fsbucketID, "path": dir, "recursive": recursive})
+
+	conn, err := fsbucket.Connect(ctx, d.Client(), d.Organization(), fsbucketID)
+	if err != nil {
+		res.Diagnostics.AddError("failed to connect to FSBucket", err.Error())
+		return
+	}
+	defer func() { _ = conn.Quit() }()
+
+	files, err := fsbucket.List(conn, dir, recursive)
+	if fsbucket.IsNotFound(err) {
+		res.Diagnostics.AddError("directory not found", fmt.Sprintf("'%s' does not exist in %s", dir, fsbucketID))
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to list files", err.Error())
+		return
+	}
+
+	config.Files = make([]FSBucketFile, len(files))
+	for i, file := range files {
+		config.Files[i] = FSBucketFile{
+			Path:         types.StringValue(file.Path),
+			Size:         types.Int64Value(file.Size),
+			LastModified: types.StringValue(file.ModTime.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	config.ID = types.StringValue(fsbucketID + ":" + dir)
+	res.Diagnostics.Append(res.State.Set(ctx, config)...)
+}
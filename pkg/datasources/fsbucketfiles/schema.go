@@ -0,0 +1,80 @@
+package fsbucketfiles
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type FSBucketFiles struct {
+	FSBucketID types.String `tfsdk:"fsbucket_id"`
+	Path       types.String `tfsdk:"path"`
+	Recursive  types.Bool   `tfsdk:"recursive"`
+
+	// Computed attributes
+	ID    types.String   `tfsdk:"id"`
+	Files []FSBucketFile `tfsdk:"files"`
+}
+
+type FSBucketFile struct {
+	Path         types.String `tfsdk:"path"`
+	Size         types.Int64  `tfsdk:"size"`
+	LastModified types.String `tfsdk:"last_modified"`
+}
+
+//go:embed doc.md
+var fsbucketFilesDoc string
+
+func (d *DataSourceFSBucketFiles) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists the files stored in a directory of an FSBucket.",
+		MarkdownDescription: fsbucketFilesDoc,
+		Attributes: map[string]schema.Attribute{
+			"fsbucket_id": schema.StringAttribute{
+				Required:    true,
+				Description: "FSBucket addon ID (format: bucket_xxx)",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^bucket_`), "must be a valid FSBucket addon ID (format: bucket_xxx)"),
+				},
+			},
+			"path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Directory to list, defaults to the root of the bucket",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "List the files of the subdirectories too, defaults to true",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Listing identifier: <fsbucket_id>:<path>",
+			},
+			"files": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Files under the directory, sorted by path",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path of the file, relative to the listed directory",
+						},
+						"size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Size of the file, in bytes",
+						},
+						"last_modified": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ISO8601 timestamp of the last change of the file",
+						},
+					},
+				},
+			},
+		},
+	}
+}
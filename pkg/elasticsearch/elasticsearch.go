@@ -0,0 +1,213 @@
+// Package elasticsearch calls the REST API of Elasticsearch addons with their credentials
+// to manage objects inside the cluster (index templates, ILM policies, users...).
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// Cluster is a REST client on an Elasticsearch addon
+type Cluster struct {
+	Endpoint string
+	User     string
+	Password string
+	http     *http.Client
+}
+
+// Error is an error answered by the REST API
+type Error struct {
+	StatusCode int
+	Type       string
+	Reason     string
+}
+
+func (e *Error) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("elasticsearch answered %d", e.StatusCode)
+	}
+	return fmt.Sprintf("elasticsearch answered %d: %s: %s", e.StatusCode, e.Type, e.Reason)
+}
+
+// Connect creates a client for the elasticsearchID addon (real ID), authenticated with the addon credentials
+func Connect(ctx context.Context, cc *client.Client, organisation, elasticsearchID string) (*Cluster, error) {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, elasticsearchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get addon ID: %w", err)
+	}
+
+	elasticRes := tmp.GetElasticsearch(ctx, cc, addonID)
+	if elasticRes.HasError() {
+		return nil, fmt.Errorf("failed to get Elasticsearch: %w", elasticRes.Error())
+	}
+	elastic := elasticRes.Payload()
+
+	return New(elastic.Host, elastic.User, elastic.Password), nil
+}
+
+// New creates a client on host, a hostname or a URL
+func New(host, user, password string) *Cluster {
+	endpoint := host
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	return &Cluster{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		User:     user,
+		Password: password,
+		http:     &http.Client{Timeout: time.Minute},
+	}
+}
+
+// Get decodes the JSON document at path in out
+func (c *Cluster) Get(ctx context.Context, path string, out any) error {
+	return c.Do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Put sends body as a JSON document
+func (c *Cluster) Put(ctx context.Context, path string, body any) error {
+	return c.Do(ctx, http.MethodPut, path, body, nil)
+}
+
+func (c *Cluster) Delete(ctx context.Context, path string) error {
+	return c.Do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// Do sends a request with an optional JSON body, and decodes the response in out when not nil
+func (c *Cluster) Do(ctx context.Context, method, path string, body any, out any) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.Endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.User, c.Password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	content, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if res.StatusCode >= 300 {
+		return errorFrom(res.StatusCode, content)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// errorFrom parses an error document: {"error": {"type": "...", "reason": "..."}, "status": 404}
+func errorFrom(statusCode int, content []byte) *Error {
+	apiErr := &Error{StatusCode: statusCode}
+
+	document := struct {
+		Error json.RawMessage `json:"error"`
+	}{}
+	if json.Unmarshal(content, &document) != nil || len(document.Error) == 0 {
+		return apiErr
+	}
+
+	details := struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	}{}
+	if json.Unmarshal(document.Error, &details) == nil {
+		apiErr.Type, apiErr.Reason = details.Type, details.Reason
+	} else {
+		// some APIs answer a plain message
+		_ = json.Unmarshal(document.Error, &apiErr.Reason)
+	}
+
+	return apiErr
+}
+
+// IsNotFound reports whether err is an API error answered with 404
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// SameDocument reports whether the remote JSON document holds all the values of the configured one,
+// whatever their formatting and fields order.
+// Fields only in the remote document are defaults added by Elasticsearch, and ignored
+func SameDocument(configured, remote string) bool {
+	var configuredValue, remoteValue any
+	if json.Unmarshal([]byte(configured), &configuredValue) != nil || json.Unmarshal([]byte(remote), &remoteValue) != nil {
+		return configured == remote
+	}
+	return Contains(remoteValue, configuredValue)
+}
+
+// Contains reports whether the decoded JSON value holds every field of subset
+func Contains(value, subset any) bool {
+	switch subset := subset.(type) {
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, field := range subset {
+			if !Contains(object[key], field) {
+				return false
+			}
+		}
+		return true
+
+	case []any:
+		array, ok := value.([]any)
+		if !ok || len(array) != len(subset) {
+			return false
+		}
+		for i := range subset {
+			if !Contains(array[i], subset[i]) {
+				return false
+			}
+		}
+		return true
+
+	case nil:
+		return value == nil
+
+	default:
+		// settings are answered as strings: "1", "true"
+		switch value.(type) {
+		case map[string]any, []any, nil:
+			return false
+		}
+		return reflect.DeepEqual(value, subset) || fmt.Sprint(value) == fmt.Sprint(subset)
+	}
+}
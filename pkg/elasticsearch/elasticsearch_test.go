@@ -0,0 +1,109 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/_ilm/policy/logs":
+			_, _ = w.Write([]byte(`{"logs": {"version": 1}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception", "reason": "policy [missing] not found"}, "status": 404}`))
+		}
+	}))
+	defer server.Close()
+	cluster := New(server.URL, "admin", "secret")
+
+	policies := map[string]struct {
+		Version int `json:"version"`
+	}{}
+	if err := cluster.Get(context.Background(), "/_ilm/policy/logs", &policies); err != nil {
+		t.Fatal(err)
+	}
+	if policies["logs"].Version != 1 {
+		t.Errorf("expect version 1, got %+v", policies)
+	}
+
+	err := cluster.Get(context.Background(), "/_ilm/policy/missing", &policies)
+	if !IsNotFound(err) {
+		t.Fatalf("expect a not found error, got %v", err)
+	}
+	if expected := "elasticsearch answered 404: resource_not_found_exception: policy [missing] not found"; err.Error() != expected {
+		t.Errorf("expect %q, got %q", expected, err.Error())
+	}
+
+	err = New(server.URL, "admin", "wrong").Delete(context.Background(), "/_ilm/policy/logs")
+	if err == nil || IsNotFound(err) {
+		t.Errorf("expect an unauthorized error, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{"xxx-elasticsearch.services.clever-cloud.com", "https://xxx-elasticsearch.services.clever-cloud.com"},
+		{"http://localhost:9200/", "http://localhost:9200"},
+	}
+
+	for _, tt := range tests {
+		if got := New(tt.host, "", "").Endpoint; got != tt.expected {
+			t.Errorf("expect %s, got %s", tt.expected, got)
+		}
+	}
+}
+
+func TestSameDocument(t *testing.T) {
+	remote := `{
+  "phases": {
+    "hot": {"min_age": "0ms", "actions": {"rollover": {"max_age": "1d", "max_primary_shard_size": "50gb"}}},
+    "delete": {"min_age": "30d", "actions": {"delete": {"delete_searchable_snapshot": true}}}
+  },
+  "settings": {"number_of_shards": "1"}
+}`
+
+	tests := []struct {
+		name       string
+		configured string
+		expected   bool
+	}{
+		{"defaults added by elasticsearch", `{"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {}}}}}`, true},
+		{"number answered as a string", `{"settings": {"number_of_shards": 1}}`, true},
+		{"changed value", `{"phases": {"delete": {"min_age": "90d"}}}`, false},
+		{"missing field", `{"phases": {"warm": {"min_age": "7d"}}}`, false},
+		{"object instead of a value", `{"settings": {"number_of_shards": {"value": 1}}}`, false},
+		{"invalid JSON", `{"phases":`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameDocument(tt.configured, remote); got != tt.expected {
+				t.Errorf("expect %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestContainsArrays(t *testing.T) {
+	if !Contains([]any{"a", "b"}, []any{"a", "b"}) {
+		t.Errorf("expect same arrays to match")
+	}
+	if Contains([]any{"a", "b"}, []any{"a"}) {
+		t.Errorf("expect an array with another length not to match")
+	}
+	if Contains([]any{"b", "a"}, []any{"a", "b"}) {
+		t.Errorf("expect the order of arrays to matter")
+	}
+}
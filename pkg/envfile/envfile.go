@@ -0,0 +1,299 @@
+// Package envfile reads environment variables from local files.
+//
+// Three formats are supported, selected by the file extension:
+//   - dotenv (default): KEY=value lines, with optional `export` prefix,
+//     single/double quoting and multiline quoted values
+//   - JSON (.json): a flat object of scalar values
+//   - YAML (.yaml, .yml): a flat mapping of scalar values
+package envfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatDotenv Format = "dotenv"
+	FormatJSON   Format = "json"
+	FormatYAML   Format = "yaml"
+)
+
+var keyRegExp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// FormatOf guess the file format from its extension
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatDotenv
+	}
+}
+
+// ReadFile parses the file at path according to its extension
+func ReadFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := Parse(FormatOf(path), content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return env, nil
+}
+
+// Parse decodes content in the given format
+func Parse(format Format, content []byte) (map[string]string, error) {
+	switch format {
+	case FormatJSON:
+		return ParseJSON(content)
+	case FormatYAML:
+		return ParseYAML(content)
+	default:
+		return ParseDotenv(content)
+	}
+}
+
+// ParseJSON decodes a flat JSON object, non string scalars are formatted
+func ParseJSON(content []byte) (map[string]string, error) {
+	raw := map[string]any{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return fromScalars(raw)
+}
+
+// ParseYAML decodes a flat YAML mapping, non string scalars are formatted
+func ParseYAML(content []byte) (map[string]string, error) {
+	raw := map[string]any{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	return fromScalars(raw)
+}
+
+func fromScalars(raw map[string]any) (map[string]string, error) {
+	env := map[string]string{}
+
+	for key, value := range raw {
+		if !keyRegExp.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable name '%s'", key)
+		}
+
+		switch v := value.(type) {
+		case string:
+			env[key] = v
+		case bool:
+			env[key] = strconv.FormatBool(v)
+		case int:
+			env[key] = strconv.Itoa(v)
+		case int64:
+			env[key] = strconv.FormatInt(v, 10)
+		case uint64:
+			env[key] = strconv.FormatUint(v, 10)
+		case float64:
+			env[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case nil:
+			return nil, fmt.Errorf("variable '%s' has a null value", key)
+		default:
+			return nil, fmt.Errorf("variable '%s' must be a scalar, got %T", key, value)
+		}
+	}
+
+	return env, nil
+}
+
+// ParseDotenv decodes a dotenv file
+//
+//	# comment
+//	export KEY=value
+//	UNQUOTED=value # trailing comment
+//	SINGLE='literal $value'
+//	DOUBLE="escaped\nvalue"
+//	MULTILINE="first line
+//	second line"
+func ParseDotenv(content []byte) (map[string]string, error) {
+	env := map[string]string{}
+	src := strings.ReplaceAll(string(content), "\r\n", "\n")
+	line := 1
+
+	for len(src) > 0 {
+		// one logical line at a time, quoted values may consume more
+		var current string
+		if i := strings.IndexByte(src, '\n'); i >= 0 {
+			current, src = src[:i], src[i+1:]
+		} else {
+			current, src = src, ""
+		}
+		startLine := line
+		line++
+
+		trimmed := strings.TrimSpace(current)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(trimmed, "export "); ok {
+			trimmed = strings.TrimSpace(rest)
+		}
+
+		key, value, found := strings.Cut(trimmed, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expect KEY=value", startLine)
+		}
+
+		key = strings.TrimSpace(key)
+		if !keyRegExp.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name '%s'", startLine, key)
+		}
+
+		value = strings.TrimLeft(value, " \t")
+		if value == "" {
+			env[key] = ""
+			continue
+		}
+
+		quote := value[0]
+		if quote != '"' && quote != '\'' {
+			if i := strings.Index(value, " #"); i >= 0 {
+				value = value[:i]
+			}
+			env[key] = strings.TrimSpace(value)
+			continue
+		}
+
+		// quoted value, read until the closing quote, possibly on next lines
+		body := value[1:]
+		for {
+			if end := closingQuote(body, quote); end >= 0 {
+				tail := strings.TrimSpace(body[end+1:])
+				if tail != "" && !strings.HasPrefix(tail, "#") {
+					return nil, fmt.Errorf("line %d: unexpected characters after closing quote", startLine)
+				}
+				body = body[:end]
+				break
+			}
+
+			if src == "" {
+				return nil, fmt.Errorf("line %d: unterminated quoted value", startLine)
+			}
+
+			var next string
+			if i := strings.IndexByte(src, '\n'); i >= 0 {
+				next, src = src[:i], src[i+1:]
+			} else {
+				next, src = src, ""
+			}
+			line++
+			body += "\n" + next
+		}
+
+		if quote == '"' {
+			body = unescape(body)
+		}
+		env[key] = body
+	}
+
+	return env, nil
+}
+
+// closingQuote returns the index of the unescaped closing quote or -1
+func closingQuote(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if quote == '"' && s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\', '$':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+// Source is a named set of variables, the name is used in duplicate reports
+type Source struct {
+	Name   string
+	Values map[string]string
+}
+
+// Duplicate reports a variable defined by more than one source
+type Duplicate struct {
+	Key string
+	// Sources in merge order, the last one wins
+	Sources []string
+}
+
+func (d Duplicate) String() string {
+	return fmt.Sprintf("%s is defined in %s, value from %s is used", d.Key, strings.Join(d.Sources, ", "), d.Sources[len(d.Sources)-1])
+}
+
+// Merge sources in order, a later source overrides an earlier one.
+// Duplicates are sorted by key
+func Merge(sources ...Source) (map[string]string, []Duplicate) {
+	env := map[string]string{}
+	definedIn := map[string][]string{}
+
+	for _, source := range sources {
+		for key, value := range source.Values {
+			env[key] = value
+			definedIn[key] = append(definedIn[key], source.Name)
+		}
+	}
+
+	duplicates := []Duplicate{}
+	for key, names := range definedIn {
+		if len(names) > 1 {
+			duplicates = append(duplicates, Duplicate{Key: key, Sources: names})
+		}
+	}
+	sort.Slice(duplicates, func(i, j int) bool { return duplicates[i].Key < duplicates[j].Key })
+
+	return env, duplicates
+}
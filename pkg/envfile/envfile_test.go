@@ -0,0 +1,159 @@
+package envfile
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        map[string]string
+		expectError bool
+	}{{
+		name:    "simple values and comments",
+		content: "# comment\nFOO=bar\n\nBAZ = qux # trailing\nEMPTY=\n",
+		want:    map[string]string{"FOO": "bar", "BAZ": "qux", "EMPTY": ""},
+	}, {
+		name:    "export prefix",
+		content: "export FOO=bar\nexport   BAR=baz",
+		want:    map[string]string{"FOO": "bar", "BAR": "baz"},
+	}, {
+		name:    "single quotes are literal",
+		content: `FOO='a \n $b # c'`,
+		want:    map[string]string{"FOO": `a \n $b # c`},
+	}, {
+		name:    "double quotes are unescaped",
+		content: `FOO="a\nb \"c\" \\d"`,
+		want:    map[string]string{"FOO": "a\nb \"c\" \\d"},
+	}, {
+		name:    "multiline double quoted value",
+		content: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1",
+		want:    map[string]string{"KEY": "-----BEGIN-----\nabc\n-----END-----", "NEXT": "1"},
+	}, {
+		name:    "multiline single quoted value",
+		content: "KEY='line1\r\nline2' # comment\r\n",
+		want:    map[string]string{"KEY": "line1\nline2"},
+	}, {
+		name:    "value containing equal sign",
+		content: "URL=postgres://u:p@host/db?sslmode=require",
+		want:    map[string]string{"URL": "postgres://u:p@host/db?sslmode=require"},
+	}, {
+		name:        "missing equal sign",
+		content:     "FOO",
+		expectError: true,
+	}, {
+		name:        "invalid key",
+		content:     "1FOO=bar",
+		expectError: true,
+	}, {
+		name:        "unterminated quote",
+		content:     "FOO=\"bar\nBAZ=1",
+		expectError: true,
+	}, {
+		name:        "garbage after closing quote",
+		content:     `FOO="bar" baz`,
+		expectError: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDotenv([]byte(tt.content))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ParseDotenv() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDotenv() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStructured(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      Format
+		content     string
+		want        map[string]string
+		expectError bool
+	}{{
+		name:    "json scalars",
+		format:  FormatJSON,
+		content: `{"FOO": "bar", "PORT": 8080, "RATIO": 0.5, "DEBUG": true}`,
+		want:    map[string]string{"FOO": "bar", "PORT": "8080", "RATIO": "0.5", "DEBUG": "true"},
+	}, {
+		name:        "json nested object",
+		format:      FormatJSON,
+		content:     `{"FOO": {"BAR": "baz"}}`,
+		expectError: true,
+	}, {
+		name:        "json null value",
+		format:      FormatJSON,
+		content:     `{"FOO": null}`,
+		expectError: true,
+	}, {
+		name:    "yaml scalars",
+		format:  FormatYAML,
+		content: "FOO: bar\nPORT: 8080\nDEBUG: false\nMULTI: |\n  a\n  b\n",
+		want:    map[string]string{"FOO": "bar", "PORT": "8080", "DEBUG": "false", "MULTI": "a\nb\n"},
+	}, {
+		name:        "yaml list",
+		format:      FormatYAML,
+		content:     "FOO:\n  - a\n",
+		expectError: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, []byte(tt.content))
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Parse() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for path, want := range map[string]Format{
+		".env":            FormatDotenv,
+		"prod.env":        FormatDotenv,
+		"config/app.json": FormatJSON,
+		"app.YAML":        FormatYAML,
+		"app.yml":         FormatYAML,
+	} {
+		if got := FormatOf(path); got != want {
+			t.Errorf("FormatOf(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	env, duplicates := Merge(
+		Source{Name: "a.env", Values: map[string]string{"A": "1", "B": "1"}},
+		Source{Name: "b.json", Values: map[string]string{"B": "2", "C": "2"}},
+		Source{Name: "environment", Values: map[string]string{"C": "3"}},
+	)
+
+	want := map[string]string{"A": "1", "B": "2", "C": "3"}
+	if !reflect.DeepEqual(env, want) {
+		t.Errorf("Merge() env = %v, want %v", env, want)
+	}
+
+	wantDuplicates := []Duplicate{
+		{Key: "B", Sources: []string{"a.env", "b.json"}},
+		{Key: "C", Sources: []string{"b.json", "environment"}},
+	}
+	if !reflect.DeepEqual(duplicates, wantDuplicates) {
+		t.Errorf("Merge() duplicates = %v, want %v", duplicates, wantDuplicates)
+	}
+}
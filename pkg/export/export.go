@@ -0,0 +1,228 @@
+// Package export dumps database addons into a Cellar bucket
+// with the client tools of each engine (pg_dump, mysqldump, mongodump).
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	minio "github.com/minio/minio-go/v7"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/s3"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// Destination is where the dump is written
+type Destination struct {
+	CellarID string
+	Bucket   string
+	// prepended to the object key
+	Prefix string
+}
+
+// Dump is the command producing the dump of a database on its standard output
+type Dump struct {
+	Tool string
+	Args []string
+	Env  []string
+	// extension of the object key
+	Extension string
+	// file given to the command, removed once the dump is done
+	ConfigFile string
+}
+
+// LookTool returns an error when the dump tool is not in the PATH
+func LookTool(tool string) error {
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("%s is not installed, install the client tools matching the addon version", tool)
+	}
+	return nil
+}
+
+// Export dumps the databaseID addon (real ID) and uploads it into the bucket, returns the object key.
+// The object is only written if the dump succeeds.
+func Export(ctx context.Context, cc *client.Client, organisation, databaseID string, destination Destination, progress func(msg string, args ...any)) (string, error) {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, databaseID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve database ID: %w", err)
+	}
+
+	progress("Fetching database credentials")
+	dump, err := DumpFor(ctx, cc, databaseID, addonID)
+	if err != nil {
+		return "", err
+	}
+	if dump.ConfigFile != "" {
+		defer func() { _ = os.Remove(dump.ConfigFile) }()
+	}
+	if err := LookTool(dump.Tool); err != nil {
+		return "", err
+	}
+
+	cellarEnvRes := tmp.GetAddonEnv(ctx, cc, organisation, destination.CellarID)
+	if cellarEnvRes.HasError() {
+		return "", fmt.Errorf("failed to get Cellar credentials: %w", cellarEnvRes.Error())
+	}
+
+	minioClient, err := s3.MinioClientFromEnvsFor(*cellarEnvRes.Payload())
+	if err != nil {
+		return "", fmt.Errorf("failed to setup S3 client: %w", err)
+	}
+
+	exists, err := minioClient.BucketExists(ctx, destination.Bucket)
+	if err != nil {
+		return "", fmt.Errorf("failed to check bucket %s: %w", destination.Bucket, err)
+	}
+	if !exists {
+		return "", fmt.Errorf("bucket %s does not exist", destination.Bucket)
+	}
+
+	key := ObjectKey(destination.Prefix, databaseID, dump.Extension, time.Now())
+	progress("Exporting %s to %s/%s", databaseID, destination.Bucket, key)
+
+	reader, writer := io.Pipe()
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, dump.Tool, dump.Args...)
+	cmd.Env = append(os.Environ(), dump.Env...)
+	cmd.Stdout = writer
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return "", fmt.Errorf("failed to start %s: %w", dump.Tool, err)
+	}
+
+	// a failing dump aborts the upload
+	go func() {
+		err := cmd.Wait()
+		if err != nil {
+			err = fmt.Errorf("%s failed: %w\n%s", dump.Tool, err, strings.TrimSpace(stderr.String()))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	info, err := minioClient.PutObject(ctx, destination.Bucket, key, &helper.ProgressReader{Reader: reader, Verb: "Exported", Progress: progress}, -1, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	// unblock the command when the upload fails
+	reader.CloseWithError(err)
+	if err != nil {
+		return "", fmt.Errorf("failed to export %s: %w", databaseID, err)
+	}
+
+	progress("Exported %d MiB", info.Size>>20)
+	return key, nil
+}
+
+// DumpFor returns the dump command of the addon, depending on the engine of databaseID
+func DumpFor(ctx context.Context, cc *client.Client, databaseID, addonID string) (*Dump, error) {
+	switch {
+	case strings.HasPrefix(databaseID, "postgresql_"):
+		pgRes := tmp.GetPostgreSQL(ctx, cc, addonID)
+		if pgRes.HasError() {
+			return nil, fmt.Errorf("failed to get database credentials: %w", pgRes.Error())
+		}
+		return PostgreSQLDump(pgRes.Payload()), nil
+
+	case strings.HasPrefix(databaseID, "mysql_"):
+		myRes := tmp.GetMySQL(ctx, cc, addonID)
+		if myRes.HasError() {
+			return nil, fmt.Errorf("failed to get database credentials: %w", myRes.Error())
+		}
+		return MySQLDump(myRes.Payload()), nil
+
+	case strings.HasPrefix(databaseID, "mongodb_"):
+		mgRes := tmp.GetMongoDB(ctx, cc, addonID)
+		if mgRes.HasError() {
+			return nil, fmt.Errorf("failed to get database credentials: %w", mgRes.Error())
+		}
+
+		// mongodump has no password environment variable
+		config, err := os.CreateTemp("", "mongodump-*.yaml")
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = config.Close() }()
+
+		dump := MongoDBDump(mgRes.Payload(), config.Name())
+		if _, err := fmt.Fprintf(config, "password: %s\n", strconv.Quote(mgRes.Payload().Password)); err != nil {
+			_ = os.Remove(config.Name())
+			return nil, err
+		}
+		return dump, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported database %s, expect a PostgreSQL, MySQL or MongoDB addon", databaseID)
+	}
+}
+
+// PostgreSQLDump uses the custom format, restorable with pg_restore
+// and the postgresql_restore action
+func PostgreSQLDump(creds *tmp.PostgreSQL) *Dump {
+	return &Dump{
+		Tool: "pg_dump",
+		Args: []string{
+			"--host", creds.Host,
+			"--port", strconv.Itoa(creds.Port),
+			"--username", creds.User,
+			"--dbname", creds.Database,
+			"--format", "custom",
+			"--no-owner",
+			"--no-privileges",
+			"--no-password",
+		},
+		// keep the password out of the process list
+		Env:       []string{"PGPASSWORD=" + creds.Password},
+		Extension: "dump",
+	}
+}
+
+// MySQLDump dumps in a consistent snapshot without locking the tables
+func MySQLDump(creds *tmp.MySQL) *Dump {
+	return &Dump{
+		Tool: "mysqldump",
+		Args: []string{
+			"--host", creds.Host,
+			"--port", strconv.Itoa(creds.Port),
+			"--user", creds.User,
+			"--single-transaction",
+			"--routines",
+			"--triggers",
+			"--no-tablespaces",
+			creds.Database,
+		},
+		Env:       []string{"MYSQL_PWD=" + creds.Password},
+		Extension: "sql",
+	}
+}
+
+// MongoDBDump writes a gzipped archive, restorable with mongorestore --archive --gzip.
+// The password is read from the config file.
+func MongoDBDump(creds *tmp.MongoDB, configFile string) *Dump {
+	return &Dump{
+		Tool: "mongodump",
+		Args: []string{
+			"--host", creds.Host,
+			"--port", strconv.FormatInt(creds.Port, 10),
+			"--username", creds.User,
+			"--authenticationDatabase", creds.Database,
+			"--db", creds.Database,
+			"--config", configFile,
+			"--archive",
+			"--gzip",
+		},
+		Extension:  "archive.gz",
+		ConfigFile: configFile,
+	}
+}
+
+// ObjectKey returns <prefix><database ID>/<UTC date>.<extension>
+func ObjectKey(prefix, databaseID, extension string, date time.Time) string {
+	return fmt.Sprintf("%s%s/%s.%s", prefix, databaseID, date.UTC().Format("2006-01-02T15-04-05Z"), extension)
+}
@@ -0,0 +1,74 @@
+package export
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+)
+
+func TestObjectKey(t *testing.T) {
+	date := time.Date(2026, 3, 4, 10, 20, 30, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{"without prefix", "", "postgresql_123/2026-03-04T09-20-30Z.dump"},
+		{"with prefix", "final/", "final/postgresql_123/2026-03-04T09-20-30Z.dump"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ObjectKey(tt.prefix, "postgresql_123", "dump", date); got != tt.expected {
+				t.Errorf("expect %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDumps(t *testing.T) {
+	tests := []struct {
+		name     string
+		dump     *Dump
+		tool     string
+		password string
+	}{
+		{
+			name:     "postgresql",
+			dump:     PostgreSQLDump(&tmp.PostgreSQL{Host: "pg.example.com", Port: 5432, User: "owner", Password: "secret", Database: "app"}),
+			tool:     "pg_dump",
+			password: "PGPASSWORD=secret",
+		},
+		{
+			name:     "mysql",
+			dump:     MySQLDump(&tmp.MySQL{Host: "my.example.com", Port: 3306, User: "owner", Password: "secret", Database: "app"}),
+			tool:     "mysqldump",
+			password: "MYSQL_PWD=secret",
+		},
+		{
+			name: "mongodb",
+			dump: MongoDBDump(&tmp.MongoDB{Host: "mg.example.com", Port: 27017, User: "owner", Password: "secret", Database: "app"}, "/tmp/mongodump.yaml"),
+			tool: "mongodump",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.dump.Tool != tt.tool {
+				t.Errorf("expect tool %s, got %s", tt.tool, tt.dump.Tool)
+			}
+			if slices.Contains(tt.dump.Args, "secret") {
+				t.Errorf("password must not be an argument: %v", tt.dump.Args)
+			}
+			if tt.password != "" && !slices.Contains(tt.dump.Env, tt.password) {
+				t.Errorf("expect %s in environment, got %v", tt.password, tt.dump.Env)
+			}
+			if !slices.Contains(tt.dump.Args, "app") {
+				t.Errorf("expect database in arguments, got %v", tt.dump.Args)
+			}
+		})
+	}
+}
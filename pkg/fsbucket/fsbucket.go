@@ -0,0 +1,134 @@
+package fsbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+type FTPCreds struct {
+	Host     string
+	Username string
+	Password string
+}
+
+// File is a regular file of an FSBucket
+type File struct {
+	// Path is relative to the listed directory
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// Extract FTP credentials from Clever Cloud FSBucket exposed env vars
+func FromEnvVars(envVars tmp.EnvVars) *FTPCreds {
+	creds := &FTPCreds{}
+
+	for _, envVar := range envVars {
+		switch envVar.Name {
+		case "BUCKET_HOST":
+			creds.Host = envVar.Value
+		case "BUCKET_FTP_USERNAME":
+			creds.Username = envVar.Value
+		case "BUCKET_FTP_PASSWORD":
+			creds.Password = envVar.Value
+		default:
+		}
+	}
+
+	return creds
+}
+
+// Connect looks up the FSBucket addon credentials and returns a logged in FTP connection on it
+func Connect(ctx context.Context, cc *client.Client, organisation, fsbucketID string) (*ftp.ServerConn, error) {
+	fsbucketEnvRes := tmp.GetAddonEnv(ctx, cc, organisation, fsbucketID)
+	if fsbucketEnvRes.HasError() {
+		return nil, fmt.Errorf("failed to get FSBucket env %s: %w", fsbucketID, fsbucketEnvRes.Error())
+	}
+
+	creds := FromEnvVars(*fsbucketEnvRes.Payload())
+	if creds.Host == "" || creds.Username == "" || creds.Password == "" {
+		return nil, fmt.Errorf("BUCKET_HOST, BUCKET_FTP_USERNAME, or BUCKET_FTP_PASSWORD not found")
+	}
+
+	return creds.Dial(ctx)
+}
+
+func (creds *FTPCreds) Dial(ctx context.Context) (*ftp.ServerConn, error) {
+	conn, err := ftp.Dial(creds.Host+":21", ftp.DialWithContext(ctx), ftp.DialWithTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to FTP server: %w", err)
+	}
+
+	if err := conn.Login(creds.Username, creds.Password); err != nil {
+		_ = conn.Quit()
+		return nil, fmt.Errorf("failed to login to FTP server: %w", err)
+	}
+
+	return conn, nil
+}
+
+// IsNotFound tells if the FTP server answered that the file or directory does not exist
+func IsNotFound(err error) bool {
+	var protoErr *textproto.Error
+	return errors.As(err, &protoErr) && protoErr.Code == ftp.StatusFileUnavailable
+}
+
+// List returns the regular files under dir, sorted by path.
+// Subdirectories are only walked when recursive is set.
+// A missing dir is reported with an error matching IsNotFound
+func List(conn *ftp.ServerConn, dir string, recursive bool) ([]File, error) {
+	root := strings.TrimSuffix(dir, "/") + "/"
+	files := []File{}
+
+	walker := conn.Walk(root)
+	for walker.Next() {
+		entry := walker.Stat()
+		if entry.Type == ftp.EntryTypeFolder && !recursive {
+			walker.SkipDir()
+		}
+		if entry.Type != ftp.EntryTypeFile {
+			continue
+		}
+
+		files = append(files, File{
+			Path:    strings.TrimPrefix(walker.Path(), root),
+			Size:    int64(entry.Size),
+			ModTime: entry.Time,
+		})
+	}
+
+	// the walk stops on the first listing error,
+	// a missing subdirectory must not be taken for a missing dir
+	if err := walker.Err(); err != nil {
+		if walker.Path() != root && IsNotFound(err) {
+			return nil, fmt.Errorf("failed to list %s: %s", walker.Path(), err.Error())
+		}
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// IsDir tells if the remote path is a directory
+func IsDir(conn *ftp.ServerConn, remotePath string) bool {
+	current, err := conn.CurrentDir()
+	if err != nil {
+		current = "/"
+	}
+
+	if err := conn.ChangeDir(remotePath); err != nil {
+		return false
+	}
+	_ = conn.ChangeDir(current)
+	return true
+}
@@ -0,0 +1,23 @@
+package helper
+
+import "io"
+
+// ProgressStep is the number of bytes between two progress reports of a ProgressReader
+const ProgressStep = 64 << 20
+
+// ProgressReader reports the bytes read every ProgressStep, as "<Verb> <N> MiB"
+type ProgressReader struct {
+	io.Reader
+	Verb     string
+	Progress func(msg string, args ...any)
+	read     int64
+}
+
+func (r *ProgressReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if (r.read+int64(n))/ProgressStep > r.read/ProgressStep {
+		r.Progress("%s %d MiB", r.Verb, (r.read+int64(n))>>20)
+	}
+	r.read += int64(n)
+	return n, err
+}
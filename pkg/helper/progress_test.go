@@ -0,0 +1,29 @@
+package helper_test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+func TestProgressReader(t *testing.T) {
+	reports := []string{}
+	reader := &helper.ProgressReader{
+		Reader:   strings.NewReader(strings.Repeat("x", 2*helper.ProgressStep+1)),
+		Verb:     "Downloaded",
+		Progress: func(msg string, args ...any) { reports = append(reports, fmt.Sprintf(msg, args...)) },
+	}
+
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil || n != 2*helper.ProgressStep+1 {
+		t.Fatalf("Copy() = %d, %v", n, err)
+	}
+
+	if want := []string{"Downloaded 64 MiB", "Downloaded 128 MiB"}; !reflect.DeepEqual(reports, want) {
+		t.Errorf("reports = %v, want %v", reports, want)
+	}
+}
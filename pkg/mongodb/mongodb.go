@@ -0,0 +1,116 @@
+// Package mongodb connects to MongoDB addons with their owner credentials
+// to manage objects inside them (users, indexes, collections...).
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.clever-cloud.com/terraform-provider/pkg/retry"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// time given to a starting addon to accept connections
+const ConnectTimeout = 2 * time.Minute
+
+// Addon is an open connection to a MongoDB addon
+type Addon struct {
+	*mongo.Client
+	// credentials of the addon owner
+	Credentials *tmp.MongoDB
+}
+
+// Connect opens a connection to the mongodbID addon (real or addon ID)
+func Connect(ctx context.Context, cc *client.Client, organisation, mongodbID string) (*Addon, error) {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, mongodbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database ID: %w", err)
+	}
+
+	mongoRes := tmp.GetMongoDB(ctx, cc, addonID)
+	if mongoRes.HasError() {
+		return nil, fmt.Errorf("failed to get database credentials: %w", mongoRes.Error())
+	}
+	creds := mongoRes.Payload()
+
+	connectCtx, cancel := context.WithTimeout(ctx, ConnectTimeout)
+	defer cancel()
+
+	mongoClient, err := retry.Connect(connectCtx, "MongoDB", func() (*mongo.Client, error) {
+		mongoClient, err := mongo.Connect(options.Client().ApplyURI(creds.Uri()))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mongoClient.Ping(connectCtx, nil); err != nil {
+			_ = mongoClient.Disconnect(connectCtx)
+			return nil, err
+		}
+
+		return mongoClient, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &Addon{Client: mongoClient, Credentials: creds}, nil
+}
+
+// Database returns the database of the addon
+func (a *Addon) Database() *mongo.Database {
+	return a.Client.Database(a.Credentials.Database)
+}
+
+// Close disconnects from the addon
+func (a *Addon) Close(ctx context.Context) {
+	_ = a.Disconnect(ctx)
+}
+
+// IsCode reports whether err is a MongoDB server error with one of the given codes
+func IsCode(err error, codes ...int) bool {
+	var serverErr mongo.ServerError
+	if !errors.As(err, &serverErr) {
+		return false
+	}
+	return slices.ContainsFunc(codes, serverErr.HasErrorCode)
+}
+
+// ParseDocument parses an extended JSON document, in relaxed or canonical form
+func ParseDocument(extJSON string) (bson.D, error) {
+	document := bson.D{}
+	if err := bson.UnmarshalExtJSON([]byte(extJSON), false, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// SameDocument reports whether the extended JSON document holds the same
+// fields and values, in the same order, as the document read from the server
+func SameDocument(extJSON string, document bson.Raw) bool {
+	parsed, err := ParseDocument(extJSON)
+	if err != nil {
+		return false
+	}
+	raw, err := bson.Marshal(parsed)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(raw, document)
+}
+
+// ExtJSON formats a document read from the server as relaxed extended JSON
+func ExtJSON(document bson.Raw) string {
+	data, err := bson.MarshalExtJSON(document, false, false)
+	if err != nil {
+		return document.String()
+	}
+	return string(data)
+}
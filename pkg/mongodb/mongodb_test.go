@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestSameDocument(t *testing.T) {
+	stored, err := bson.Marshal(bson.D{
+		{Key: "status", Value: "active"},
+		{Key: "age", Value: bson.D{{Key: "$gte", Value: int32(18)}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		extJSON  string
+		expected bool
+	}{
+		{"same document", `{"status": "active", "age": {"$gte": 18}}`, true},
+		{"canonical form", `{"status": "active", "age": {"$gte": {"$numberInt": "18"}}}`, true},
+		{"other value", `{"status": "active", "age": {"$gte": 21}}`, false},
+		{"other order", `{"age": {"$gte": 18}, "status": "active"}`, false},
+		{"invalid JSON", `{"status": `, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameDocument(tt.extJSON, stored); got != tt.expected {
+				t.Errorf("expect %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
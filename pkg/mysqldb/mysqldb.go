@@ -0,0 +1,143 @@
+// Package mysqldb connects to MySQL addons with their owner credentials
+// to manage objects inside them (users, privileges...).
+package mysqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"go.clever-cloud.com/terraform-provider/pkg/retry"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// time given to a starting addon to accept connections
+const ConnectTimeout = 2 * time.Minute
+
+// Addon is an open connection pool to a MySQL addon
+type Addon struct {
+	*sql.DB
+	// credentials of the addon owner
+	Credentials *tmp.MySQL
+}
+
+// Option tunes the driver configuration of a connection
+type Option func(*mysql.Config)
+
+// WithMultiStatements allows several statements in a single query
+func WithMultiStatements(dsn *mysql.Config) {
+	dsn.MultiStatements = true
+}
+
+// Connect opens a connection pool to the mysqlID addon (real or addon ID)
+func Connect(ctx context.Context, cc *client.Client, organisation, mysqlID string, options ...Option) (*Addon, error) {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, mysqlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database ID: %w", err)
+	}
+
+	myRes := tmp.GetMySQL(ctx, cc, addonID)
+	if myRes.HasError() {
+		return nil, fmt.Errorf("failed to get database credentials: %w", myRes.Error())
+	}
+	creds := myRes.Payload()
+
+	connectCtx, cancel := context.WithTimeout(ctx, ConnectTimeout)
+	defer cancel()
+
+	db, err := retry.Connect(connectCtx, "MySQL", func() (*sql.DB, error) {
+		db, err := sql.Open("mysql", DSN(creds, options...))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := db.PingContext(connectCtx); err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+
+		return db, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &Addon{DB: db, Credentials: creds}, nil
+}
+
+// DSN builds the driver connection string of the addon database
+func DSN(creds *tmp.MySQL, options ...Option) string {
+	dsn := mysql.NewConfig()
+	dsn.User = creds.User
+	dsn.Passwd = creds.Password
+	dsn.Net = "tcp"
+	dsn.Addr = net.JoinHostPort(creds.Host, strconv.Itoa(creds.Port))
+	dsn.DBName = creds.Database
+	for _, option := range options {
+		option(dsn)
+	}
+	return dsn.FormatDSN()
+}
+
+// Ident quotes an identifier, parts are joined with dots (database.table)
+func Ident(parts ...string) string {
+	quoted := make([]string, len(parts))
+	for i, part := range parts {
+		quoted[i] = "`" + strings.ReplaceAll(part, "`", "``") + "`"
+	}
+	return strings.Join(quoted, ".")
+}
+
+// Idents quotes and joins identifiers with commas
+func Idents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = Ident(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+var literalReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	"\x00", `\0`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\x1a", `\Z`,
+)
+
+// Literal quotes a string literal, for account management statements
+// which do not accept parameters
+func Literal(value string) string {
+	return "'" + literalReplacer.Replace(value) + "'"
+}
+
+// HostRegex matches the host part of an account: `%`, a hostname or an IP
+// with `%` and `_` wildcards, optionally followed by a netmask or a CIDR prefix
+var HostRegex = regexp.MustCompile(`^[A-Za-z0-9.:%_-]{1,255}(/[0-9.]{1,15})?$`)
+
+// Account formats a user account for account management and GRANT statements
+func Account(user, host string) string {
+	return Literal(user) + "@" + Literal(host)
+}
+
+// Grantee formats a user account as the GRANTEE column of information_schema,
+// to be passed as a query parameter
+func Grantee(user, host string) string {
+	return "'" + strings.ReplaceAll(user, "'", "''") + "'@'" + strings.ReplaceAll(host, "'", "''") + "'"
+}
+
+// IsCode reports whether err is a MySQL error with one of the given error numbers
+func IsCode(err error, codes ...uint16) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && slices.Contains(codes, myErr.Number)
+}
@@ -0,0 +1,65 @@
+package mysqldb
+
+import (
+	"testing"
+
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+)
+
+func TestQuoting(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"identifier", Ident("app"), "`app`"},
+		{"qualified identifier", Ident("app", "orders"), "`app`.`orders`"},
+		{"identifier with backtick", Ident("a`b"), "`a``b`"},
+		{"identifiers", Idents([]string{"id", "name"}), "`id`, `name`"},
+		{"literal", Literal("secret"), `'secret'`},
+		{"literal with quotes", Literal(`it's a \ test`), `'it\'s a \\ test'`},
+		{"account", Account("reader", "%"), `'reader'@'%'`},
+		{"account with quote", Account("o'neil", "10.0.0.%"), `'o\'neil'@'10.0.0.%'`},
+		{"account with backslash", Account(`a\`, "%"), `'a\\'@'%'`},
+		{"grantee", Grantee("reader", "%"), `'reader'@'%'`},
+		{"grantee with quote", Grantee("o'neil", "10.0.0.%"), `'o''neil'@'10.0.0.%'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("expect %s, got %s", tt.expected, tt.got)
+			}
+		})
+	}
+}
+
+func TestHostRegex(t *testing.T) {
+	for host, expected := range map[string]bool{
+		"%":                         true,
+		"localhost":                 true,
+		"10.0.%":                    true,
+		"192.168.1.0/255.255.255.0": true,
+		"10.0.0.0/8":                true,
+		"fe80::1":                   true,
+		"":                          false,
+		`a\`:                        false,
+		"a'b":                       false,
+		"a b":                       false,
+	} {
+		if got := HostRegex.MatchString(host); got != expected {
+			t.Errorf("expect %v for %q, got %v", expected, host, got)
+		}
+	}
+}
+
+func TestDSN(t *testing.T) {
+	creds := &tmp.MySQL{Host: "mysql.example.com", Port: 3306, User: "owner", Password: "secret", Database: "app"}
+
+	if got, want := DSN(creds), "owner:secret@tcp(mysql.example.com:3306)/app"; got != want {
+		t.Errorf("DSN() = %s, want %s", got, want)
+	}
+	if got, want := DSN(creds, WithMultiStatements), "owner:secret@tcp(mysql.example.com:3306)/app?multiStatements=true"; got != want {
+		t.Errorf("DSN(WithMultiStatements) = %s, want %s", got, want)
+	}
+}
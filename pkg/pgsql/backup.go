@@ -0,0 +1,108 @@
+package pgsql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// FindBackup returns the backup of the addon matching the selector, see SelectBackup
+func FindBackup(ctx context.Context, cc *client.Client, organisation, postgresqlID, selector string, diags *diag.Diagnostics) *tmp.PostgreSQLBackup {
+	backupsRes := tmp.GetPostgreSQLBackups(ctx, cc, organisation, postgresqlID)
+	if backupsRes.HasError() {
+		diags.AddError(
+			"Failed to get PostgreSQL backups",
+			backupsRes.Error().Error(),
+		)
+		return nil
+	}
+	backups := *backupsRes.Payload()
+
+	if len(backups) == 0 {
+		diags.AddError(
+			"No backups found",
+			fmt.Sprintf("No backups exist for PostgreSQL addon %s. Backups are created automatically 24 hours after addon creation.", postgresqlID),
+		)
+		return nil
+	}
+
+	tflog.Debug(ctx, "Retrieved backups", map[string]any{"count": len(backups)})
+
+	return SelectBackup(backups, selector, diags)
+}
+
+// SelectBackup returns the backup matching the selector: 'latest', a backup UUID
+// or an RFC3339 date to get the most recent backup before it
+func SelectBackup(backups []tmp.PostgreSQLBackup, selector string, diags *diag.Diagnostics) *tmp.PostgreSQLBackup {
+	switch {
+	case selector == "latest":
+		return findLatestBackup(backups, diags)
+	case isUUID(selector):
+		return findBackupByUUID(backups, selector, diags)
+	default:
+		return findBackupByDate(backups, selector, diags)
+	}
+}
+
+func isUUID(s string) bool {
+	return uuid.Validate(s) == nil
+}
+
+func findBackupByUUID(backups []tmp.PostgreSQLBackup, backupUUID string, diags *diag.Diagnostics) *tmp.PostgreSQLBackup {
+	for i := range backups {
+		if backups[i].BackupID == backupUUID {
+			return &backups[i]
+		}
+	}
+
+	diags.AddError("Backup not found", fmt.Sprintf("No backup found with UUID %s ", backupUUID))
+	return nil
+}
+
+func findLatestBackup(backups []tmp.PostgreSQLBackup, diags *diag.Diagnostics) *tmp.PostgreSQLBackup {
+	if len(backups) == 0 {
+		diags.AddError("No backup found", "there is no backup available")
+		return nil
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreationDate.After(backups[j].CreationDate)
+	})
+
+	return &backups[0]
+}
+
+func findBackupByDate(backups []tmp.PostgreSQLBackup, dateStr string, diags *diag.Diagnostics) *tmp.PostgreSQLBackup {
+	targetDate, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		diags.AddError(
+			"Invalid date format",
+			fmt.Sprintf("Expected ISO8601/RFC3339 format (e.g., '2025-12-23T10:00:00Z'), got: %s. Error: %s", dateStr, err.Error()),
+		)
+		return nil
+	}
+
+	validBackups := pkg.Filter(backups, func(backup tmp.PostgreSQLBackup) bool {
+		creationTime := backup.CreationDate
+		return creationTime.Before(targetDate) || creationTime.Equal(targetDate)
+	})
+
+	if len(validBackups) == 0 {
+		diags.AddError("No backup found", "No backup before the given date")
+		return nil
+	}
+
+	sort.Slice(validBackups, func(i, j int) bool {
+		return validBackups[i].CreationDate.After(validBackups[j].CreationDate)
+	})
+
+	return &validBackups[0]
+}
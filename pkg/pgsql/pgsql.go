@@ -0,0 +1,98 @@
+// Package pgsql connects to PostgreSQL addons with their owner credentials
+// to manage objects inside them (roles, schemas, privileges...).
+package pgsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"go.clever-cloud.com/terraform-provider/pkg/retry"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// time given to a starting addon to accept connections
+const ConnectTimeout = 2 * time.Minute
+
+// Addon is an open connection to a PostgreSQL addon
+type Addon struct {
+	*pgx.Conn
+	// credentials of the addon owner
+	Credentials *tmp.PostgreSQL
+}
+
+// Connect opens a connection to the postgresqlID addon (real or addon ID).
+// database overrides the addon database when not empty.
+func Connect(ctx context.Context, cc *client.Client, organisation, postgresqlID, database string) (*Addon, error) {
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, postgresqlID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database ID: %w", err)
+	}
+
+	pgRes := tmp.GetPostgreSQL(ctx, cc, addonID)
+	if pgRes.HasError() {
+		return nil, fmt.Errorf("failed to get database credentials: %w", pgRes.Error())
+	}
+	creds := pgRes.Payload()
+
+	if database == "" {
+		database = creds.Database
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, ConnectTimeout)
+	defer cancel()
+
+	conn, err := retry.Connect(connectCtx, "PG", func() (*pgx.Conn, error) {
+		return pgx.Connect(connectCtx, DSN(creds, database))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &Addon{Conn: conn, Credentials: creds}, nil
+}
+
+// DSN builds the connection URL of a database of the addon
+func DSN(creds *tmp.PostgreSQL, database string) string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(creds.User, creds.Password),
+		Host:   net.JoinHostPort(creds.Host, strconv.Itoa(creds.Port)),
+		Path:   "/" + database,
+	}
+	return u.String()
+}
+
+// Ident quotes an identifier, parts are joined with dots (schema.table)
+func Ident(parts ...string) string {
+	return pgx.Identifier(parts).Sanitize()
+}
+
+// Idents quotes and joins identifiers with commas
+func Idents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = Ident(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Literal quotes a string literal, for statements which do not accept parameters (DDL)
+func Literal(value string) string {
+	return pq.QuoteLiteral(value)
+}
+
+// IsCode reports whether err is a PostgreSQL error with the given SQLSTATE
+func IsCode(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
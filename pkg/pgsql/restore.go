@@ -0,0 +1,121 @@
+package pgsql
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+)
+
+var ErrPgRestoreMissing = errors.New("pg_restore is not installed, install the PostgreSQL client tools matching the addon version")
+
+// LookPgRestore returns ErrPgRestoreMissing when pg_restore is not in the PATH
+func LookPgRestore() error {
+	if _, err := exec.LookPath("pg_restore"); err != nil {
+		return ErrPgRestoreMissing
+	}
+	return nil
+}
+
+// RestoreBackup downloads the backup and restores it into the database of the target addon.
+// With clean, objects of the backup are dropped before being recreated.
+func RestoreBackup(ctx context.Context, backup *tmp.PostgreSQLBackup, target *tmp.PostgreSQL, clean bool, progress func(msg string, args ...any)) error {
+	if err := LookPgRestore(); err != nil {
+		return err
+	}
+
+	progress("Downloading backup %s (%s)", backup.BackupID, backup.CreationDate.Format("2006-01-02 15:04"))
+	file, err := DownloadBackup(ctx, backup, progress)
+	if err != nil {
+		return fmt.Errorf("failed to download backup: %w", err)
+	}
+	defer func() { _ = os.Remove(file) }()
+
+	progress("Restoring backup into %s", target.Database)
+	return Restore(ctx, target, file, clean)
+}
+
+// DownloadBackup writes the backup to a temporary file and returns its path,
+// the caller removes it
+func DownloadBackup(ctx context.Context, backup *tmp.PostgreSQLBackup, progress func(msg string, args ...any)) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, backup.DownloadURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	file, err := os.CreateTemp("", "postgresql-backup-*.dump")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = file.Close() }()
+
+	written, err := io.Copy(file, &helper.ProgressReader{Reader: res.Body, Verb: "Downloaded", Progress: progress})
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+
+	progress("Downloaded %d MiB", written>>20)
+	return file.Name(), nil
+}
+
+// Restore runs pg_restore on the dump file against the database of the addon
+func Restore(ctx context.Context, creds *tmp.PostgreSQL, file string, clean bool) error {
+	cmd := exec.CommandContext(ctx, "pg_restore", RestoreArgs(creds, file, clean)...)
+	// keep the password out of the process list
+	cmd.Env = append(os.Environ(), "PGPASSWORD="+creds.Password)
+
+	output := &bytes.Buffer{}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_restore failed: %w\n%s", err, lastLines(output.String(), 20))
+	}
+	return nil
+}
+
+// RestoreArgs returns the pg_restore arguments, owners and privileges of the
+// backup are skipped as the addon user owns the restored objects
+func RestoreArgs(creds *tmp.PostgreSQL, file string, clean bool) []string {
+	args := []string{
+		"--host", creds.Host,
+		"--port", strconv.Itoa(creds.Port),
+		"--username", creds.User,
+		"--dbname", creds.Database,
+		"--no-owner",
+		"--no-privileges",
+		"--no-password",
+	}
+	if clean {
+		args = append(args, "--clean", "--if-exists")
+	}
+	return append(args, file)
+}
+
+func lastLines(output string, count int) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) > count {
+		lines = lines[len(lines)-count:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,40 @@
+package pgsql
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+)
+
+func TestRestoreArgs(t *testing.T) {
+	creds := &tmp.PostgreSQL{Host: "db.example.com", Port: 5432, User: "owner", Password: "secret", Database: "app"}
+
+	tests := []struct {
+		name     string
+		clean    bool
+		expected []string
+	}{
+		{"without clean", false, []string{
+			"--host", "db.example.com", "--port", "5432", "--username", "owner", "--dbname", "app",
+			"--no-owner", "--no-privileges", "--no-password", "backup.dump",
+		}},
+		{"with clean", true, []string{
+			"--host", "db.example.com", "--port", "5432", "--username", "owner", "--dbname", "app",
+			"--no-owner", "--no-privileges", "--no-password", "--clean", "--if-exists", "backup.dump",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RestoreArgs(creds, "backup.dump", tt.clean)
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("expect %v, got %v", tt.expected, got)
+			}
+			if slices.ContainsFunc(got, func(arg string) bool { return strings.Contains(arg, creds.Password) }) {
+				t.Errorf("password must not be passed as argument")
+			}
+		})
+	}
+}
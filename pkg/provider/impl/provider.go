@@ -13,6 +13,7 @@ type Provider struct {
 	gitAuth                *http.BasicAuth
 	organization           string
 	isNetwrkgroupsDisabled bool
+	sopsAgeKey             string
 }
 
 func New(version string) func() provider.Provider {
@@ -35,3 +36,7 @@ func (p *Provider) GitAuth() *http.BasicAuth {
 func (p *Provider) IsNetwrkgroupsDisabled() bool {
 	return p.isNetwrkgroupsDisabled
 }
+
+func (p *Provider) SopsAgeKey() string {
+	return p.sopsAgeKey
+}
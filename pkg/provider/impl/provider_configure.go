@@ -80,6 +80,7 @@ func (p *Provider) Configure(ctx context.Context, req provider.ConfigureRequest,
 	}
 
 	p.isNetwrkgroupsDisabled = config.DisableNetworkgroup.ValueBool()
+	p.sopsAgeKey = config.SopsAgeKey.ValueString()
 
 	p.organization = os.Getenv("CC_ORGANISATION")
 	if !config.Organisation.IsUnknown() && !config.Organisation.IsNull() {
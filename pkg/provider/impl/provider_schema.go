@@ -21,6 +21,7 @@ type ProviderData struct {
 	ConsumerKey         types.String `tfsdk:"consumer_key"`
 	ConsumerSecret      types.String `tfsdk:"consumer_secret"`
 	DisableNetworkgroup types.Bool   `tfsdk:"disable_networkgroups"`
+	SopsAgeKey          types.String `tfsdk:"sops_age_key"`
 }
 
 //go:embed provider.md
@@ -66,6 +67,11 @@ func (p *Provider) Schema(_ context.Context, req provider.SchemaRequest, res *pr
 				Optional:            true,
 				MarkdownDescription: "Disable netorkgroups features",
 			},
+			"sops_age_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "age identity (`AGE-SECRET-KEY-1...`) used to decrypt `environment_sops_file`. Defaults to the SOPS_AGE_KEY and SOPS_AGE_KEY_FILE environment variables, then to the sops default keys file.",
+			},
 		},
 	}
 }
@@ -10,4 +10,5 @@ type Provider interface {
 	Client() *client.Client
 	GitAuth() *http.BasicAuth
 	IsNetwrkgroupsDisabled() bool
+	SopsAgeKey() string
 }
@@ -0,0 +1,98 @@
+// Package pulsar connects to the namespace of Pulsar addons with their token
+// to manage objects inside it (topics, subscriptions, schemas...).
+package pulsar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/rest"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// Addon is an admin client restricted to the namespace of a Pulsar addon
+type Addon struct {
+	pulsaradmin.Client
+	Tenant    string
+	Namespace string
+}
+
+// Connect creates an admin client for the pulsarID addon (real ID), authenticated with the addon token
+func Connect(ctx context.Context, cc *client.Client, organisation, pulsarID string) (*Addon, error) {
+	pulsarRes := tmp.GetPulsar(ctx, cc, organisation, pulsarID)
+	if pulsarRes.HasError() {
+		return nil, fmt.Errorf("failed to get Pulsar: %w", pulsarRes.Error())
+	}
+	pulsar := pulsarRes.Payload()
+
+	clusterRes := tmp.GetPulsarCluster(ctx, cc, pulsar.ClusterID)
+	if clusterRes.HasError() {
+		return nil, fmt.Errorf("failed to get Pulsar cluster: %w", clusterRes.Error())
+	}
+
+	admin, err := pulsaradmin.NewClient(&pulsaradmin.Config{
+		WebServiceURL: WebServiceURL(clusterRes.Payload()),
+		Token:         pulsar.Token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Pulsar admin client: %w", err)
+	}
+
+	return &Addon{Client: admin, Tenant: pulsar.Tenant, Namespace: pulsar.Namespace}, nil
+}
+
+// WebServiceURL returns the REST API address of the cluster
+func WebServiceURL(cluster *tmp.PulsarCluster) string {
+	if cluster.WebTLSPort != 0 {
+		return fmt.Sprintf("https://%s:%d", cluster.URL, cluster.WebTLSPort)
+	}
+	return fmt.Sprintf("http://%s:%d", cluster.URL, cluster.WebPort)
+}
+
+// NamespaceName returns the namespace of the addon
+func (a *Addon) NamespaceName() (*utils.NameSpaceName, error) {
+	return utils.GetNameSpaceName(a.Tenant, a.Namespace)
+}
+
+// Topic resolves a topic of the addon namespace.
+// name is either a full topic name (persistent://tenant/namespace/topic)
+// or a local name, which is a persistent topic.
+func (a *Addon) Topic(name string) (*utils.TopicName, error) {
+	if !strings.Contains(name, "://") {
+		name = fmt.Sprintf("persistent://%s/%s/%s", a.Tenant, a.Namespace, name)
+	}
+
+	topic, err := utils.GetTopicName(name)
+	if err != nil {
+		return nil, err
+	}
+	if topic.GetTenant() != a.Tenant || topic.GetNamespace() != a.Namespace {
+		return nil, fmt.Errorf("topic %s is not in the addon namespace %s/%s", name, a.Tenant, a.Namespace)
+	}
+
+	return topic, nil
+}
+
+// IsCode reports whether err is an admin API error with one of the given HTTP status codes
+func IsCode(err error, codes ...int) bool {
+	var restErr rest.Error
+	return errors.As(err, &restErr) && slices.Contains(codes, restErr.Code)
+}
+
+// SameDefinition reports whether two JSON schema definitions hold the same values,
+// whatever their formatting and fields order
+func SameDefinition(a, b string) bool {
+	var valueA, valueB any
+	if json.Unmarshal([]byte(a), &valueA) != nil || json.Unmarshal([]byte(b), &valueB) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
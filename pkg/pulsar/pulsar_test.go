@@ -0,0 +1,88 @@
+package pulsar
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/rest"
+)
+
+func TestTopic(t *testing.T) {
+	addon := &Addon{Tenant: "orga_xxx", Namespace: "pulsar_xxx"}
+
+	tests := []struct {
+		name     string
+		topic    string
+		expected string
+		fails    bool
+	}{
+		{"local name", "events", "persistent://orga_xxx/pulsar_xxx/events", false},
+		{"full name", "persistent://orga_xxx/pulsar_xxx/events", "persistent://orga_xxx/pulsar_xxx/events", false},
+		{"non persistent", "non-persistent://orga_xxx/pulsar_xxx/events", "non-persistent://orga_xxx/pulsar_xxx/events", false},
+		{"other namespace", "persistent://orga_xxx/other/events", "", true},
+		{"other tenant", "persistent://public/default/events", "", true},
+		{"invalid domain", "kafka://orga_xxx/pulsar_xxx/events", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topic, err := addon.Topic(tt.topic)
+			if tt.fails {
+				if err == nil {
+					t.Errorf("expect an error, got %s", topic)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if topic.String() != tt.expected {
+				t.Errorf("expect %s, got %s", tt.expected, topic)
+			}
+		})
+	}
+}
+
+func TestIsCode(t *testing.T) {
+	notFound := rest.Error{Code: 404, Reason: "Topic not found"}
+
+	if !IsCode(notFound, 404) {
+		t.Errorf("expect 404 to match")
+	}
+	if !IsCode(fmt.Errorf("failed to read topic: %w", notFound), 409, 404) {
+		t.Errorf("expect wrapped 404 to match")
+	}
+	if IsCode(notFound, 409) {
+		t.Errorf("expect 404 not to match 409")
+	}
+	if IsCode(fmt.Errorf("connection refused"), 404) {
+		t.Errorf("expect a network error not to match")
+	}
+}
+
+func TestSameDefinition(t *testing.T) {
+	stored := `{"type":"record","name":"User","fields":[{"name":"email","type":"string"}]}`
+
+	tests := []struct {
+		name       string
+		definition string
+		expected   bool
+	}{
+		{"same definition", stored, true},
+		{"other formatting", `{
+  "name": "User",
+  "type": "record",
+  "fields": [{ "name": "email", "type": "string" }]
+}`, true},
+		{"other field", `{"type":"record","name":"User","fields":[{"name":"phone","type":"string"}]}`, false},
+		{"invalid JSON", `{"type":`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameDefinition(tt.definition, stored); got != tt.expected {
+				t.Errorf("expect %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"go.clever-cloud.com/terraform-provider/pkg/actions"
+	"go.clever-cloud.com/terraform-provider/pkg/datasources/cellarobject"
 	"go.clever-cloud.com/terraform-provider/pkg/datasources/defaultloadbalancer"
+	"go.clever-cloud.com/terraform-provider/pkg/datasources/fsbucketfiles"
 	"go.clever-cloud.com/terraform-provider/pkg/datasources/postgresqlbackup"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/addon"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/addonprovider"
@@ -29,13 +31,30 @@ import (
 	"go.clever-cloud.com/terraform-provider/pkg/resources/configprovider"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/cellar"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/cellar/bucket"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/cellar/object"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/elasticsearch"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/elasticsearch/ilmpolicy"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/elasticsearch/indextemplate"
+	elasticsearchrole "go.clever-cloud.com/terraform-provider/pkg/resources/database/elasticsearch/role"
+	elasticsearchuser "go.clever-cloud.com/terraform-provider/pkg/resources/database/elasticsearch/user"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/fsbucket"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/materiakv"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/mongodb"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/mongodb/collection"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/mongodb/index"
+	mongodbuser "go.clever-cloud.com/terraform-provider/pkg/resources/database/mongodb/user"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/mysql"
+	mysqlgrant "go.clever-cloud.com/terraform-provider/pkg/resources/database/mysql/grant"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/mysql/user"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql/database"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql/extension"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql/grant"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql/role"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/pulsar"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/pulsar/subscription"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/pulsar/topic"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/pulsar/topicschema"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/database/redis"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/drain"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/kubernetes"
@@ -50,7 +69,9 @@ import (
 )
 
 var Datasources = []func() datasource.DataSource{
+	cellarobject.NewDataSourceCellarObject,
 	defaultloadbalancer.NewDataSourceDefaultLoadBalancer,
+	fsbucketfiles.NewDataSourceFSBucketFiles,
 	postgresqlbackup.NewDataSourcePostgreSQLBackup,
 }
 
@@ -59,6 +80,7 @@ var Resources = []func() resource.Resource{
 	addonprovider.NewResourceAddonProvider,
 	bucket.NewResourceCellarBucket,
 	cellar.NewResourceCellar,
+	object.NewResourceCellarObject,
 	fsbucket.NewResourceFSBucket,
 	java.NewResourceJava("war"),
 	java.NewResourceJava("jar"),
@@ -66,12 +88,25 @@ var Resources = []func() resource.Resource{
 	materiakv.NewResourceMateriaKV,
 	metabase.NewResourceMetabase,
 	mongodb.NewResourceMongoDB,
+	mongodbuser.NewResourceMongoDBUser,
+	index.NewResourceMongoDBIndex,
+	collection.NewResourceMongoDBCollection,
 	mysql.NewResourceMySQL,
+	user.NewResourceMySQLUser,
+	mysqlgrant.NewResourceMySQLGrant,
 	nodejs.NewResourceNodeJS,
 	otoroshi.NewResourceOtoroshi,
 	php.NewResourcePHP,
 	postgresql.NewResourcePostgreSQL,
+	role.NewResourcePostgreSQLRole,
+	database.NewResourcePostgreSQLDatabase,
+	grant.NewResourcePostgreSQLGrant,
+	extension.NewResourcePostgreSQLExtension,
 	elasticsearch.NewResourceElasticsearch,
+	indextemplate.NewResourceIndexTemplate,
+	ilmpolicy.NewResourceILMPolicy,
+	elasticsearchrole.NewResourceElasticsearchRole,
+	elasticsearchuser.NewResourceElasticsearchUser,
 	python.NewResourcePython,
 	ruby.NewResourceRuby,
 	scala.NewResourceScala(),
@@ -94,6 +129,9 @@ var Resources = []func() resource.Resource{
 	frankenphp.NewResourceFrankenPHP,
 	play2.NewResourcePlay2(),
 	pulsar.NewResourcePulsar,
+	topic.NewResourcePulsarTopic,
+	subscription.NewResourcePulsarSubscription,
+	topicschema.NewResourcePulsarSchema,
 	rust.NewResourceRust,
 	networkgroup.NewResourceNetworkgroup,
 	v.NewResourceV,
@@ -106,5 +144,11 @@ var Resources = []func() resource.Resource{
 var Actions = []func() action.Action{
 	actions.RebootApplication,
 	actions.ExecuteDatabaseSQL,
+	actions.DatabaseMigrate,
 	actions.FSBucketUpload,
+	actions.FSBucketDownload,
+	actions.PostgreSQLRestore,
+	actions.DatabaseCopy,
+	actions.DatabaseExport,
+	actions.CellarSync,
 }
@@ -1,6 +1,11 @@
 package addon
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -8,7 +13,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/export"
+	"go.clever-cloud.dev/client"
 )
 
 type CommonAttributes struct {
@@ -56,3 +64,57 @@ var addonCommon = map[string]schema.Attribute{
 func WithAddonCommons(runtimeSpecifics map[string]schema.Attribute) map[string]schema.Attribute {
 	return pkg.Merge(addonCommon, runtimeSpecifics)
 }
+
+// FinalSnapshot is the Cellar bucket receiving a dump of the database before it is deleted
+type FinalSnapshot struct {
+	CellarID types.String `tfsdk:"cellar_id"`
+	Bucket   types.String `tfsdk:"bucket"`
+	Prefix   types.String `tfsdk:"prefix"`
+}
+
+var FinalSnapshotAttribute = schema.SingleNestedAttribute{
+	Optional: true,
+	MarkdownDescription: "Export a dump of the database into a Cellar bucket before deleting the addon, the deletion is aborted if the export fails. " +
+		"The client tools of the engine (`pg_dump`, `mysqldump` or `mongodump`) must be installed where Terraform runs, the plan fails without them.",
+	Attributes: map[string]schema.Attribute{
+		"cellar_id": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Cellar addon ID of the bucket",
+		},
+		"bucket": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the bucket receiving the dump",
+		},
+		"prefix": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Prefix of the object key, the dump is written to `<prefix><addon ID>/<date>.<extension>`",
+		},
+	},
+}
+
+// ValidateTool reports a missing dump tool at plan time, the export only runs
+// on deletion when it is too late to install it
+func (s *FinalSnapshot) ValidateTool(tool string, diags *diag.Diagnostics) {
+	if err := export.LookTool(tool); err != nil {
+		diags.AddAttributeError(path.Root("final_snapshot"), "cannot export final snapshot", err.Error())
+	}
+}
+
+// Export writes the dump of the databaseID addon into the bucket
+func (s *FinalSnapshot) Export(ctx context.Context, cc *client.Client, organisation, databaseID string, diags *diag.Diagnostics) {
+	destination := export.Destination{
+		CellarID: s.CellarID.ValueString(),
+		Bucket:   s.Bucket.ValueString(),
+		Prefix:   s.Prefix.ValueString(),
+	}
+
+	key, err := export.Export(ctx, cc, organisation, databaseID, destination, func(msg string, args ...any) {
+		tflog.Info(ctx, fmt.Sprintf(msg, args...))
+	})
+	if err != nil {
+		diags.AddError("failed to export final snapshot, the addon is not deleted", err.Error())
+		return
+	}
+
+	tflog.Info(ctx, "final snapshot exported", map[string]any{"bucket": destination.Bucket, "key": key})
+}
@@ -0,0 +1,141 @@
+package addon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
+)
+
+// MigrationRequest changes the plan, region or version of an addon without data loss
+type MigrationRequest struct {
+	// addon provider, i.e. postgresql-addon
+	ProviderID string
+	// plan slug
+	Plan   string
+	Region string
+	// nil keeps the current version
+	Version *string
+}
+
+// Migrate runs a migration of the addon (real or addon ID) and waits for it to complete.
+// It returns the target plan, or nil when the migration could not be done.
+// A migration provisions new instances: connection details must be refreshed afterwards.
+func Migrate(ctx context.Context, cc *client.Client, organisation, id string, req MigrationRequest, diags *diag.Diagnostics) *tmp.AddonPlan {
+	addonsProvidersRes := tmp.GetAddonsProviders(ctx, cc)
+	if addonsProvidersRes.HasError() {
+		diags.AddError("failed to get addon providers", addonsProvidersRes.Error().Error())
+		return nil
+	}
+	addonsProviders := addonsProvidersRes.Payload()
+
+	prov := pkg.LookupAddonProvider(*addonsProviders, req.ProviderID)
+	billingPlan := pkg.LookupProviderPlan(prov, req.Plan)
+	if billingPlan == nil {
+		diags.AddError("failed to find plan", "expect: "+strings.Join(pkg.ProviderPlansAsList(prov), ", ")+", got: "+req.Plan)
+		return nil
+	}
+
+	addonID, err := tmp.RealIDToAddonID(ctx, cc, organisation, id)
+	if err != nil {
+		diags.AddError("failed to get addon ID", err.Error())
+		return nil
+	}
+
+	// Check for already running migrations
+	migrationsRes := tmp.ListAddonMigrations(ctx, cc, organisation, addonID)
+	if migrationsRes.HasError() {
+		diags.AddError("failed to list migrations", migrationsRes.Error().Error())
+		return nil
+	}
+	migrations := migrationsRes.Payload()
+
+	runningMig := pkg.First(*migrations, func(mig tmp.AddonMigrationResponse) bool {
+		return mig.Status == "RUNNING"
+	})
+	if runningMig != nil {
+		diags.AddError(
+			"migration already in progress",
+			fmt.Sprintf("A migration (ID: %s) is already running for this addon. Please wait for it to complete before requesting a new migration.", runningMig.MigrationID),
+		)
+		return nil
+	}
+
+	// TODO:
+	// migration cannot be run if instance is not ready
+	// hard to know when instance is OK, because instance API return UP even if the database is not listening
+
+	migrationReq := tmp.AddonMigrationRequest{Region: req.Region, PlanID: billingPlan.ID, Version: req.Version}
+	tflog.Debug(ctx, "migration request", map[string]any{
+		"version": migrationReq.Version,
+		"region":  migrationReq.Region,
+		"plan":    migrationReq.PlanID,
+	})
+
+	migrationRes := tmp.MigrateAddon(ctx, cc, organisation, addonID, migrationReq)
+	if migrationRes.HasError() {
+		diags.AddError("failed to migrate addon", migrationRes.Error().Error())
+		return nil
+	}
+	migration := migrationRes.Payload()
+
+	tflog.Info(ctx, "Addon migration started", map[string]any{
+		"addon":        id,
+		"migration_id": migration.MigrationID,
+		"status":       migration.Status,
+		"request_date": migration.RequestDate,
+	})
+
+	t := time.NewTicker(1 * time.Second)
+	defer t.Stop()
+
+	// Wait for migration to complete
+	migrationID := migration.MigrationID
+	for {
+		// Check if context is done (timeout or cancellation)
+		select {
+		case <-ctx.Done():
+			diags.AddError("migration timeout", "Migration did not complete within the allowed time, check DB logs")
+			return nil
+		case <-t.C:
+			migrationsRes := tmp.GetAddonMigrations(ctx, cc, organisation, addonID, migrationID)
+			if migrationsRes.HasError() {
+				diags.AddWarning("failed to check migration status", migrationsRes.Error().Error())
+				continue
+			}
+			currentMigration := migrationsRes.Payload()
+
+			tflog.Info(ctx, "Migration status check", map[string]any{
+				"addon":        id,
+				"migration_id": currentMigration.MigrationID,
+				"status":       currentMigration.Status,
+			})
+			for _, step := range currentMigration.Steps {
+				tflog.Debug(ctx, step.Name, map[string]any{
+					"message": step.Message,
+					"value":   step.Value,
+					"status":  step.Status,
+				})
+			}
+
+			switch currentMigration.Status {
+			case "OK":
+				return billingPlan
+			case "RUNNING":
+				continue
+			default:
+				diags.AddError(
+					"migration failed",
+					fmt.Sprintf("Migration ended with status: %s", currentMigration.Status),
+				)
+				return nil
+			}
+		}
+	}
+}
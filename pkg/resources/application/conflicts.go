@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/attributes"
+)
+
+// variables written by the attributes common to every runtime
+var commonEnvAttributes = map[string]path.Path{
+	"APP_FOLDER":            path.Root("app_folder"),
+	"CC_PRE_BUILD_HOOK":     path.Root("hooks").AtName("pre_build"),
+	"CC_POST_BUILD_HOOK":    path.Root("hooks").AtName("post_build"),
+	"CC_PRE_RUN_HOOK":       path.Root("hooks").AtName("pre_run"),
+	"CC_RUN_FAILED_HOOK":    path.Root("hooks").AtName("run_failed"),
+	"CC_RUN_SUCCEEDED_HOOK": path.Root("hooks").AtName("run_succeed"),
+
+	attributes.CC_REDIRECTIONIO_PROJECT_KEY:           path.Root("integrations").AtName("redirectionio").AtName("project_key"),
+	attributes.CC_REDIRECTIONIO_INSTANCE_NAME:         path.Root("integrations").AtName("redirectionio").AtName("instance_name"),
+	attributes.CC_REDIRECTIONIO_BACKEND_PORT:          path.Root("integrations").AtName("redirectionio").AtName("backend_port"),
+	attributes.NEW_RELIC_LICENSE_KEY:                  path.Root("integrations").AtName("newrelic").AtName("license_key"),
+	attributes.NEW_RELIC_APP_NAME:                     path.Root("integrations").AtName("newrelic").AtName("app_name"),
+	attributes.CC_CLAMAV:                              path.Root("integrations").AtName("clamav").AtName("enabled"),
+	attributes.CC_VARNISH_FILE:                        path.Root("integrations").AtName("varnish").AtName("config_file"),
+	attributes.CC_VARNISH_STORAGE_SIZE:                path.Root("integrations").AtName("varnish").AtName("storage_size"),
+	attributes.CC_METRICS_PROMETHEUS_USER:             path.Root("integrations").AtName("prometheus").AtName("user"),
+	attributes.CC_METRICS_PROMETHEUS_PASSWORD:         path.Root("integrations").AtName("prometheus").AtName("password"),
+	attributes.CC_METRICS_PROMETHEUS_PATH:             path.Root("integrations").AtName("prometheus").AtName("path"),
+	attributes.CC_METRICS_PROMETHEUS_PORT:             path.Root("integrations").AtName("prometheus").AtName("port"),
+	attributes.CC_METRICS_PROMETHEUS_RESPONSE_TIMEOUT: path.Root("integrations").AtName("prometheus").AtName("response_timeout"),
+	attributes.CC_ENABLE_PGPOOL:                       path.Root("integrations").AtName("pgpoolii").AtName("enabled"),
+}
+
+// ValidateEnvironmentConflicts warns about variables set both by a typed attribute
+// (runtime field, hook, integration...) and by `environment` or an environment file.
+// The typed attribute wins the merge, the custom value is ignored.
+//
+// The owner of a variable comes from the runtime variables mapping (EnvAttributes)
+// and from the attributes common to every runtime.
+func ValidateEnvironmentConflicts[T any, P interface {
+	*T
+	RuntimePlan
+}](ctx context.Context, plan P, diags *diag.Diagnostics) {
+	runtime := plan.GetRuntimePtr()
+	if runtime.Environment.IsUnknown() {
+		return
+	}
+
+	// best effort: values not known yet are checked again during apply
+	probeDiags := diag.Diagnostics{}
+	custom := runtime.CustomEnvironment(ctx, &probeDiags)
+	if probeDiags.HasError() || len(custom) == 0 {
+		return
+	}
+
+	typedPlan := *plan
+	P(&typedPlan).GetRuntimePtr().Environment = types.MapNull(types.StringType)
+	P(&typedPlan).GetRuntimePtr().EnvironmentFiles = attributes.NullEnvironmentFiles
+	typed := P(&typedPlan).ToEnv(ctx, &probeDiags)
+	if probeDiags.HasError() {
+		return
+	}
+
+	conflicts := []string{}
+	for key := range typed {
+		if _, isCustom := custom[key]; !isCustom {
+			continue
+		}
+		// reported with their index by attributes.ValidateWorkers
+		if strings.HasPrefix(key, attributes.CC_WORKER_COMMAND_PREFIX) ||
+			strings.HasPrefix(key, attributes.CC_HEALTH_CHECK_PATH_PREFIX) ||
+			key == attributes.CC_WORKER_RESTART {
+			continue
+		}
+		conflicts = append(conflicts, key)
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+	slices.Sort(conflicts)
+
+	owners := map[string]path.Path{}
+	for key, attribute := range plan.EnvAttributes() {
+		owners[key] = path.Root(attribute)
+	}
+	for key, attributePath := range commonEnvAttributes {
+		owners[key] = attributePath
+	}
+
+	for _, key := range conflicts {
+		customPath := runtime.CustomEnvironmentPath(key)
+
+		typedPath := "a typed attribute"
+		if owner, ok := owners[key]; ok {
+			typedPath = fmt.Sprintf("'%s'", owner)
+		}
+
+		diags.AddAttributeWarning(
+			customPath,
+			"environment variable conflicts with a typed attribute",
+			fmt.Sprintf("%s is set by both %s and '%s', the value of %s is used, remove one of them", key, typedPath, customPath, typedPath),
+		)
+	}
+}
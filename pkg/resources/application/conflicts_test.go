@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/attributes"
+)
+
+type conflictPlan struct {
+	Runtime
+	Version types.String `tfsdk:"version"`
+}
+
+func (p conflictPlan) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
+	env := p.CustomEnvironment(ctx, diags)
+	pkg.IfIsSetStr(p.Version, func(s string) { env["CC_VERSION"] = s })
+	env = pkg.Merge(env, p.Hooks.ToEnv())
+	return env
+}
+
+func (p conflictPlan) ToDeployment(auth *http.BasicAuth) *Deployment                         { return nil }
+func (p conflictPlan) FromEnv(context.Context, *maps.Map[string, string], *diag.Diagnostics) {}
+func (p conflictPlan) EnvAttributes() map[string]string {
+	return map[string]string{"CC_VERSION": "version"}
+}
+
+func TestValidateEnvironmentConflicts(t *testing.T) {
+	ctx := context.Background()
+
+	newPlan := func(env map[string]string) *conflictPlan {
+		environment, _ := types.MapValueFrom(ctx, types.StringType, env)
+		return &conflictPlan{
+			Runtime: Runtime{
+				Environment:      environment,
+				EnvironmentFiles: attributes.NullEnvironmentFiles,
+				Hooks:            &attributes.Hooks{PreRun: types.StringValue("./migrate")},
+			},
+			Version: types.StringValue("1"),
+		}
+	}
+
+	t.Run("no conflict", func(t *testing.T) {
+		diags := diag.Diagnostics{}
+		ValidateEnvironmentConflicts(ctx, newPlan(map[string]string{"FOO": "bar"}), &diags)
+		if len(diags) != 0 {
+			t.Errorf("unexpected diagnostics: %v", diags)
+		}
+	})
+
+	t.Run("runtime field and hook conflicts", func(t *testing.T) {
+		diags := diag.Diagnostics{}
+		plan := newPlan(map[string]string{"CC_VERSION": "2", "CC_PRE_RUN_HOOK": "./other", "FOO": "bar"})
+		ValidateEnvironmentConflicts(ctx, plan, &diags)
+
+		if diags.HasError() || diags.WarningsCount() != 2 {
+			t.Fatalf("expect 2 warnings, got %v", diags)
+		}
+
+		hookErr, ok := diags.Warnings()[0].(diag.DiagnosticWithPath)
+		if !ok || !hookErr.Path().Equal(path.Root("environment").AtMapKey("CC_PRE_RUN_HOOK")) {
+			t.Errorf("unexpected path for the first warning: %v", diags.Warnings()[0])
+		}
+		if !strings.Contains(hookErr.Detail(), "'hooks.pre_run'") {
+			t.Errorf("expect the typed attribute path in detail, got: %s", hookErr.Detail())
+		}
+		if !strings.Contains(diags.Warnings()[1].Detail(), "'version'") {
+			t.Errorf("expect the typed attribute path in detail, got: %s", diags.Warnings()[1].Detail())
+		}
+
+		// the plan must be left untouched
+		if plan.Version.ValueString() != "1" || plan.Hooks.PreRun.ValueString() != "./migrate" {
+			t.Errorf("plan has been modified: %+v", plan)
+		}
+	})
+}
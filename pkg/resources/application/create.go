@@ -124,6 +124,7 @@ func Create[T RuntimePlan](ctx context.Context, resource RuntimeResource, plan T
 
 	// Extract vhosts and environment from plan
 	vhosts := plan.VHostsAsStrings(ctx, &diags)
+	plan.GetRuntimePtr().SetSopsAgeKey(resource.SopsAgeKey())
 	environment := plan.ToEnv(ctx, &diags)
 	if diags.HasError() {
 		return diags
@@ -106,4 +106,6 @@ func (r *ResourceDocker) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "docker", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
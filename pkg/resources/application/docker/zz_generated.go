@@ -0,0 +1,217 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package docker
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/attributes"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/application"
+)
+
+// Variables holds the typed environment variables of the docker runtime
+type Variables struct {
+	Dockerfile        types.String `tfsdk:"dockerfile"`
+	ContainerPort     types.Int64  `tfsdk:"container_port"`
+	ContainerPortTCP  types.Int64  `tfsdk:"container_port_tcp"`
+	EnableIPv6        types.Bool   `tfsdk:"enable_ipv6"`
+	IPv6Cidr          types.String `tfsdk:"ipv6_cidr"`
+	RegistryURL       types.String `tfsdk:"registry_url"`
+	RegistryUser      types.String `tfsdk:"registry_user"`
+	RegistryPassword  types.String `tfsdk:"registry_password"`
+	DaemonSocketMount types.Bool   `tfsdk:"daemon_socket_mount"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"dockerfile": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The name of the Dockerfile to build",
+	},
+	"container_port": schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Set to custom HTTP port if your Docker container runs on custom port",
+	},
+	"container_port_tcp": schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Set to custom TCP port if your Docker container runs on custom port.",
+	},
+	"enable_ipv6": schema.BoolAttribute{
+		Optional:           true,
+		DeprecationMessage: "never works, please use `ipv6_cidr`",
+	},
+	"ipv6_cidr": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Activate the support of IPv6 with an IPv6 subnet int the docker daemon",
+		Validators: []validator.String{
+			ipv6CIDRValidator,
+		},
+	},
+	"registry_url": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The server of your private registry (optional).\tDocker’s public registry",
+	},
+	"registry_user": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The username to login to a private registry",
+	},
+	"registry_password": schema.StringAttribute{
+		Optional:            true,
+		Sensitive:           true,
+		MarkdownDescription: "The password of your username",
+	},
+	"daemon_socket_mount": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Set to true to access the host Docker socket from inside your container",
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.Dockerfile, func(s string) { env["CC_DOCKERFILE"] = s })
+	pkg.IfIsSetI(v.ContainerPort, func(i int64) { env["CC_DOCKER_EXPOSED_HTTP_PORT"] = strconv.FormatInt(i, 10) })
+	pkg.IfIsSetI(v.ContainerPortTCP, func(i int64) { env["CC_DOCKER_EXPOSED_TCP_PORT"] = strconv.FormatInt(i, 10) })
+	pkg.IfIsSetStr(v.IPv6Cidr, func(s string) { env["CC_DOCKER_FIXED_CIDR_V6"] = s })
+	pkg.IfIsSetStr(v.RegistryURL, func(s string) { env["CC_DOCKER_LOGIN_SERVER"] = s })
+	pkg.IfIsSetStr(v.RegistryUser, func(s string) { env["CC_DOCKER_LOGIN_USERNAME"] = s })
+	pkg.IfIsSetStr(v.RegistryPassword, func(s string) { env["CC_DOCKER_LOGIN_PASSWORD"] = s })
+	pkg.IfIsSetB(v.DaemonSocketMount, func(b bool) { env["CC_MOUNT_DOCKER_SOCKET"] = strconv.FormatBool(b) })
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.Dockerfile = pkg.FromStrPtr(env.PopPtr("CC_DOCKERFILE"))
+	v.ContainerPort = pkg.FromIntPtr(env.PopPtr("CC_DOCKER_EXPOSED_HTTP_PORT"))
+	v.ContainerPortTCP = pkg.FromIntPtr(env.PopPtr("CC_DOCKER_EXPOSED_TCP_PORT"))
+	v.IPv6Cidr = pkg.FromStrPtr(env.PopPtr("CC_DOCKER_FIXED_CIDR_V6"))
+	v.RegistryURL = pkg.FromStrPtr(env.PopPtr("CC_DOCKER_LOGIN_SERVER"))
+	v.RegistryUser = pkg.FromStrPtr(env.PopPtr("CC_DOCKER_LOGIN_USERNAME"))
+	v.RegistryPassword = pkg.FromStrPtr(env.PopPtr("CC_DOCKER_LOGIN_PASSWORD"))
+	v.DaemonSocketMount = pkg.FromBoolPtr(env.PopPtr("CC_MOUNT_DOCKER_SOCKET"))
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_DOCKERFILE":               "dockerfile",
+		"CC_DOCKER_EXPOSED_HTTP_PORT": "container_port",
+		"CC_DOCKER_EXPOSED_TCP_PORT":  "container_port_tcp",
+		"CC_DOCKER_FIXED_CIDR_V6":     "ipv6_cidr",
+		"CC_DOCKER_LOGIN_SERVER":      "registry_url",
+		"CC_DOCKER_LOGIN_USERNAME":    "registry_user",
+		"CC_DOCKER_LOGIN_PASSWORD":    "registry_password",
+		"CC_MOUNT_DOCKER_SOCKET":      "daemon_socket_mount",
+	}
+}
+
+func (r *ResourceDocker) GetVariantSlug() string {
+	return "docker"
+}
+
+type VariablesV0 struct {
+	Dockerfile        types.String `tfsdk:"dockerfile"`
+	ContainerPort     types.Int64  `tfsdk:"container_port"`
+	ContainerPortTCP  types.Int64  `tfsdk:"container_port_tcp"`
+	EnableIPv6        types.Bool   `tfsdk:"enable_ipv6"`
+	IPv6Cidr          types.String `tfsdk:"ipv6_cidr"`
+	RegistryURL       types.String `tfsdk:"registry_url"`
+	RegistryUser      types.String `tfsdk:"registry_user"`
+	RegistryPassword  types.String `tfsdk:"registry_password"`
+	DaemonSocketMount types.Bool   `tfsdk:"daemon_socket_mount"`
+}
+
+type stateV0 struct {
+	application.RuntimeV0
+	VariablesV0
+}
+
+var schemaV0 = schema.Schema{
+	Version:             0,
+	MarkdownDescription: dockerDoc,
+	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
+		"dockerfile": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The name of the Dockerfile to build",
+		},
+		"container_port": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Set to custom HTTP port if your Docker container runs on custom port",
+		},
+		"container_port_tcp": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Set to custom TCP port if your Docker container runs on custom port.",
+		},
+		"enable_ipv6": schema.BoolAttribute{
+			Optional:           true,
+			DeprecationMessage: "never works, please use `ipv6_cidr`",
+		},
+		"ipv6_cidr": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Activate the support of IPv6 with an IPv6 subnet int the docker daemon",
+			Validators: []validator.String{
+				ipv6CIDRValidator,
+			},
+		},
+		"registry_url": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The server of your private registry (optional).\tDocker’s public registry",
+		},
+		"registry_user": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The username to login to a private registry",
+		},
+		"registry_password": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "The password of your username",
+		},
+		"daemon_socket_mount": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Set to true to access the host Docker socket from inside your container",
+		},
+	}),
+	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+}
+
+// UpgradeState implements state migration from version 0 to 1 for vhosts attribute
+func (r *ResourceDocker) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, res *resource.UpgradeStateResponse) {
+				tflog.Info(ctx, "Upgrading Docker resource state from version 0 to 1")
+
+				old := helper.StateFrom[stateV0](ctx, *req.State, &res.Diagnostics)
+				if res.Diagnostics.HasError() {
+					return
+				}
+
+				newState := Docker{
+					Runtime: application.UpgradeRuntimeV0(ctx, old.RuntimeV0, &res.Diagnostics),
+					Variables: Variables{
+						Dockerfile:        old.Dockerfile,
+						ContainerPort:     old.ContainerPort,
+						ContainerPortTCP:  old.ContainerPortTCP,
+						EnableIPv6:        old.EnableIPv6,
+						IPv6Cidr:          old.IPv6Cidr,
+						RegistryURL:       old.RegistryURL,
+						RegistryUser:      old.RegistryUser,
+						RegistryPassword:  old.RegistryPassword,
+						DaemonSocketMount: old.DaemonSocketMount,
+					},
+				}
+
+				res.Diagnostics.Append(res.State.Set(ctx, newState)...)
+			},
+		},
+	}
+}
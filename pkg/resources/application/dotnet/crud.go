@@ -109,4 +109,6 @@ func (r *ResourceDotnet) ModifyPlan(ctx context.Context, req resource.ModifyPlan
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "dotnet", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
@@ -10,17 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Dotnet struct {
 	application.Runtime
-	DotnetProfile types.String `tfsdk:"profile"`
-	DotnetProj    types.String `tfsdk:"proj"`
-	DotnetTFM     types.String `tfsdk:"tfm"`
-	DotnetVersion types.String `tfsdk:"version"`
+	Variables
 }
 
 //go:embed doc.md
@@ -30,54 +26,30 @@ func (r ResourceDotnet) Schema(ctx context.Context, req resource.SchemaRequest,
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: dotnetDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			"profile": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Override the build configuration settings in your project. Default: Release",
-			},
-			"proj": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "The name of your project file to use for the build, without the .csproj / .fsproj / .vbproj extension.",
-			},
-			"tfm": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Compiles for a specific framework. The framework must be defined in the project file. Example : net5.0",
-			},
-			"version": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Choose the .NET Core version between 6.0, 8.0, 9.0. Default: '8.0'",
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (dotnetapp Dotnet) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(dotnetapp.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := dotnetapp.CustomEnvironment(ctx, diags)
 
 	env = pkg.Merge(env, customEnv)
 
-	pkg.IfIsSetStr(dotnetapp.DotnetProfile, func(s string) { env["CC_DOTNET_PROFILE"] = s })
-	pkg.IfIsSetStr(dotnetapp.DotnetProj, func(s string) { env["CC_DOTNET_PROJ"] = s })
-	pkg.IfIsSetStr(dotnetapp.DotnetTFM, func(s string) { env["CC_DOTNET_TFM"] = s })
-	pkg.IfIsSetStr(dotnetapp.DotnetVersion, func(s string) { env["CC_DOTNET_VERSION"] = s })
+	dotnetapp.Variables.ToEnv(ctx, env, diags)
 
 	env = pkg.Merge(env, dotnetapp.Hooks.ToEnv())
 	env = pkg.Merge(env, dotnetapp.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, dotnetapp.Workers, diags))
+	env = pkg.Merge(env, dotnetapp.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (dotnetapp *Dotnet) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
-	dotnetapp.DotnetProfile = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_PROFILE"))
-	dotnetapp.DotnetProj = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_PROJ"))
-	dotnetapp.DotnetTFM = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_TFM"))
-	dotnetapp.DotnetVersion = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_VERSION"))
+	dotnetapp.Variables.FromEnv(ctx, env, diags)
 
 	dotnetapp.Integrations = attributes.FromEnvIntegrations(ctx, env, dotnetapp.Integrations, diags)
 }
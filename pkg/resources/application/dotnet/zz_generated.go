@@ -0,0 +1,70 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package dotnet
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+// Variables holds the typed environment variables of the dotnet runtime
+type Variables struct {
+	DotnetProfile types.String `tfsdk:"profile"`
+	DotnetProj    types.String `tfsdk:"proj"`
+	DotnetTFM     types.String `tfsdk:"tfm"`
+	DotnetVersion types.String `tfsdk:"version"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"profile": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Override the build configuration settings in your project. Default: Release",
+	},
+	"proj": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The name of your project file to use for the build, without the .csproj / .fsproj / .vbproj extension.",
+	},
+	"tfm": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Compiles for a specific framework. The framework must be defined in the project file. Example : net5.0",
+	},
+	"version": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Choose the .NET Core version between 6.0, 8.0, 9.0. Default: '8.0'",
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.DotnetProfile, func(s string) { env["CC_DOTNET_PROFILE"] = s })
+	pkg.IfIsSetStr(v.DotnetProj, func(s string) { env["CC_DOTNET_PROJ"] = s })
+	pkg.IfIsSetStr(v.DotnetTFM, func(s string) { env["CC_DOTNET_TFM"] = s })
+	pkg.IfIsSetStr(v.DotnetVersion, func(s string) { env["CC_DOTNET_VERSION"] = s })
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.DotnetProfile = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_PROFILE"))
+	v.DotnetProj = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_PROJ"))
+	v.DotnetTFM = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_TFM"))
+	v.DotnetVersion = pkg.FromStrPtr(env.PopPtr("CC_DOTNET_VERSION"))
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_DOTNET_PROFILE": "profile",
+		"CC_DOTNET_PROJ":    "proj",
+		"CC_DOTNET_TFM":     "tfm",
+		"CC_DOTNET_VERSION": "version",
+	}
+}
+
+func (r *ResourceDotnet) GetVariantSlug() string {
+	return "dotnet"
+}
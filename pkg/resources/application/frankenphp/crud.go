@@ -108,4 +108,6 @@ func (r *ResourceFrankenPHP) ModifyPlan(ctx context.Context, req resource.Modify
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "frankenphp", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
@@ -10,15 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type FrankenPHP struct {
 	application.Runtime
-	DevDependencies types.Bool `tfsdk:"dev_dependencies"`
+	Variables
 }
 
 //go:embed doc.md
@@ -28,43 +26,31 @@ func (r ResourceFrankenPHP) Schema(ctx context.Context, req resource.SchemaReque
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: frankenphpDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			"dev_dependencies": schema.BoolAttribute{
-				Optional:            true,
-				Computed:            true,
-				MarkdownDescription: "Install development dependencies (Default: false)",
-				Default:             booldefault.StaticBool(false),
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (fp *FrankenPHP) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(fp.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := fp.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
-	pkg.IfIsSetB(fp.DevDependencies, func(devDeps bool) {
-		if devDeps {
-			env["CC_PHP_DEV_DEPENDENCIES"] = "install"
-		}
-	})
+	fp.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, fp.Hooks.ToEnv())
 	env = pkg.Merge(env, fp.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, fp.Workers, diags))
+	env = pkg.Merge(env, fp.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (fp *FrankenPHP) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
-	pkg.SetBoolIf(&fp.DevDependencies, env.PopPtr("CC_PHP_DEV_DEPENDENCIES"), "install")
+	fp.Variables.FromEnv(ctx, env, diags)
 
 	fp.Integrations = attributes.FromEnvIntegrations(ctx, env, fp.Integrations, diags)
 }
@@ -0,0 +1,3 @@
+package application
+
+//go:generate go run ../../../tools/runtimegen -spec runtimes.yaml
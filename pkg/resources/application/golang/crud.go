@@ -109,4 +109,6 @@ func (r *ResourceGo) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "go", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
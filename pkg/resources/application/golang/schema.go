@@ -16,10 +16,7 @@ import (
 
 type Go struct {
 	application.Runtime
-}
-
-type GoV0 struct {
-	application.RuntimeV0
+	Variables
 }
 
 //go:embed doc.md
@@ -32,38 +29,32 @@ func (r ResourceGo) Schema(ctx context.Context, req resource.SchemaRequest, res
 var schemaGo = schema.Schema{
 	Version:             1,
 	MarkdownDescription: goDoc,
-	Attributes:          application.WithRuntimeCommons(map[string]schema.Attribute{}),
-	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaGoV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: goDoc,
-	Attributes:          application.WithRuntimeCommonsV0(map[string]schema.Attribute{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
 	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (g Go) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(g.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := g.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
 	pkg.IfIsSetStr(g.AppFolder, func(s string) { env["APP_FOLDER"] = s })
+	g.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, g.Hooks.ToEnv())
 	env = pkg.Merge(env, g.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, g.Workers, diags))
+	env = pkg.Merge(env, g.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (g *Go) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	g.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
+	g.Variables.FromEnv(ctx, env, diags)
 
 	g.Integrations = attributes.FromEnvIntegrations(ctx, env, g.Integrations, diags)
 }
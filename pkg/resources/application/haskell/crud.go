@@ -109,4 +109,6 @@ func (r *ResourceHaskell) ModifyPlan(ctx context.Context, req resource.ModifyPla
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "haskell", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
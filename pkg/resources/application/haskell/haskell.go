@@ -18,7 +18,3 @@ func NewResourceHaskell() resource.Resource {
 func (r *ResourceHaskell) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
 	res.TypeName = req.ProviderTypeName + "_haskell"
 }
-
-func (r *ResourceHaskell) GetVariantSlug() string {
-	return "haskell"
-}
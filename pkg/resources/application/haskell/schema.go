@@ -10,17 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Haskell struct {
 	application.Runtime
-	StackTarget                     types.String `tfsdk:"stack_target"`
-	StackSetupCommand               types.String `tfsdk:"stack_setup_command"`
-	StackInstallCommand             types.String `tfsdk:"stack_install_command"`
-	StackInstallDependenciesCommand types.String `tfsdk:"stack_install_dependencies_command"`
+	Variables
 }
 
 //go:embed doc.md
@@ -30,56 +26,32 @@ func (r ResourceHaskell) Schema(ctx context.Context, req resource.SchemaRequest,
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: haskellDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			"stack_target": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Specify Stack package target.",
-			},
-			"stack_setup_command": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Only use this variable to override the default `setup` Stack step command.",
-			},
-			"stack_install_command": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Only use this variable to override the default `install` Stack step command.",
-			},
-			"stack_install_dependencies_command": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Only use this variable to override the default `install --only-dependencies` Stack step command.",
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (haskellapp Haskell) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(haskellapp.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := haskellapp.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
-	pkg.IfIsSetStr(haskellapp.StackTarget, func(s string) { env["CC_HASKELL_STACK_TARGET"] = s })
-	pkg.IfIsSetStr(haskellapp.StackSetupCommand, func(s string) { env["CC_HASKELL_STACK_SETUP_COMMAND"] = s })
-	pkg.IfIsSetStr(haskellapp.StackInstallCommand, func(s string) { env["CC_HASKELL_STACK_INSTALL_COMMAND"] = s })
-	pkg.IfIsSetStr(haskellapp.StackInstallDependenciesCommand, func(s string) { env["CC_HASKELL_STACK_INSTALL_DEPENDENCIES_COMMAND"] = s })
+	haskellapp.Variables.ToEnv(ctx, env, diags)
 
 	env = pkg.Merge(env, haskellapp.Hooks.ToEnv())
 	env = pkg.Merge(env, haskellapp.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, haskellapp.Workers, diags))
+	env = pkg.Merge(env, haskellapp.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (haskellapp *Haskell) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
-	haskellapp.StackTarget = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_TARGET"))
-	haskellapp.StackSetupCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_SETUP_COMMAND"))
-	haskellapp.StackInstallCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_INSTALL_COMMAND"))
-	haskellapp.StackInstallDependenciesCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_INSTALL_DEPENDENCIES_COMMAND"))
+	haskellapp.Variables.FromEnv(ctx, env, diags)
 
 	haskellapp.Integrations = attributes.FromEnvIntegrations(ctx, env, haskellapp.Integrations, diags)
 }
@@ -0,0 +1,70 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package haskell
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+// Variables holds the typed environment variables of the haskell runtime
+type Variables struct {
+	StackTarget                     types.String `tfsdk:"stack_target"`
+	StackSetupCommand               types.String `tfsdk:"stack_setup_command"`
+	StackInstallCommand             types.String `tfsdk:"stack_install_command"`
+	StackInstallDependenciesCommand types.String `tfsdk:"stack_install_dependencies_command"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"stack_target": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Specify Stack package target.",
+	},
+	"stack_setup_command": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only use this variable to override the default `setup` Stack step command.",
+	},
+	"stack_install_command": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only use this variable to override the default `install` Stack step command.",
+	},
+	"stack_install_dependencies_command": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Only use this variable to override the default `install --only-dependencies` Stack step command.",
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.StackTarget, func(s string) { env["CC_HASKELL_STACK_TARGET"] = s })
+	pkg.IfIsSetStr(v.StackSetupCommand, func(s string) { env["CC_HASKELL_STACK_SETUP_COMMAND"] = s })
+	pkg.IfIsSetStr(v.StackInstallCommand, func(s string) { env["CC_HASKELL_STACK_INSTALL_COMMAND"] = s })
+	pkg.IfIsSetStr(v.StackInstallDependenciesCommand, func(s string) { env["CC_HASKELL_STACK_INSTALL_DEPENDENCIES_COMMAND"] = s })
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.StackTarget = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_TARGET"))
+	v.StackSetupCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_SETUP_COMMAND"))
+	v.StackInstallCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_INSTALL_COMMAND"))
+	v.StackInstallDependenciesCommand = pkg.FromStrPtr(env.PopPtr("CC_HASKELL_STACK_INSTALL_DEPENDENCIES_COMMAND"))
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_HASKELL_STACK_TARGET":                       "stack_target",
+		"CC_HASKELL_STACK_SETUP_COMMAND":                "stack_setup_command",
+		"CC_HASKELL_STACK_INSTALL_COMMAND":              "stack_install_command",
+		"CC_HASKELL_STACK_INSTALL_DEPENDENCIES_COMMAND": "stack_install_dependencies_command",
+	}
+}
+
+func (r *ResourceHaskell) GetVariantSlug() string {
+	return "haskell"
+}
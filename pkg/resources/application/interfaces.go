@@ -25,6 +25,7 @@ type RuntimePlan interface {
 	ToDeployment(auth *http.BasicAuth) *Deployment
 	GetRuntimePtr() *Runtime
 	FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics)
+	EnvAttributes() map[string]string
 }
 
 // AppResponseProvider abstracts access to the underlying AppResponse for response mapping
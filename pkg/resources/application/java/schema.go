@@ -12,19 +12,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	helperMaps "github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Java struct {
 	application.Runtime
-	JavaVersion types.String `tfsdk:"java_version"`
-}
-
-type JavaV0 struct {
-	application.RuntimeV0
-	JavaVersion types.String `tfsdk:"java_version"`
+	Variables
 }
 
 //go:embed doc.md
@@ -37,49 +31,31 @@ func (r ResourceJava) Schema(ctx context.Context, req resource.SchemaRequest, re
 var schemaJava = schema.Schema{
 	Version:             1,
 	MarkdownDescription: javaDoc,
-	Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-		"java_version": schema.StringAttribute{
-			Optional:    true,
-			Description: "Choose the JVM version between 7 to 24 for OpenJDK or graalvm-ce for GraalVM 21.0.0.2 (based on OpenJDK 11.0).",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaJavaV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: javaDoc,
-	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
-		"java_version": schema.StringAttribute{
-			Optional:    true,
-			Description: "Choose the JVM version between 7 to 24 for OpenJDK or graalvm-ce for GraalVM 21.0.0.2 (based on OpenJDK 11.0).",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
+	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (plan *Java) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(plan.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := plan.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	maps.Copy(env, customEnv)
 
 	pkg.IfIsSetStr(plan.AppFolder, func(s string) { env["APP_FOLDER"] = s })
-	pkg.IfIsSetStr(plan.JavaVersion, func(s string) { env["CC_JAVA_VERSION"] = s })
+	plan.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, plan.Hooks.ToEnv())
 	env = pkg.Merge(env, plan.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, plan.Workers, diags))
+	env = pkg.Merge(env, plan.HealthCheck.ToEnv(ctx, diags))
 	return env
 }
 
 func (plan *Java) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
 	plan.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
-	plan.JavaVersion = pkg.FromStrPtr(env.PopPtr("CC_JAVA_VERSION"))
+	plan.Variables.FromEnv(ctx, env, diags)
 
 	plan.Integrations = attributes.FromEnvIntegrations(ctx, env, plan.Integrations, diags)
 }
@@ -18,7 +18,3 @@ func NewResourceLinux() resource.Resource {
 func (r *ResourceLinux) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
 	res.TypeName = req.ProviderTypeName + "_linux"
 }
-
-func (r *ResourceLinux) GetVariantSlug() string {
-	return "linux"
-}
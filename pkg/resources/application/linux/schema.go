@@ -10,19 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Linux struct {
 	application.Runtime
-	RunCommand   types.String `tfsdk:"run_command"`
-	BuildCommand types.String `tfsdk:"build_command"`
-	Makefile     types.String `tfsdk:"makefile"`
-	MiseFilePath types.String `tfsdk:"mise_file_path"`
-	DisableMise  types.Bool   `tfsdk:"disable_mise"`
+	Variables
 }
 
 //go:embed doc.md
@@ -32,70 +26,34 @@ func (r ResourceLinux) Schema(ctx context.Context, req resource.SchemaRequest, r
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: linuxDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			"run_command": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "The command to start your application.",
-			},
-			"build_command": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "The command to run during the build phase.",
-			},
-			"makefile": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Custom Makefile name or path.",
-			},
-			"mise_file_path": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "Custom path for the mise.toml configuration file (relative path).",
-			},
-			"disable_mise": schema.BoolAttribute{
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
-				MarkdownDescription: "Disable Mise tool installation (Default: false).",
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (l *Linux) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(l.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := l.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
 	pkg.IfIsSetStr(l.AppFolder, func(s string) { env["APP_FOLDER"] = s })
-	pkg.IfIsSetStr(l.RunCommand, func(s string) { env["CC_RUN_COMMAND"] = s })
-	pkg.IfIsSetStr(l.BuildCommand, func(s string) { env["CC_BUILD_COMMAND"] = s })
-	pkg.IfIsSetStr(l.Makefile, func(s string) { env["CC_MAKEFILE"] = s })
-	pkg.IfIsSetStr(l.MiseFilePath, func(s string) { env["CC_MISE_FILE_PATH"] = s })
-	pkg.IfIsSetB(l.DisableMise, func(disable bool) {
-		if disable {
-			env["CC_DISABLE_MISE"] = "true"
-		}
-	})
+	l.Variables.ToEnv(ctx, env, diags)
 
 	env = pkg.Merge(env, l.Hooks.ToEnv())
 	env = pkg.Merge(env, l.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, l.Workers, diags))
+	env = pkg.Merge(env, l.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (l *Linux) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	l.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
-	l.RunCommand = pkg.FromStrPtr(env.PopPtr("CC_RUN_COMMAND"))
-	l.BuildCommand = pkg.FromStrPtr(env.PopPtr("CC_BUILD_COMMAND"))
-	l.Makefile = pkg.FromStrPtr(env.PopPtr("CC_MAKEFILE"))
-	l.MiseFilePath = pkg.FromStrPtr(env.PopPtr("CC_MISE_FILE_PATH"))
-	pkg.SetBoolIf(&l.DisableMise, env.PopPtr("CC_DISABLE_MISE"), "true")
+	l.Variables.FromEnv(ctx, env, diags)
 
 	l.Integrations = attributes.FromEnvIntegrations(ctx, env, l.Integrations, diags)
 }
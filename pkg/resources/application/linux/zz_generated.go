@@ -0,0 +1,85 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package linux
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+// Variables holds the typed environment variables of the linux runtime
+type Variables struct {
+	RunCommand   types.String `tfsdk:"run_command"`
+	BuildCommand types.String `tfsdk:"build_command"`
+	Makefile     types.String `tfsdk:"makefile"`
+	MiseFilePath types.String `tfsdk:"mise_file_path"`
+	DisableMise  types.Bool   `tfsdk:"disable_mise"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"run_command": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The command to start your application.",
+	},
+	"build_command": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The command to run during the build phase.",
+	},
+	"makefile": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Custom Makefile name or path.",
+	},
+	"mise_file_path": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Custom path for the mise.toml configuration file (relative path).",
+	},
+	"disable_mise": schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Disable Mise tool installation (Default: false).",
+		Default:             booldefault.StaticBool(false),
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.RunCommand, func(s string) { env["CC_RUN_COMMAND"] = s })
+	pkg.IfIsSetStr(v.BuildCommand, func(s string) { env["CC_BUILD_COMMAND"] = s })
+	pkg.IfIsSetStr(v.Makefile, func(s string) { env["CC_MAKEFILE"] = s })
+	pkg.IfIsSetStr(v.MiseFilePath, func(s string) { env["CC_MISE_FILE_PATH"] = s })
+	pkg.IfIsSetB(v.DisableMise, func(b bool) {
+		if b {
+			env["CC_DISABLE_MISE"] = "true"
+		}
+	})
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.RunCommand = pkg.FromStrPtr(env.PopPtr("CC_RUN_COMMAND"))
+	v.BuildCommand = pkg.FromStrPtr(env.PopPtr("CC_BUILD_COMMAND"))
+	v.Makefile = pkg.FromStrPtr(env.PopPtr("CC_MAKEFILE"))
+	v.MiseFilePath = pkg.FromStrPtr(env.PopPtr("CC_MISE_FILE_PATH"))
+	pkg.SetBoolIf(&v.DisableMise, env.PopPtr("CC_DISABLE_MISE"), "true")
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_RUN_COMMAND":    "run_command",
+		"CC_BUILD_COMMAND":  "build_command",
+		"CC_MAKEFILE":       "makefile",
+		"CC_MISE_FILE_PATH": "mise_file_path",
+		"CC_DISABLE_MISE":   "disable_mise",
+	}
+}
+
+func (r *ResourceLinux) GetVariantSlug() string {
+	return "linux"
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"maps"
 	"regexp"
 	"testing"
 	"time"
 
 	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
@@ -16,11 +19,45 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/application/nodejs"
 	"go.clever-cloud.com/terraform-provider/pkg/tests"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
 	"go.clever-cloud.dev/client"
 )
 
+func TestVariablesToEnv(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name            string
+		devDependencies types.Bool
+		expectEnv       map[string]string
+	}{
+		{name: "true", devDependencies: types.BoolValue(true), expectEnv: map[string]string{"CC_NODE_DEV_DEPENDENCIES": "install"}},
+		{name: "false", devDependencies: types.BoolValue(false), expectEnv: map[string]string{}},
+		{name: "null", devDependencies: types.BoolNull(), expectEnv: map[string]string{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := diag.Diagnostics{}
+			env := map[string]string{}
+			vars := nodejs.Variables{
+				DevDependencies: tt.devDependencies,
+				StartScript:     types.StringNull(),
+				PackageManager:  types.StringNull(),
+				Registry:        types.StringNull(),
+				RegistryToken:   types.StringNull(),
+			}
+			vars.ToEnv(ctx, env, &diags)
+			if diags.HasError() {
+				t.Fatalf("ToEnv() diags = %v", diags)
+			}
+			if !maps.Equal(env, tt.expectEnv) {
+				t.Errorf("ToEnv() = %v, want %v", env, tt.expectEnv)
+			}
+		})
+	}
+}
+
 func TestAccNodejs_basic(t *testing.T) {
 	t.Parallel()
 
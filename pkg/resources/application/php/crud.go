@@ -106,4 +106,6 @@ func (r *ResourcePHP) ModifyPlan(ctx context.Context, req resource.ModifyPlanReq
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "php", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
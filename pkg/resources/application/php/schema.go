@@ -10,25 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type PHP struct {
 	application.Runtime
-	PHPVersion      types.String `tfsdk:"php_version"`
-	WebRoot         types.String `tfsdk:"webroot"`
-	RedisSessions   types.Bool   `tfsdk:"redis_sessions"`
-	DevDependencies types.Bool   `tfsdk:"dev_dependencies"`
-}
-
-type PHPV0 struct {
-	application.RuntimeV0
-	PHPVersion      types.String `tfsdk:"php_version"`
-	WebRoot         types.String `tfsdk:"webroot"`
-	RedisSessions   types.Bool   `tfsdk:"redis_sessions"`
-	DevDependencies types.Bool   `tfsdk:"dev_dependencies"`
+	Variables
 }
 
 //go:embed doc.md
@@ -41,92 +29,32 @@ func (r ResourcePHP) Schema(ctx context.Context, req resource.SchemaRequest, res
 var schemaPHP = schema.Schema{
 	Version:             1,
 	MarkdownDescription: phpDoc,
-	Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-		// CC_WEBROOT
-		"php_version": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "PHP version (Default: 8)",
-		},
-		"webroot": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Define the DocumentRoot of your project (default: \".\")",
-		},
-
-		"redis_sessions": schema.BoolAttribute{
-			Optional:            true,
-			MarkdownDescription: "Use a linked Redis instance to store sessions (Default: false)",
-		},
-		"dev_dependencies": schema.BoolAttribute{
-			Optional:            true,
-			MarkdownDescription: "Install development dependencies",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaPHPV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: phpDoc,
-	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
-		// CC_WEBROOT
-		"php_version": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "PHP version (Default: 8)",
-		},
-		"webroot": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Define the DocumentRoot of your project (default: \".\")",
-		},
-
-		"redis_sessions": schema.BoolAttribute{
-			Optional:            true,
-			MarkdownDescription: "Use a linked Redis instance to store sessions (Default: false)",
-		},
-		"dev_dependencies": schema.BoolAttribute{
-			Optional:            true,
-			MarkdownDescription: "Install development dependencies",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
+	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (p *PHP) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(p.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := p.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
 	pkg.IfIsSetStr(p.AppFolder, func(s string) { env["APP_FOLDER"] = s })
-	pkg.IfIsSetStr(p.WebRoot, func(webroot string) { env["CC_WEBROOT"] = webroot })
-	pkg.IfIsSetStr(p.PHPVersion, func(version string) { env["CC_PHP_VERSION"] = version })
-	pkg.IfIsSetB(p.DevDependencies, func(devDeps bool) {
-		if devDeps {
-			env["CC_PHP_DEV_DEPENDENCIES"] = "install"
-		}
-	})
-	pkg.IfIsSetB(p.RedisSessions, func(redis bool) {
-		if redis {
-			env["SESSION_TYPE"] = "redis"
-		}
-	})
+	p.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, p.Hooks.ToEnv())
 	env = pkg.Merge(env, p.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, p.Workers, diags))
+	env = pkg.Merge(env, p.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (p *PHP) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	p.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
-	p.WebRoot = pkg.FromStrPtr(env.PopPtr("CC_WEBROOT"))
-	p.PHPVersion = pkg.FromStrPtr(env.PopPtr("CC_PHP_VERSION"))
-	pkg.SetBoolIf(&p.DevDependencies, env.PopPtr("CC_PHP_DEV_DEPENDENCIES"), "install")
-	pkg.SetBoolIf(&p.RedisSessions, env.PopPtr("SESSION_TYPE"), "redis")
+	p.Variables.FromEnv(ctx, env, diags)
 
 	p.Integrations = attributes.FromEnvIntegrations(ctx, env, p.Integrations, diags)
 }
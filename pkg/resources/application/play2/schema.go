@@ -18,10 +18,7 @@ import (
 
 type Play2 struct {
 	application.Runtime
-}
-
-type Play2V0 struct {
-	application.RuntimeV0
+	Variables
 }
 
 //go:embed doc.md
@@ -34,36 +31,30 @@ func (r ResourcePlay2) Schema(ctx context.Context, req resource.SchemaRequest, r
 var schemaPlay2 = schema.Schema{
 	Version:             1,
 	MarkdownDescription: play2Doc,
-	Attributes:          application.WithRuntimeCommons(map[string]schema.Attribute{}),
-	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaPlay2V0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: play2Doc,
-	Attributes:          application.WithRuntimeCommonsV0(map[string]schema.Attribute{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
 	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (plan *Play2) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(plan.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := plan.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	maps.Copy(env, customEnv)
 
 	pkg.IfIsSetStr(plan.AppFolder, func(s string) { env["APP_FOLDER"] = s })
+	plan.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, plan.Hooks.ToEnv())
 	env = pkg.Merge(env, plan.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, plan.Workers, diags))
+	env = pkg.Merge(env, plan.HealthCheck.ToEnv(ctx, diags))
 	return env
 }
 
 func (play2 *Play2) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
 	play2.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
+	play2.Variables.FromEnv(ctx, env, diags)
 	play2.Integrations = attributes.FromEnvIntegrations(ctx, env, play2.Integrations, diags)
 }
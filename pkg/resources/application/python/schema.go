@@ -10,21 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Python struct {
 	application.Runtime
-	PythonVersion   types.String `tfsdk:"python_version"`
-	PipRequirements types.String `tfsdk:"pip_requirements"`
-}
-
-type PythonV0 struct {
-	application.RuntimeV0
-	PythonVersion   types.String `tfsdk:"python_version"`
-	PipRequirements types.String `tfsdk:"pip_requirements"`
+	Variables
 }
 
 //go:embed doc.md
@@ -37,64 +29,32 @@ func (r ResourcePython) Schema(ctx context.Context, req resource.SchemaRequest,
 var schemaPythonV1 = schema.Schema{
 	Version:             1,
 	MarkdownDescription: pythonDoc,
-	Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-		// CC_PYTHON_VERSION
-		"python_version": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Python version >= 2.7",
-		},
-		// CC_PIP_REQUIREMENTS_FILE
-		"pip_requirements": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Define a custom requirements.txt file (default: requirements.txt)",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaPythonV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: pythonDoc,
-	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
-		// CC_PYTHON_VERSION
-		"python_version": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Python version >= 2.7",
-		},
-		// CC_PIP_REQUIREMENTS_FILE
-		"pip_requirements": schema.StringAttribute{
-			Optional:            true,
-			MarkdownDescription: "Define a custom requirements.txt file (default: requirements.txt)",
-		},
-	}),
-	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
+	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (py Python) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(py.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := py.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
 	pkg.IfIsSetStr(py.AppFolder, func(s string) { env["APP_FOLDER"] = s })
-	pkg.IfIsSetStr(py.PythonVersion, func(version string) { env["CC_PYTHON_VERSION"] = version })
-	pkg.IfIsSetStr(py.PipRequirements, func(pipReqFile string) { env["CC_PIP_REQUIREMENTS_FILE"] = pipReqFile })
+	py.Variables.ToEnv(ctx, env, diags)
 
 	env = pkg.Merge(env, py.Hooks.ToEnv())
 	env = pkg.Merge(env, py.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, py.Workers, diags))
+	env = pkg.Merge(env, py.HealthCheck.ToEnv(ctx, diags))
 	return env
 }
 
 func (py *Python) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	py.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
-	py.PythonVersion = pkg.FromStrPtr(env.PopPtr("CC_PYTHON_VERSION"))
-	py.PipRequirements = pkg.FromStrPtr(env.PopPtr("CC_PIP_REQUIREMENTS_FILE"))
+	py.Variables.FromEnv(ctx, env, diags)
 
 	py.Integrations = attributes.FromEnvIntegrations(ctx, env, py.Integrations, diags)
 }
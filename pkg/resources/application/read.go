@@ -137,6 +137,11 @@ func Read[T RuntimePlan](ctx context.Context, resource RuntimeResource, state T)
 	state.FromEnv(ctx, env, &diags)
 
 	runtime.Hooks = attributes.FromEnvHooks(env, runtime.Hooks)
+	runtime.Workers = attributes.FromEnvWorkers(ctx, env, runtime.Workers, &diags)
+	runtime.HealthCheck = attributes.FromEnvHealthCheck(ctx, env, runtime.HealthCheck, &diags)
+
+	// Variables owned by environment files are not reflected in `environment`
+	runtime.ReadEnvironment(ctx, env, runtime.Environment, &diags)
 
 	if env.Size() > 0 {
 		nativeEnvMap := maps.Collect(env.All)
@@ -0,0 +1,319 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package ruby
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/attributes"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/application"
+)
+
+// Variables holds the typed environment variables of the ruby runtime
+type Variables struct {
+	RubyVersion           types.String `tfsdk:"ruby_version"`
+	EnableSidekiq         types.Bool   `tfsdk:"enable_sidekiq"`
+	RackupServer          types.String `tfsdk:"rackup_server"`
+	RakeGoals             types.String `tfsdk:"rake_goals"`
+	SidekiqFiles          types.String `tfsdk:"sidekiq_files"`
+	HTTPBasicAuth         types.String `tfsdk:"http_basic_auth"`
+	NginxProxyBuffers     types.String `tfsdk:"nginx_proxy_buffers"`
+	NginxProxyBufferSize  types.String `tfsdk:"nginx_proxy_buffer_size"`
+	EnableGzipCompression types.Bool   `tfsdk:"enable_gzip_compression"`
+	GzipTypes             types.String `tfsdk:"gzip_types"`
+	NginxReadTimeout      types.Int64  `tfsdk:"nginx_read_timeout"`
+	RackEnv               types.String `tfsdk:"rack_env"`
+	RailsEnv              types.String `tfsdk:"rails_env"`
+	StaticFilesPath       types.String `tfsdk:"static_files_path"`
+	StaticURLPrefix       types.String `tfsdk:"static_url_prefix"`
+	StaticWebroot         types.String `tfsdk:"static_webroot"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"ruby_version": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Ruby version to use (e.g., '3.3', '3.3.1')",
+	},
+	"enable_sidekiq": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Enable Sidekiq background process",
+	},
+	"rackup_server": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Server to use for serving the Ruby application (default: puma)",
+	},
+	"rake_goals": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Comma-separated list of rake goals to execute (e.g., 'db:migrate,assets:precompile')",
+	},
+	"sidekiq_files": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Specify a list of Sidekiq configuration files (e.g., './config/sidekiq_1.yml,./config/sidekiq_2.yml')",
+	},
+	"http_basic_auth": schema.StringAttribute{
+		Optional:            true,
+		Sensitive:           true,
+		MarkdownDescription: "Restrict HTTP access to your application (format: 'login:password')",
+	},
+	"nginx_proxy_buffers": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Sets the number and size of the buffers used for reading a response from the proxied server",
+	},
+	"nginx_proxy_buffer_size": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Sets the size of the buffer used for reading the first part of the response received from the proxied server",
+	},
+	"enable_gzip_compression": schema.BoolAttribute{
+		Optional:            true,
+		MarkdownDescription: "Set to true to gzip-compress through Nginx",
+	},
+	"gzip_types": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Set the mime types to compress (default: 'text/* application/json application/xml application/javascript image/svg+xml')",
+	},
+	"nginx_read_timeout": schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Read timeout in seconds (default: 300)",
+	},
+	"rack_env": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Rack environment variable",
+	},
+	"rails_env": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Rails environment variable",
+	},
+	"static_files_path": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Relative path to where your static files are stored",
+	},
+	"static_url_prefix": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The URL path under which you want to serve static files, usually /public",
+	},
+	"static_webroot": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "Path to the web content to serve, relative to the root of your application",
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.RubyVersion, func(s string) { env["CC_RUBY_VERSION"] = s })
+	pkg.IfIsSetB(v.EnableSidekiq, func(b bool) {
+		if b {
+			env["CC_ENABLE_SIDEKIQ"] = "true"
+		}
+	})
+	pkg.IfIsSetStr(v.RackupServer, func(s string) { env["CC_RACKUP_SERVER"] = s })
+	pkg.IfIsSetStr(v.RakeGoals, func(s string) { env["CC_RAKEGOALS"] = s })
+	pkg.IfIsSetStr(v.SidekiqFiles, func(s string) { env["CC_SIDEKIQ_FILES"] = s })
+	pkg.IfIsSetStr(v.HTTPBasicAuth, func(s string) { env["CC_HTTP_BASIC_AUTH"] = s })
+	pkg.IfIsSetStr(v.NginxProxyBuffers, func(s string) { env["CC_NGINX_PROXY_BUFFERS"] = s })
+	pkg.IfIsSetStr(v.NginxProxyBufferSize, func(s string) { env["CC_NGINX_PROXY_BUFFER_SIZE"] = s })
+	pkg.IfIsSetB(v.EnableGzipCompression, func(b bool) {
+		if b {
+			env["ENABLE_GZIP_COMPRESSION"] = "true"
+		}
+	})
+	pkg.IfIsSetStr(v.GzipTypes, func(s string) { env["GZIP_TYPES"] = s })
+	pkg.IfIsSetI(v.NginxReadTimeout, func(i int64) { env["NGINX_READ_TIMEOUT"] = strconv.FormatInt(i, 10) })
+	pkg.IfIsSetStr(v.RackEnv, func(s string) { env["RACK_ENV"] = s })
+	pkg.IfIsSetStr(v.RailsEnv, func(s string) { env["RAILS_ENV"] = s })
+	pkg.IfIsSetStr(v.StaticFilesPath, func(s string) { env["STATIC_FILES_PATH"] = s })
+	pkg.IfIsSetStr(v.StaticURLPrefix, func(s string) { env["STATIC_URL_PREFIX"] = s })
+	pkg.IfIsSetStr(v.StaticWebroot, func(s string) { env["STATIC_WEBROOT"] = s })
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.RubyVersion = pkg.FromStrPtr(env.PopPtr("CC_RUBY_VERSION"))
+	pkg.SetBoolIf(&v.EnableSidekiq, env.PopPtr("CC_ENABLE_SIDEKIQ"), "true")
+	v.RackupServer = pkg.FromStrPtr(env.PopPtr("CC_RACKUP_SERVER"))
+	v.RakeGoals = pkg.FromStrPtr(env.PopPtr("CC_RAKEGOALS"))
+	v.SidekiqFiles = pkg.FromStrPtr(env.PopPtr("CC_SIDEKIQ_FILES"))
+	v.HTTPBasicAuth = pkg.FromStrPtr(env.PopPtr("CC_HTTP_BASIC_AUTH"))
+	v.NginxProxyBuffers = pkg.FromStrPtr(env.PopPtr("CC_NGINX_PROXY_BUFFERS"))
+	v.NginxProxyBufferSize = pkg.FromStrPtr(env.PopPtr("CC_NGINX_PROXY_BUFFER_SIZE"))
+	pkg.SetBoolIf(&v.EnableGzipCompression, env.PopPtr("ENABLE_GZIP_COMPRESSION"), "true")
+	v.GzipTypes = pkg.FromStrPtr(env.PopPtr("GZIP_TYPES"))
+	v.NginxReadTimeout = pkg.FromIntPtr(env.PopPtr("NGINX_READ_TIMEOUT"))
+	v.RackEnv = pkg.FromStrPtr(env.PopPtr("RACK_ENV"))
+	v.RailsEnv = pkg.FromStrPtr(env.PopPtr("RAILS_ENV"))
+	v.StaticFilesPath = pkg.FromStrPtr(env.PopPtr("STATIC_FILES_PATH"))
+	v.StaticURLPrefix = pkg.FromStrPtr(env.PopPtr("STATIC_URL_PREFIX"))
+	v.StaticWebroot = pkg.FromStrPtr(env.PopPtr("STATIC_WEBROOT"))
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_RUBY_VERSION":            "ruby_version",
+		"CC_ENABLE_SIDEKIQ":          "enable_sidekiq",
+		"CC_RACKUP_SERVER":           "rackup_server",
+		"CC_RAKEGOALS":               "rake_goals",
+		"CC_SIDEKIQ_FILES":           "sidekiq_files",
+		"CC_HTTP_BASIC_AUTH":         "http_basic_auth",
+		"CC_NGINX_PROXY_BUFFERS":     "nginx_proxy_buffers",
+		"CC_NGINX_PROXY_BUFFER_SIZE": "nginx_proxy_buffer_size",
+		"ENABLE_GZIP_COMPRESSION":    "enable_gzip_compression",
+		"GZIP_TYPES":                 "gzip_types",
+		"NGINX_READ_TIMEOUT":         "nginx_read_timeout",
+		"RACK_ENV":                   "rack_env",
+		"RAILS_ENV":                  "rails_env",
+		"STATIC_FILES_PATH":          "static_files_path",
+		"STATIC_URL_PREFIX":          "static_url_prefix",
+		"STATIC_WEBROOT":             "static_webroot",
+	}
+}
+
+func (r *ResourceRuby) GetVariantSlug() string {
+	return "ruby"
+}
+
+type VariablesV0 struct {
+	RubyVersion           types.String `tfsdk:"ruby_version"`
+	EnableSidekiq         types.Bool   `tfsdk:"enable_sidekiq"`
+	RackupServer          types.String `tfsdk:"rackup_server"`
+	RakeGoals             types.String `tfsdk:"rake_goals"`
+	SidekiqFiles          types.String `tfsdk:"sidekiq_files"`
+	HTTPBasicAuth         types.String `tfsdk:"http_basic_auth"`
+	NginxProxyBuffers     types.String `tfsdk:"nginx_proxy_buffers"`
+	NginxProxyBufferSize  types.String `tfsdk:"nginx_proxy_buffer_size"`
+	EnableGzipCompression types.Bool   `tfsdk:"enable_gzip_compression"`
+	GzipTypes             types.String `tfsdk:"gzip_types"`
+	NginxReadTimeout      types.Int64  `tfsdk:"nginx_read_timeout"`
+	RackEnv               types.String `tfsdk:"rack_env"`
+	RailsEnv              types.String `tfsdk:"rails_env"`
+	StaticFilesPath       types.String `tfsdk:"static_files_path"`
+	StaticURLPrefix       types.String `tfsdk:"static_url_prefix"`
+	StaticWebroot         types.String `tfsdk:"static_webroot"`
+}
+
+type stateV0 struct {
+	application.RuntimeV0
+	VariablesV0
+}
+
+var schemaV0 = schema.Schema{
+	Version:             0,
+	MarkdownDescription: rubyDoc,
+	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
+		"ruby_version": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Ruby version to use (e.g., '3.3', '3.3.1')",
+		},
+		"enable_sidekiq": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Enable Sidekiq background process",
+		},
+		"rackup_server": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Server to use for serving the Ruby application (default: puma)",
+		},
+		"rake_goals": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Comma-separated list of rake goals to execute (e.g., 'db:migrate,assets:precompile')",
+		},
+		"sidekiq_files": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Specify a list of Sidekiq configuration files (e.g., './config/sidekiq_1.yml,./config/sidekiq_2.yml')",
+		},
+		"http_basic_auth": schema.StringAttribute{
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "Restrict HTTP access to your application (format: 'login:password')",
+		},
+		"nginx_proxy_buffers": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Sets the number and size of the buffers used for reading a response from the proxied server",
+		},
+		"nginx_proxy_buffer_size": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Sets the size of the buffer used for reading the first part of the response received from the proxied server",
+		},
+		"enable_gzip_compression": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Set to true to gzip-compress through Nginx",
+		},
+		"gzip_types": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Set the mime types to compress (default: 'text/* application/json application/xml application/javascript image/svg+xml')",
+		},
+		"nginx_read_timeout": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Read timeout in seconds (default: 300)",
+		},
+		"rack_env": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Rack environment variable",
+		},
+		"rails_env": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Rails environment variable",
+		},
+		"static_files_path": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Relative path to where your static files are stored",
+		},
+		"static_url_prefix": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The URL path under which you want to serve static files, usually /public",
+		},
+		"static_webroot": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Path to the web content to serve, relative to the root of your application",
+		},
+	}),
+	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+}
+
+// UpgradeState implements state migration from version 0 to 1 for vhosts attribute
+func (r *ResourceRuby) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, res *resource.UpgradeStateResponse) {
+				tflog.Info(ctx, "Upgrading Ruby resource state from version 0 to 1")
+
+				old := helper.StateFrom[stateV0](ctx, *req.State, &res.Diagnostics)
+				if res.Diagnostics.HasError() {
+					return
+				}
+
+				newState := Ruby{
+					Runtime: application.UpgradeRuntimeV0(ctx, old.RuntimeV0, &res.Diagnostics),
+					Variables: Variables{
+						RubyVersion:           old.RubyVersion,
+						EnableSidekiq:         old.EnableSidekiq,
+						RackupServer:          old.RackupServer,
+						RakeGoals:             old.RakeGoals,
+						SidekiqFiles:          old.SidekiqFiles,
+						HTTPBasicAuth:         old.HTTPBasicAuth,
+						NginxProxyBuffers:     old.NginxProxyBuffers,
+						NginxProxyBufferSize:  old.NginxProxyBufferSize,
+						EnableGzipCompression: old.EnableGzipCompression,
+						GzipTypes:             old.GzipTypes,
+						NginxReadTimeout:      old.NginxReadTimeout,
+						RackEnv:               old.RackEnv,
+						RailsEnv:              old.RailsEnv,
+						StaticFilesPath:       old.StaticFilesPath,
+						StaticURLPrefix:       old.StaticURLPrefix,
+						StaticWebroot:         old.StaticWebroot,
+					},
+				}
+
+				res.Diagnostics.Append(res.State.Set(ctx, newState)...)
+			},
+		},
+	}
+}
@@ -7,9 +7,12 @@ import (
 
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/attributes"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/resources"
 )
 
 // VHost represents a virtual host configuration
@@ -59,12 +62,18 @@ type Runtime struct {
 	Deployment       *attributes.Deployment   `tfsdk:"deployment"`
 	Hooks            *attributes.Hooks        `tfsdk:"hooks"`
 	Integrations     *attributes.Integrations `tfsdk:"integrations"`
+	Workers          types.List               `tfsdk:"workers"`
+	HealthCheck      *attributes.HealthCheck  `tfsdk:"health_check"`
 	Redirection      *TCPRedirection          `tfsdk:"redirection"`
 
 	// Env
 	AppFolder          types.String `tfsdk:"app_folder"`
 	Environment        types.Map    `tfsdk:"environment"`
 	ExposedEnvironment types.Map    `tfsdk:"exposed_environment"`
+	attributes.EnvironmentFiles
+
+	// provider age identity, to decrypt the SOPS file when the plan could not
+	sopsAgeKey string
 }
 
 // RuntimeV0 represents the schema v0 of Runtime (for state upgrades)
@@ -122,6 +131,33 @@ func (r Runtime) VHostsAsStrings(ctx context.Context, diags *diag.Diagnostics) [
 	return items
 }
 
+// CustomEnvironment returns the user defined variables: environment files merged with the environment map
+func (r Runtime) CustomEnvironment(ctx context.Context, diags *diag.Diagnostics) map[string]string {
+	return r.EnvironmentFiles.MergeEnvironment(ctx, r.sopsAgeKey, r.Environment, diags)
+}
+
+// CustomEnvironmentPath returns the attribute defining a custom variable:
+// the inline environment, the SOPS file or the plain environment files
+func (r Runtime) CustomEnvironmentPath(key string) path.Path {
+	if !r.Environment.IsNull() && !r.Environment.IsUnknown() {
+		if _, isInline := r.Environment.Elements()[key]; isInline {
+			return path.Root("environment").AtMapKey(key)
+		}
+	}
+	if !r.FromSops.IsNull() && !r.FromSops.IsUnknown() {
+		if _, isSops := r.FromSops.Elements()[key]; isSops {
+			return path.Root("environment_sops_file")
+		}
+	}
+
+	return path.Root("environment_files")
+}
+
+// SetSopsAgeKey sets the provider age identity used by CustomEnvironment
+func (r *Runtime) SetSopsAgeKey(ageKey string) {
+	r.sopsAgeKey = ageKey
+}
+
 // GetRuntimePtr returns a pointer to the Runtime struct for modification
 func (r *Runtime) GetRuntimePtr() *Runtime {
 	return r
@@ -154,3 +190,37 @@ func (r *Runtime) ToDeployment(gitAuth *http.BasicAuth) *Deployment {
 
 	return d
 }
+
+// UpgradeRuntimeV0 converts the common attributes of a schema v0 state,
+// attributes introduced since then are set to null
+func UpgradeRuntimeV0(ctx context.Context, old RuntimeV0, diags *diag.Diagnostics) Runtime {
+	oldVhosts := []string{}
+	diags.Append(old.VHosts.ElementsAs(ctx, &oldVhosts, false)...)
+	vhosts := helper.VHostsFromAPIHosts(ctx, oldVhosts, old.VHosts, diags)
+
+	return Runtime{
+		ID:                 old.ID,
+		Name:               old.Name,
+		Description:        old.Description,
+		MinInstanceCount:   old.MinInstanceCount,
+		MaxInstanceCount:   old.MaxInstanceCount,
+		SmallestFlavor:     old.SmallestFlavor,
+		BiggestFlavor:      old.BiggestFlavor,
+		BuildFlavor:        old.BuildFlavor,
+		Region:             old.Region,
+		StickySessions:     old.StickySessions,
+		RedirectHTTPS:      old.RedirectHTTPS,
+		VHosts:             vhosts,
+		DeployURL:          old.DeployURL,
+		Dependencies:       old.Dependencies,
+		Deployment:         old.Deployment,
+		Hooks:              old.Hooks,
+		Integrations:       nil,
+		AppFolder:          old.AppFolder,
+		Environment:        old.Environment,
+		Networkgroups:      resources.NullNetworkgroupConfig,
+		ExposedEnvironment: NullExposedEnv,
+		EnvironmentFiles:   attributes.NullEnvironmentFiles,
+		Workers:            attributes.NullWorkers,
+	}
+}
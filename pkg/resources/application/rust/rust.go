@@ -18,9 +18,3 @@ func NewResourceRust() resource.Resource {
 func (r *ResourceRust) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
 	res.TypeName = req.ProviderTypeName + "_rust"
 }
-
-const CC_RUST_FEATURES = "CC_RUST_FEATURES"
-
-func (r *ResourceRust) GetVariantSlug() string {
-	return "rust"
-}
@@ -3,7 +3,6 @@ package rust
 import (
 	"context"
 	_ "embed"
-	"strings"
 
 	"go.clever-cloud.com/terraform-provider/pkg/attributes"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/application"
@@ -11,20 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type Rust struct {
 	application.Runtime
-	Features types.Set `tfsdk:"features"`
-}
-
-func (r Rust) FeaturesAsStrings(ctx context.Context, diags *diag.Diagnostics) []string {
-	features := []string{}
-	diags.Append(r.Features.ElementsAs(ctx, &features, true)...)
-	return features
+	Variables
 }
 
 //go:embed doc.md
@@ -34,49 +26,33 @@ func (r ResourceRust) Schema(ctx context.Context, req resource.SchemaRequest, re
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: rustDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			// https://doc.rust-lang.org/cargo/reference/features.html#command-line-feature-options
-			// Multiple features may be separated with commas or spaces.
-			// If using spaces, be sure to use quotes around all the features if running Cargo from a shell (such as --features "foo bar").
-			// If building multiple packages in a workspace, the package-name/feature-name syntax can be used to specify features for specific workspace members.
-			"features": schema.SetAttribute{
-				ElementType:         types.StringType,
-				Optional:            true,
-				MarkdownDescription: "List of Rust features to enable during build",
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (r Rust) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(r.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := r.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
 	pkg.IfIsSetStr(r.AppFolder, func(s string) { env["APP_FOLDER"] = s })
+	r.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, r.Hooks.ToEnv())
 	env = pkg.Merge(env, r.Integrations.ToEnv(ctx, diags))
-
-	// Handle Rust features
-	features := r.FeaturesAsStrings(ctx, diags)
-	if len(features) > 0 {
-		env[CC_RUST_FEATURES] = strings.Join(features, ",")
-	}
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, r.Workers, diags))
+	env = pkg.Merge(env, r.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (r *Rust) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	r.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
-	r.Features = pkg.FromSetSplit(env.PopPtr(CC_RUST_FEATURES), ",", diags)
+	r.Variables.FromEnv(ctx, env, diags)
 
 	r.Integrations = attributes.FromEnvIntegrations(ctx, env, r.Integrations, diags)
 }
@@ -18,12 +18,7 @@ import (
 
 type Scala struct {
 	application.Runtime
-	// Scala related
-}
-
-type ScalaV0 struct {
-	application.RuntimeV0
-	// Scala related
+	Variables
 }
 
 //go:embed doc.md
@@ -36,37 +31,31 @@ func (r ResourceScala) Schema(ctx context.Context, req resource.SchemaRequest, r
 var schemaScala = schema.Schema{
 	Version:             1,
 	MarkdownDescription: scalaDoc,
-	Attributes:          application.WithRuntimeCommons(map[string]schema.Attribute{}),
-	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaScalaV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: scalaDoc,
-	Attributes:          application.WithRuntimeCommonsV0(map[string]schema.Attribute{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
 	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (plan *Scala) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(plan.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := plan.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	maps.Copy(env, customEnv)
 
 	pkg.IfIsSetStr(plan.AppFolder, func(s string) { env["APP_FOLDER"] = s })
+	plan.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, plan.Hooks.ToEnv())
 	env = pkg.Merge(env, plan.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, plan.Workers, diags))
+	env = pkg.Merge(env, plan.HealthCheck.ToEnv(ctx, diags))
 	return env
 }
 
 func (scala *Scala) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
 	scala.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
+	scala.Variables.FromEnv(ctx, env, diags)
 
 	scala.Integrations = attributes.FromEnvIntegrations(ctx, env, scala.Integrations, diags)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/attributes"
@@ -176,6 +177,8 @@ var runtimeCommon = map[string]schema.Attribute{
 	},
 	"networkgroups": resources.NetworkgroupsAttribute,
 	"integrations":  attributes.IntegrationsAttribute,
+	"workers":       attributes.WorkersAttribute,
+	"health_check":  attributes.HealthCheckAttribute,
 	"redirection": schema.SingleNestedAttribute{
 		Optional:            true,
 		MarkdownDescription: "Expose the application local port 4040 on an external TCP port ([TCP redirections](https://www.clever.cloud/developers/doc/administrate/tcp-redirections/))",
@@ -303,7 +306,7 @@ var runtimeCommonV0 = map[string]schema.Attribute{
 
 // WithRuntimeCommons merges runtime-specific schema attributes with common ones
 func WithRuntimeCommons(runtimeSpecifics map[string]schema.Attribute) map[string]schema.Attribute {
-	return pkg.Merge(runtimeCommon, runtimeSpecifics)
+	return pkg.Merge(pkg.Merge(runtimeCommon, attributes.EnvironmentFilesAttributes), runtimeSpecifics)
 }
 
 // WithRuntimeCommonsV0 merges runtime-specific schema attributes with common V0 ones
@@ -357,3 +360,19 @@ func ValidateRuntimeFlavors(ctx context.Context, r provider.Provider, variantSlu
 		}
 	}
 }
+
+// ModifyPlanCommons resolves plan values shared by all runtimes
+func ModifyPlanCommons(ctx context.Context, r provider.Provider, runtime *Runtime, plan *tfsdk.Plan, diags *diag.Diagnostics) {
+	runtime.SetSopsAgeKey(r.SopsAgeKey())
+	runtime.EnvironmentFiles.ModifyPlan(ctx, r.SopsAgeKey(), runtime.Environment, plan, diags)
+
+	// best effort: values not known yet are checked again on the next plan
+	custom := map[string]string{}
+	if !runtime.Environment.IsUnknown() {
+		probeDiags := diag.Diagnostics{}
+		if env := runtime.CustomEnvironment(ctx, &probeDiags); !probeDiags.HasError() {
+			custom = env
+		}
+	}
+	attributes.ValidateWorkers(ctx, runtime.Workers, runtime.HealthCheck, custom, runtime.CustomEnvironmentPath, diags)
+}
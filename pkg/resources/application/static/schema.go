@@ -16,12 +16,7 @@ import (
 
 type Static struct {
 	application.Runtime
-	// Static related
-}
-
-type StaticV0 struct {
-	application.RuntimeV0
-	// Static related
+	Variables
 }
 
 //go:embed doc.md
@@ -34,24 +29,14 @@ func (r ResourceStatic) Schema(ctx context.Context, req resource.SchemaRequest,
 var schemaStatic = schema.Schema{
 	Version:             1,
 	MarkdownDescription: staticDoc,
-	Attributes:          application.WithRuntimeCommons(map[string]schema.Attribute{}),
-	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
-}
-
-var schemaStaticV0 = schema.Schema{
-	Version:             0,
-	MarkdownDescription: staticDoc,
-	Attributes:          application.WithRuntimeCommonsV0(map[string]schema.Attribute{}),
+	Attributes:          application.WithRuntimeCommons(variablesAttributes),
 	Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 }
 
 func (plan *Static) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(plan.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := plan.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
@@ -60,13 +45,17 @@ func (plan *Static) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[stri
 	}
 
 	pkg.IfIsSetStr(plan.AppFolder, func(s string) { env["APP_FOLDER"] = s })
+	plan.Variables.ToEnv(ctx, env, diags)
 	env = pkg.Merge(env, plan.Hooks.ToEnv())
 	env = pkg.Merge(env, plan.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, plan.Workers, diags))
+	env = pkg.Merge(env, plan.HealthCheck.ToEnv(ctx, diags))
 	return env
 }
 
 func (s *Static) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
 	s.AppFolder = pkg.FromStrPtr(env.PopPtr("APP_FOLDER"))
+	s.Variables.FromEnv(ctx, env, diags)
 
 	s.Integrations = attributes.FromEnvIntegrations(ctx, env, s.Integrations, diags)
 }
@@ -109,4 +109,6 @@ func (r *ResourceStaticApache) ModifyPlan(ctx context.Context, req resource.Modi
 	}
 
 	application.ValidateRuntimeFlavors(ctx, r, "static-apache", plan.Runtime, &res.Diagnostics)
+	application.ModifyPlanCommons(ctx, r, &plan.Runtime, &res.Plan, &res.Diagnostics)
+	application.ValidateEnvironmentConflicts(ctx, &plan, &res.Diagnostics)
 }
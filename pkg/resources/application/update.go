@@ -102,6 +102,7 @@ func Update[T RuntimePlan](ctx context.Context, resource RuntimeResource, plan,
 	}
 
 	// Extract environments from plan and state
+	plan.GetRuntimePtr().SetSopsAgeKey(resource.SopsAgeKey())
 	planEnvironment := plan.ToEnv(ctx, &diags)
 	if diags.HasError() {
 		return diags
@@ -10,16 +10,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
 )
 
 type V struct {
 	application.Runtime
-	Binary           types.String `tfsdk:"binary"`
-	DevelopmentBuild types.Bool   `tfsdk:"development_build"`
+	Variables
 }
 
 //go:embed doc.md
@@ -29,50 +26,32 @@ func (r ResourceV) Schema(ctx context.Context, req resource.SchemaRequest, res *
 	res.Schema = schema.Schema{
 		Version:             1,
 		MarkdownDescription: vDoc,
-		Attributes: application.WithRuntimeCommons(map[string]schema.Attribute{
-			"binary": schema.StringAttribute{
-				Optional:            true,
-				MarkdownDescription: "The name of the output binary file. Default: `${APP_HOME}/v_bin_${APP_ID}`",
-			},
-			"development_build": schema.BoolAttribute{
-				Optional:            true,
-				Computed:            true,
-				Default:             booldefault.StaticBool(false),
-				MarkdownDescription: "Set to true to compile without the `-prod` flag.",
-			},
-		}),
-		Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+		Attributes:          application.WithRuntimeCommons(variablesAttributes),
+		Blocks:              attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
 	}
 }
 
 func (vapp V) ToEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
 	env := map[string]string{}
 
-	// do not use the real map since ElementAs can nullish it
-	// https://github.com/hashicorp/terraform-plugin-framework/issues/698
-	customEnv := map[string]string{}
-	diags.Append(vapp.Environment.ElementsAs(ctx, &customEnv, false)...)
+	customEnv := vapp.CustomEnvironment(ctx, diags)
 	if diags.HasError() {
 		return env
 	}
 	env = pkg.Merge(env, customEnv)
 
-	pkg.IfIsSetStr(vapp.Binary, func(s string) { env["CC_V_BINARY"] = s })
-	pkg.IfIsSetB(vapp.DevelopmentBuild, func(devBuild bool) {
-		if devBuild {
-			env["ENVIRONMENT"] = "development"
-		}
-	})
+	vapp.Variables.ToEnv(ctx, env, diags)
 
 	env = pkg.Merge(env, vapp.Hooks.ToEnv())
 	env = pkg.Merge(env, vapp.Integrations.ToEnv(ctx, diags))
+	env = pkg.Merge(env, attributes.WorkersToEnv(ctx, vapp.Workers, diags))
+	env = pkg.Merge(env, vapp.HealthCheck.ToEnv(ctx, diags))
 
 	return env
 }
 
 func (vapp *V) FromEnv(ctx context.Context, env *maps.Map[string, string], diags *diag.Diagnostics) {
-	vapp.Binary = pkg.FromStrPtr(env.PopPtr("CC_V_BINARY"))
-	pkg.SetBoolIf(&vapp.DevelopmentBuild, env.PopPtr("ENVIRONMENT"), "development")
+	vapp.Variables.FromEnv(ctx, env, diags)
 
 	vapp.Integrations = attributes.FromEnvIntegrations(ctx, env, vapp.Integrations, diags)
 }
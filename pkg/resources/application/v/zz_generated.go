@@ -0,0 +1,61 @@
+// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package v
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+// Variables holds the typed environment variables of the v runtime
+type Variables struct {
+	Binary           types.String `tfsdk:"binary"`
+	DevelopmentBuild types.Bool   `tfsdk:"development_build"`
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+	"binary": schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "The name of the output binary file. Default: `${APP_HOME}/v_bin_${APP_ID}`",
+	},
+	"development_build": schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Set to true to compile without the `-prod` flag.",
+		Default:             booldefault.StaticBool(false),
+	},
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+	pkg.IfIsSetStr(v.Binary, func(s string) { env["CC_V_BINARY"] = s })
+	pkg.IfIsSetB(v.DevelopmentBuild, func(b bool) {
+		if b {
+			env["ENVIRONMENT"] = "development"
+		}
+	})
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+	v.Binary = pkg.FromStrPtr(env.PopPtr("CC_V_BINARY"))
+	pkg.SetBoolIf(&v.DevelopmentBuild, env.PopPtr("ENVIRONMENT"), "development")
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+		"CC_V_BINARY": "binary",
+		"ENVIRONMENT": "development_build",
+	}
+}
+
+func (r *ResourceV) GetVariantSlug() string {
+	return "v"
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
@@ -53,7 +52,7 @@ func (r *ResourceConfigProvider) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	envVars := addonConfigProvider.toEnv(ctx, &res.Diagnostics)
+	envVars := addonConfigProvider.toEnv(ctx, r.SopsAgeKey(), &res.Diagnostics)
 	if res.Diagnostics.HasError() {
 		return
 	}
@@ -140,7 +139,7 @@ func (r *ResourceConfigProvider) Update(ctx context.Context, req resource.Update
 	state.Name = pkg.FromStr(addonRes.Payload().Name)
 
 	tflog.Debug(ctx, "Updating environment variables")
-	envVars := plan.toEnv(ctx, &resp.Diagnostics)
+	envVars := plan.toEnv(ctx, r.SopsAgeKey(), &resp.Diagnostics)
 
 	// Always initialize an empty slice, even if there are no environment variables
 	envVarsArray := []tmp.EnvVar{}
@@ -161,12 +160,12 @@ func (r *ResourceConfigProvider) Update(ctx context.Context, req resource.Update
 		acc[envVar.Name] = envVar.Value
 		return acc
 	})
-	m, d := types.MapValueFrom(ctx, types.StringType, envVarsFromAPI)
-	resp.Diagnostics.Append(d...)
+	state.Environment = plan.Environment
+	state.EnvironmentFiles = plan.EnvironmentFiles
+	state.fromEnv(ctx, envVarsFromAPI, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.Environment = m
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -192,3 +191,16 @@ func (r *ResourceConfigProvider) Delete(ctx context.Context, req resource.Delete
 
 	resp.State.RemoveResource(ctx)
 }
+
+func (r *ResourceConfigProvider) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	plan := helper.PlanFrom[ConfigProvider](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.EnvironmentFiles.ModifyPlan(ctx, r.SopsAgeKey(), plan.Environment, &res.Plan, &res.Diagnostics)
+}
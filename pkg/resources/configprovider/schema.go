@@ -3,6 +3,7 @@ package configprovider
 import (
 	"context"
 	_ "embed"
+	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -11,13 +12,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	helperMaps "github.com/miton18/helper/maps"
 	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/attributes"
 )
 
 type ConfigProvider struct {
 	ID          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Environment types.Map    `tfsdk:"environment"`
+	attributes.EnvironmentFiles
 }
 
 //go:embed doc.md
@@ -27,9 +31,9 @@ func (r ResourceConfigProvider) Schema(_ context.Context, req resource.SchemaReq
 	resp.Schema = schema.Schema{
 		Version:             0,
 		MarkdownDescription: resourceConfigProviderDoc,
-		Attributes: map[string]schema.Attribute{
+		Attributes: pkg.Merge(map[string]schema.Attribute{
 			"environment": schema.MapAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				Description: "Environment variables injected into the application",
 				ElementType: types.StringType,
@@ -37,18 +41,24 @@ func (r ResourceConfigProvider) Schema(_ context.Context, req resource.SchemaReq
 			},
 			"id":   schema.StringAttribute{Computed: true, MarkdownDescription: "Generated unique identifier", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 			"name": schema.StringAttribute{Required: true, MarkdownDescription: "Name of the service"},
-		},
+		}, attributes.EnvironmentFilesAttributes),
 	}
 }
 
-func (appCp ConfigProvider) toEnv(ctx context.Context, diags *diag.Diagnostics) map[string]string {
-	env := map[string]string{}
-	diags.Append(appCp.Environment.ElementsAs(ctx, &env, false)...)
-	return env
+func (appCp ConfigProvider) toEnv(ctx context.Context, ageKey string, diags *diag.Diagnostics) map[string]string {
+	return appCp.MergeEnvironment(ctx, ageKey, appCp.Environment, diags)
 }
 
+// fromEnv splits the add-on variables between environment files and the environment map
 func (appCp *ConfigProvider) fromEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
-	m, d := types.MapValueFrom(ctx, types.StringType, env)
+	remaining := helperMaps.NewMap(env)
+	appCp.ReadEnvironment(ctx, remaining, appCp.Environment, diags)
+
+	if remaining.Size() == 0 && appCp.Environment.IsNull() {
+		return
+	}
+
+	m, d := types.MapValueFrom(ctx, types.StringType, maps.Collect(remaining.All))
 	diags.Append(d...)
 	if diags.HasError() {
 		return
@@ -8,8 +8,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/minio/minio-go/v7"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 	"go.clever-cloud.com/terraform-provider/pkg/s3"
@@ -56,6 +58,77 @@ func TestAccCellarBucket_basic(t *testing.T) {
 	})
 }
 
+func TestAccCellarBucket_configuration(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	cellarName := acctest.RandomWithPrefix("tf-test-cellar")
+	rName := acctest.RandomWithPrefix("tf-test-uploads")
+	fullName := "clevercloud_cellar_bucket." + rName
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+
+	cellarBlock := helper.NewRessource(
+		"clevercloud_cellar",
+		"cellar_configuration",
+		helper.SetKeyValues(map[string]any{"name": cellarName}),
+	)
+
+	cellarBucketBlock := helper.NewRessource(
+		"clevercloud_cellar_bucket",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"id":          rName,
+			"cellar_id":   "${clevercloud_cellar.cellar_configuration.id}",
+			"public_read": true,
+		}),
+		helper.SetBlockValues("versioning", map[string]any{"enabled": true}),
+	).AddNestedBlocks("cors_rule", []helper.Block{
+		helper.NewBlock(map[string]any{
+			"allowed_origins": []string{"https://app.example.com"},
+			"allowed_methods": []string{"GET", "PUT"},
+			"max_age_seconds": 3600,
+		}, nil),
+	}).AddNestedBlocks("lifecycle_rule", []helper.Block{
+		helper.NewBlock(map[string]any{
+			"id":                                     "uploads",
+			"prefix":                                 "tmp/",
+			"expiration_days":                        7,
+			"abort_incomplete_multipart_upload_days": 1,
+		}, nil),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 tests.ExpectOrganisation(t),
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: fullName,
+			Config:       providerBlock.Append(cellarBlock, cellarBucketBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("public_read"), knownvalue.Bool(true)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("versioning").AtMapKey("enabled"), knownvalue.Bool(true)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("cors_rule").AtSliceIndex(0).AtMapKey("allowed_methods"), knownvalue.ListExact([]knownvalue.Check{
+					knownvalue.StringExact("GET"),
+					knownvalue.StringExact("PUT"),
+				})),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("lifecycle_rule").AtSliceIndex(0).AtMapKey("enabled"), knownvalue.Bool(true)),
+			},
+		}, {
+			// removed settings are removed from the bucket
+			ResourceName: fullName,
+			Config: providerBlock.Append(
+				cellarBlock,
+				cellarBucketBlock.
+					UnsetOneValue("public_read").
+					AddNestedBlocks("lifecycle_rule", []helper.Block{}),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("public_read"), knownvalue.Null()),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("lifecycle_rule"), knownvalue.ListSizeExact(0)),
+			},
+		}},
+	})
+}
+
 // TestAccCellarBucket_deleteNonEmpty tests the scenario described in issue #295
 // where a bucket with objects cannot be deleted
 func TestAccCellarBucket_deleteNonEmpty(t *testing.T) {
@@ -0,0 +1,310 @@
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/cors"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/s3"
+)
+
+// PublicReadPolicy returns the canned policy allowing anyone to read the bucket objects
+func PublicReadPolicy(bucket string) string {
+	return fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"PublicRead","Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucket)
+}
+
+// manages tells if a bucket setting is configured, the others are left untouched
+func (bucket *CellarBucket) manages() bool {
+	return !bucket.Policy.IsNull() ||
+		!bucket.PublicRead.IsNull() ||
+		len(bucket.CORSRules) > 0 ||
+		len(bucket.LifecycleRules) > 0 ||
+		bucket.Versioning != nil ||
+		bucket.Website != nil
+}
+
+// policyDocument returns the expected bucket policy, empty when there is none
+func (bucket *CellarBucket) policyDocument() string {
+	if bucket.PublicRead.ValueBool() {
+		return PublicReadPolicy(bucket.Name.ValueString())
+	}
+	return bucket.Policy.ValueString()
+}
+
+// applyConfiguration sets the bucket settings which changed since prior, nil on creation
+func applyConfiguration(ctx context.Context, minioClient *minio.Client, creds *s3.CellarCreds, plan, prior *CellarBucket, diags *diag.Diagnostics) {
+	name := plan.Name.ValueString()
+	if prior == nil {
+		prior = &CellarBucket{Name: plan.Name}
+	}
+
+	// versioning first, noncurrent version expiration needs it
+	if enabled, wasEnabled := plan.Versioning != nil && plan.Versioning.Enabled.ValueBool(), prior.Versioning != nil && prior.Versioning.Enabled.ValueBool(); enabled != wasEnabled {
+		var err error
+		if enabled {
+			err = minioClient.EnableVersioning(ctx, name)
+		} else {
+			err = minioClient.SuspendVersioning(ctx, name)
+		}
+		if err != nil {
+			diags.AddError("failed to set bucket versioning", err.Error())
+			return
+		}
+	}
+
+	if corsConfig, priorCorsConfig := corsConfigFrom(plan.CORSRules), corsConfigFrom(prior.CORSRules); !reflect.DeepEqual(corsConfig, priorCorsConfig) {
+		if err := minioClient.SetBucketCors(ctx, name, corsConfig); err != nil {
+			diags.AddError("failed to set bucket CORS rules", err.Error())
+			return
+		}
+	}
+
+	if lifecycleConfig, priorLifecycleConfig := lifecycleConfigFrom(plan.LifecycleRules), lifecycleConfigFrom(prior.LifecycleRules); !reflect.DeepEqual(lifecycleConfig, priorLifecycleConfig) {
+		if err := minioClient.SetBucketLifecycle(ctx, name, lifecycleConfig); err != nil {
+			diags.AddError("failed to set bucket lifecycle rules", err.Error())
+			return
+		}
+	}
+
+	if policy, priorPolicy := plan.policyDocument(), prior.policyDocument(); policy != priorPolicy {
+		if err := minioClient.SetBucketPolicy(ctx, name, policy); err != nil {
+			diags.AddError("failed to set bucket policy", err.Error())
+			return
+		}
+	}
+
+	if website, priorWebsite := websiteConfigFrom(plan.Website), websiteConfigFrom(prior.Website); !reflect.DeepEqual(website, priorWebsite) {
+		var err error
+		if website == nil {
+			err = creds.DeleteBucketWebsite(ctx, name)
+		} else {
+			err = creds.SetBucketWebsite(ctx, name, website)
+		}
+		if err != nil {
+			diags.AddError("failed to set bucket website", err.Error())
+			return
+		}
+	}
+}
+
+// readConfiguration refreshes the configured bucket settings
+func readConfiguration(ctx context.Context, minioClient *minio.Client, creds *s3.CellarCreds, bucket *CellarBucket, diags *diag.Diagnostics) {
+	name := bucket.Name.ValueString()
+
+	if bucket.Versioning != nil {
+		versioning, err := minioClient.GetBucketVersioning(ctx, name)
+		if err != nil {
+			diags.AddError("failed to read bucket versioning", err.Error())
+			return
+		}
+		bucket.Versioning.Enabled = types.BoolValue(versioning.Enabled())
+	}
+
+	if len(bucket.CORSRules) > 0 {
+		corsConfig, err := minioClient.GetBucketCors(ctx, name)
+		if err != nil {
+			diags.AddError("failed to read bucket CORS rules", err.Error())
+			return
+		}
+		bucket.CORSRules = corsRulesFrom(corsConfig)
+	}
+
+	if len(bucket.LifecycleRules) > 0 {
+		lifecycleConfig, err := minioClient.GetBucketLifecycle(ctx, name)
+		if err != nil && minio.ToErrorResponse(err).Code != "NoSuchLifecycleConfiguration" {
+			diags.AddError("failed to read bucket lifecycle rules", err.Error())
+			return
+		}
+		bucket.LifecycleRules = lifecycleRulesFrom(lifecycleConfig)
+	}
+
+	if !bucket.Policy.IsNull() || !bucket.PublicRead.IsNull() {
+		policy, err := minioClient.GetBucketPolicy(ctx, name)
+		if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucketPolicy" {
+			diags.AddError("failed to read bucket policy", err.Error())
+			return
+		}
+
+		if !bucket.PublicRead.IsNull() {
+			bucket.PublicRead = types.BoolValue(samePolicy(policy, PublicReadPolicy(name)))
+		}
+		// keep the configured formatting when the policy did not change
+		if !bucket.Policy.IsNull() && !samePolicy(policy, bucket.Policy.ValueString()) {
+			bucket.Policy = pkg.FromStr(policy)
+		}
+	}
+
+	if bucket.Website != nil {
+		website, err := creds.GetBucketWebsite(ctx, name)
+		if err != nil {
+			diags.AddError("failed to read bucket website", err.Error())
+			return
+		}
+		bucket.Website = websiteFrom(website)
+	}
+}
+
+func corsConfigFrom(rules []CORSRule) *cors.Config {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	config := &cors.Config{CORSRules: make([]cors.Rule, len(rules))}
+	for i, rule := range rules {
+		config.CORSRules[i] = cors.Rule{
+			AllowedOrigin: stringsFrom(rule.AllowedOrigins),
+			AllowedMethod: stringsFrom(rule.AllowedMethods),
+			AllowedHeader: stringsFrom(rule.AllowedHeaders),
+			ExposeHeader:  stringsFrom(rule.ExposeHeaders),
+			MaxAgeSeconds: int(rule.MaxAgeSeconds.ValueInt64()),
+		}
+	}
+	return config
+}
+
+func corsRulesFrom(config *cors.Config) []CORSRule {
+	rules := []CORSRule{}
+	if config == nil {
+		return rules
+	}
+
+	for _, rule := range config.CORSRules {
+		corsRule := CORSRule{
+			AllowedOrigins: valuesFrom(rule.AllowedOrigin),
+			AllowedMethods: valuesFrom(rule.AllowedMethod),
+			AllowedHeaders: valuesFrom(rule.AllowedHeader),
+			ExposeHeaders:  valuesFrom(rule.ExposeHeader),
+		}
+		if rule.MaxAgeSeconds > 0 {
+			corsRule.MaxAgeSeconds = types.Int64Value(int64(rule.MaxAgeSeconds))
+		}
+		rules = append(rules, corsRule)
+	}
+	return rules
+}
+
+func lifecycleConfigFrom(rules []LifecycleRule) *lifecycle.Configuration {
+	config := lifecycle.NewConfiguration()
+	for _, rule := range rules {
+		status := "Disabled"
+		if rule.Enabled.ValueBool() {
+			status = "Enabled"
+		}
+
+		lifecycleRule := lifecycle.Rule{
+			ID:         rule.ID.ValueString(),
+			Status:     status,
+			RuleFilter: lifecycle.Filter{Prefix: rule.Prefix.ValueString()},
+		}
+		lifecycleRule.Expiration.Days = lifecycle.ExpirationDays(rule.ExpirationDays.ValueInt64())
+		lifecycleRule.NoncurrentVersionExpiration.NoncurrentDays = lifecycle.ExpirationDays(rule.NoncurrentVersionExpirationDays.ValueInt64())
+		lifecycleRule.AbortIncompleteMultipartUpload.DaysAfterInitiation = lifecycle.ExpirationDays(rule.AbortIncompleteMultipartUploadDays.ValueInt64())
+
+		config.Rules = append(config.Rules, lifecycleRule)
+	}
+	return config
+}
+
+func lifecycleRulesFrom(config *lifecycle.Configuration) []LifecycleRule {
+	rules := []LifecycleRule{}
+	if config == nil {
+		return rules
+	}
+
+	for _, rule := range config.Rules {
+		prefix := rule.RuleFilter.Prefix
+		if prefix == "" {
+			prefix = rule.Prefix
+		}
+
+		rules = append(rules, LifecycleRule{
+			ID:                                 types.StringValue(rule.ID),
+			Enabled:                            types.BoolValue(rule.Status == "Enabled"),
+			Prefix:                             pkg.FromStr(prefix),
+			ExpirationDays:                     daysFrom(rule.Expiration.Days),
+			NoncurrentVersionExpirationDays:    daysFrom(rule.NoncurrentVersionExpiration.NoncurrentDays),
+			AbortIncompleteMultipartUploadDays: daysFrom(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation),
+		})
+	}
+	return rules
+}
+
+func websiteConfigFrom(website *Website) *s3.WebsiteConfiguration {
+	if website == nil {
+		return nil
+	}
+
+	config := &s3.WebsiteConfiguration{
+		IndexDocument: &s3.IndexDocument{Suffix: website.IndexDocument.ValueString()},
+	}
+	if !website.ErrorDocument.IsNull() {
+		config.ErrorDocument = &s3.ErrorDocument{Key: website.ErrorDocument.ValueString()}
+	}
+	return config
+}
+
+func websiteFrom(config *s3.WebsiteConfiguration) *Website {
+	if config == nil {
+		return nil
+	}
+
+	website := &Website{}
+	if config.IndexDocument != nil {
+		website.IndexDocument = pkg.FromStr(config.IndexDocument.Suffix)
+	}
+	if config.ErrorDocument != nil {
+		website.ErrorDocument = pkg.FromStr(config.ErrorDocument.Key)
+	}
+	return website
+}
+
+// samePolicy compares 2 policies as JSON documents
+func samePolicy(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var aDoc, bDoc any
+	if json.Unmarshal([]byte(a), &aDoc) != nil || json.Unmarshal([]byte(b), &bDoc) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aDoc, bDoc)
+}
+
+func stringsFrom(values []types.String) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = value.ValueString()
+	}
+	return strs
+}
+
+func valuesFrom(strs []string) []types.String {
+	if len(strs) == 0 {
+		return nil
+	}
+
+	values := make([]types.String, len(strs))
+	for i, str := range strs {
+		values[i] = types.StringValue(str)
+	}
+	return values
+}
+
+func daysFrom(days lifecycle.ExpirationDays) types.Int64 {
+	if days == 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(int64(days))
+}
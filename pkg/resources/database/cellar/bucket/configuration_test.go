@@ -0,0 +1,83 @@
+package bucket
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCORSRules(t *testing.T) {
+	rules := []CORSRule{{
+		AllowedOrigins: []types.String{types.StringValue("https://app.example.com")},
+		AllowedMethods: []types.String{types.StringValue("GET"), types.StringValue("PUT")},
+		ExposeHeaders:  []types.String{types.StringValue("ETag")},
+		MaxAgeSeconds:  types.Int64Value(3600),
+	}}
+
+	config := corsConfigFrom(rules)
+	if len(config.CORSRules) != 1 || config.CORSRules[0].MaxAgeSeconds != 3600 {
+		t.Fatalf("unexpected CORS configuration %+v", config)
+	}
+	if got := corsRulesFrom(config); !reflect.DeepEqual(got, rules) {
+		t.Errorf("expect %+v, got %+v", rules, got)
+	}
+
+	if config := corsConfigFrom(nil); config != nil {
+		t.Errorf("expect no CORS configuration, got %+v", config)
+	}
+	if got := corsRulesFrom(nil); got == nil || len(got) != 0 {
+		t.Errorf("expect no CORS rules, got %+v", got)
+	}
+}
+
+func TestLifecycleRules(t *testing.T) {
+	rules := []LifecycleRule{{
+		ID:                                 types.StringValue("tmp"),
+		Enabled:                            types.BoolValue(true),
+		Prefix:                             types.StringValue("tmp/"),
+		ExpirationDays:                     types.Int64Value(7),
+		NoncurrentVersionExpirationDays:    types.Int64Null(),
+		AbortIncompleteMultipartUploadDays: types.Int64Value(1),
+	}, {
+		ID:                                 types.StringValue("versions"),
+		Enabled:                            types.BoolValue(false),
+		Prefix:                             types.StringNull(),
+		ExpirationDays:                     types.Int64Null(),
+		NoncurrentVersionExpirationDays:    types.Int64Value(30),
+		AbortIncompleteMultipartUploadDays: types.Int64Null(),
+	}}
+
+	config := lifecycleConfigFrom(rules)
+	if config.Rules[0].Status != "Enabled" || config.Rules[1].Status != "Disabled" {
+		t.Fatalf("unexpected lifecycle statuses %+v", config.Rules)
+	}
+	if got := lifecycleRulesFrom(config); !reflect.DeepEqual(got, rules) {
+		t.Errorf("expect %+v, got %+v", rules, got)
+	}
+
+	if !lifecycleConfigFrom(nil).Empty() {
+		t.Errorf("expect an empty lifecycle configuration")
+	}
+}
+
+func TestSamePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"identical", PublicReadPolicy("assets"), PublicReadPolicy("assets"), true},
+		{"formatting", `{"Version": "2012-10-17", "Statement": []}`, `{"Statement":[],"Version":"2012-10-17"}`, true},
+		{"other bucket", PublicReadPolicy("assets"), PublicReadPolicy("uploads"), false},
+		{"no policy", "", PublicReadPolicy("assets"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := samePolicy(tt.a, tt.b); got != tt.expected {
+				t.Errorf("expect %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
@@ -2,14 +2,13 @@ package bucket
 
 import (
 	"context"
-	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	minio "github.com/minio/minio-go/v7"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 	"go.clever-cloud.com/terraform-provider/pkg/s3"
-	"go.clever-cloud.com/terraform-provider/pkg/tmp"
 )
 
 // Create a new resource
@@ -19,21 +18,21 @@ func (r *ResourceCellarBucket) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	cellarEnvRes := tmp.GetAddonEnv(ctx, r.Client(), r.Organization(), bucket.CellarID.ValueString())
-	if cellarEnvRes.HasError() {
-		resp.Diagnostics.AddError(fmt.Sprintf("create: failed to get cellar env %s", bucket.CellarID.String()), cellarEnvRes.Error().Error())
+	minioClient, creds := r.connect(ctx, &bucket, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	minioClient, err := s3.MinioClientFromEnvsFor(*cellarEnvRes.Payload())
+	err := minioClient.MakeBucket(ctx, bucket.Name.ValueString(), minio.MakeBucketOptions{})
 	if err != nil {
-		resp.Diagnostics.AddError("failed to setup S3 client", err.Error())
+		resp.Diagnostics.AddError("failed to create bucket", err.Error())
 		return
 	}
+	// the bucket exists, even if a setting fails
+	resp.Diagnostics.Append(resp.State.Set(ctx, CellarBucket{Name: bucket.Name, CellarID: bucket.CellarID})...)
 
-	err = minioClient.MakeBucket(ctx, bucket.Name.ValueString(), minio.MakeBucketOptions{})
-	if err != nil {
-		resp.Diagnostics.AddError("failed to create bucket", err.Error())
+	applyConfiguration(ctx, minioClient, creds, &bucket, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -52,7 +51,18 @@ func (r *ResourceCellarBucket) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	// nothing to update yet
+	// only the configured settings are refreshed
+	if cellar.manages() {
+		minioClient, creds := r.connect(ctx, &cellar, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		readConfiguration(ctx, minioClient, creds, &cellar, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, cellar)...)
 }
@@ -86,8 +96,19 @@ func (r *ResourceCellarBucket) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	// No updateable attributes in current schema, just maintain current state
-	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	if plan.manages() || state.manages() {
+		minioClient, creds := r.connect(ctx, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		applyConfiguration(ctx, minioClient, creds, &plan, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 }
 
 // Delete resource
@@ -97,15 +118,8 @@ func (r *ResourceCellarBucket) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	cellarEnvRes := tmp.GetAddonEnv(ctx, r.Client(), r.Organization(), bucket.CellarID.ValueString())
-	if cellarEnvRes.HasError() {
-		resp.Diagnostics.AddError("delete: failed to get cellar env", cellarEnvRes.Error().Error())
-		return
-	}
-
-	minioClient, err := s3.MinioClientFromEnvsFor(*cellarEnvRes.Payload())
-	if err != nil {
-		resp.Diagnostics.AddError("failed to setup S3 client", err.Error())
+	minioClient, _ := r.connect(ctx, &bucket, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -135,7 +149,7 @@ func (r *ResourceCellarBucket) Delete(ctx context.Context, req resource.DeleteRe
 	}
 	tflog.Debug(ctx, "all object has been deleted")
 
-	err = minioClient.RemoveBucket(ctx, bucket.Name.ValueString())
+	err := minioClient.RemoveBucket(ctx, bucket.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("failed to delete bucket", err.Error())
 		return
@@ -143,3 +157,13 @@ func (r *ResourceCellarBucket) Delete(ctx context.Context, req resource.DeleteRe
 
 	resp.State.RemoveResource(ctx)
 }
+
+func (r *ResourceCellarBucket) connect(ctx context.Context, bucket *CellarBucket, diags *diag.Diagnostics) (*minio.Client, *s3.CellarCreds) {
+	minioClient, creds, err := s3.Connect(ctx, r.Client(), r.Organization(), bucket.CellarID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Cellar addon", err.Error())
+		return nil, nil
+	}
+
+	return minioClient, creds
+}
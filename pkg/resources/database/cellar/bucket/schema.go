@@ -3,20 +3,65 @@ package bucket
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
 )
 
 type CellarBucket struct {
 	// Should be name, but ID is mandatory for now
 	// https://github.com/hashicorp/terraform-plugin-testing/issues/84
 	// TODO: Name instead of ID when issue is resolved
-	Name     types.String `tfsdk:"id"`
-	CellarID types.String `tfsdk:"cellar_id"`
+	Name           types.String    `tfsdk:"id"`
+	CellarID       types.String    `tfsdk:"cellar_id"`
+	Policy         types.String    `tfsdk:"policy"`
+	PublicRead     types.Bool      `tfsdk:"public_read"`
+	CORSRules      []CORSRule      `tfsdk:"cors_rule"`
+	LifecycleRules []LifecycleRule `tfsdk:"lifecycle_rule"`
+	Versioning     *Versioning     `tfsdk:"versioning"`
+	Website        *Website        `tfsdk:"website"`
 }
 
+type CORSRule struct {
+	AllowedOrigins []types.String `tfsdk:"allowed_origins"`
+	AllowedMethods []types.String `tfsdk:"allowed_methods"`
+	AllowedHeaders []types.String `tfsdk:"allowed_headers"`
+	ExposeHeaders  []types.String `tfsdk:"expose_headers"`
+	MaxAgeSeconds  types.Int64    `tfsdk:"max_age_seconds"`
+}
+
+type LifecycleRule struct {
+	ID                                 types.String `tfsdk:"id"`
+	Enabled                            types.Bool   `tfsdk:"enabled"`
+	Prefix                             types.String `tfsdk:"prefix"`
+	ExpirationDays                     types.Int64  `tfsdk:"expiration_days"`
+	NoncurrentVersionExpirationDays    types.Int64  `tfsdk:"noncurrent_version_expiration_days"`
+	AbortIncompleteMultipartUploadDays types.Int64  `tfsdk:"abort_incomplete_multipart_upload_days"`
+}
+
+type Versioning struct {
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+type Website struct {
+	IndexDocument types.String `tfsdk:"index_document"`
+	ErrorDocument types.String `tfsdk:"error_document"`
+}
+
+// CORSMethods allowed by S3
+var CORSMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
 //go:embed doc.md
 var resourceCellarBucketDoc string
 
@@ -28,6 +73,144 @@ func (r ResourceCellarBucket) Schema(_ context.Context, req resource.SchemaReque
 			// customer provided
 			"id":        schema.StringAttribute{Required: true, MarkdownDescription: "Name of the bucket"},
 			"cellar_id": schema.StringAttribute{Required: true, MarkdownDescription: "Cellar's reference"},
+			"policy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Bucket policy, as a JSON document (`jsonencode({ Version = \"2012-10-17\", Statement = [...] })`)",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("public_read")),
+				},
+			},
+			"public_read": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Allow anonymous read of all the objects, shortcut for the canned public-read policy",
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"cors_rule": schema.ListNestedBlock{
+				MarkdownDescription: "Cross-origin requests allowed on the bucket objects",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"allowed_origins": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							MarkdownDescription: "Origins allowed to send requests, `*` for any",
+							Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
+						},
+						"allowed_methods": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							MarkdownDescription: fmt.Sprintf("HTTP methods allowed (%s)", strings.Join(CORSMethods, ", ")),
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueStringsAre(stringvalidator.OneOf(CORSMethods...)),
+							},
+						},
+						"allowed_headers": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Request headers allowed in a preflight request",
+						},
+						"expose_headers": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Response headers the browser can read",
+						},
+						"max_age_seconds": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Time a browser can cache the preflight response",
+							Validators:          []validator.Int64{int64validator.AtLeast(0)},
+						},
+					},
+				},
+			},
+			"lifecycle_rule": schema.ListNestedBlock{
+				MarkdownDescription: "Expiration of the bucket objects",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Unique identifier of the rule",
+							Validators:          []validator.String{stringvalidator.LengthBetween(1, 255)},
+						},
+						"enabled": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Apply the rule",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Apply the rule to the objects starting with this prefix only",
+						},
+						"expiration_days": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Delete the objects this number of days after their creation",
+							Validators:          []validator.Int64{int64validator.AtLeast(1)},
+						},
+						"noncurrent_version_expiration_days": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Delete the previous versions of the objects this number of days after they became noncurrent",
+							Validators:          []validator.Int64{int64validator.AtLeast(1)},
+						},
+						"abort_incomplete_multipart_upload_days": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Abort the multipart uploads not completed this number of days after they started",
+							Validators:          []validator.Int64{int64validator.AtLeast(1)},
+						},
+					},
+				},
+			},
+			"versioning": schema.SingleNestedBlock{
+				MarkdownDescription: "Keep the previous versions of the objects",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Enable versioning, disabling it suspends the versioning of new objects",
+					},
+				},
+			},
+			"website": schema.SingleNestedBlock{
+				MarkdownDescription: "Serve the bucket objects as a static website",
+				Attributes: map[string]schema.Attribute{
+					"index_document": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Object returned for requests on a directory, as `index.html`",
+					},
+					"error_document": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Object returned on 4XX errors",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ResourceCellarBucket) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	bucket := helper.ConfigFrom[CellarBucket](ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !bucket.Policy.IsNull() && !bucket.Policy.IsUnknown() && !json.Valid([]byte(bucket.Policy.ValueString())) {
+		resp.Diagnostics.AddAttributeError(path.Root("policy"), "invalid policy", "policy must be a JSON document")
+	}
+
+	for i, rule := range bucket.LifecycleRules {
+		if rule.ExpirationDays.IsNull() && rule.NoncurrentVersionExpirationDays.IsNull() && rule.AbortIncompleteMultipartUploadDays.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("lifecycle_rule").AtListIndex(i),
+				"missing lifecycle action",
+				"set at least one of expiration_days, noncurrent_version_expiration_days or abort_incomplete_multipart_upload_days",
+			)
+		}
+	}
+
+	if bucket.Versioning != nil && bucket.Versioning.Enabled.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("versioning").AtName("enabled"), "missing versioning state", "enabled is required in the versioning block")
+	}
+
+	if bucket.Website != nil && bucket.Website.IndexDocument.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("website").AtName("index_document"), "missing index document", "index_document is required in the website block")
 	}
 }
@@ -0,0 +1,242 @@
+package object
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	minio "github.com/minio/minio-go/v7"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/s3"
+)
+
+// ModifyPlan uploads the object again when the local content does not match the etag
+func (r *ResourceCellarObject) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	plan := helper.PlanFrom[Object](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Object](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() || plan.Source.IsUnknown() || plan.Content.IsUnknown() {
+		return
+	}
+
+	etag, err := localETag(&plan)
+	if err != nil {
+		res.Diagnostics.AddAttributeError(path.Root("source"), "failed to read source", err.Error())
+		return
+	}
+
+	// on a change, the etag is set by the upload
+	if etag == state.ETag.ValueString() {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("etag"), state.ETag)...)
+	} else {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("etag"), types.StringUnknown())...)
+	}
+}
+
+// Create a new resource
+func (r *ResourceCellarObject) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Object](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	minioClient := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	upload(ctx, minioClient, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.Bucket.ValueString() + "/" + plan.Key.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceCellarObject) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Object](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	minioClient := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := minioClient.StatObject(ctx, state.Bucket.ValueString(), state.Key.ValueString(), minio.StatObjectOptions{})
+	if code := minio.ToErrorResponse(err).Code; code == minio.NoSuchKey || code == minio.NoSuchBucket {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read object", err.Error())
+		return
+	}
+
+	state.ETag = types.StringValue(info.ETag)
+	state.ContentType = pkg.FromStr(info.ContentType)
+	state.CacheControl = pkg.FromStr(info.Metadata.Get("Cache-Control"))
+	if len(info.UserMetadata) > 0 || !state.Metadata.IsNull() {
+		configured := map[string]string{}
+		res.Diagnostics.Append(state.Metadata.ElementsAs(ctx, &configured, false)...)
+
+		metadata, diags := types.MapValueFrom(ctx, types.StringType, metadataFrom(configured, info.UserMetadata))
+		res.Diagnostics.Append(diags...)
+		state.Metadata = metadata
+	}
+
+	state.ID = types.StringValue(state.Bucket.ValueString() + "/" + state.Key.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, the object is uploaded again
+func (r *ResourceCellarObject) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Object](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Object](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	minioClient := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	upload(ctx, minioClient, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceCellarObject) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Object](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	minioClient := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := minioClient.RemoveObject(ctx, state.Bucket.ValueString(), state.Key.ValueString(), minio.RemoveObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code != minio.NoSuchBucket {
+		res.Diagnostics.AddError("failed to delete object", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceCellarObject) connect(ctx context.Context, object *Object, diags *diag.Diagnostics) *minio.Client {
+	minioClient, _, err := s3.Connect(ctx, r.Client(), r.Organization(), object.CellarID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Cellar addon", err.Error())
+		return nil
+	}
+
+	return minioClient
+}
+
+// upload puts the object in a single request, so its etag is the MD5 of the content
+func upload(ctx context.Context, minioClient *minio.Client, object *Object, diags *diag.Diagnostics) {
+	reader, size, err := contentOf(object)
+	if err != nil {
+		diags.AddAttributeError(path.Root("source"), "failed to read source", err.Error())
+		return
+	}
+	defer func() { _ = reader.Close() }()
+
+	metadata := map[string]string{}
+	if !object.Metadata.IsNull() {
+		diags.Append(object.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if object.ContentType.IsUnknown() {
+		object.ContentType = types.StringValue("application/octet-stream")
+	}
+
+	info, err := minioClient.PutObject(ctx, object.Bucket.ValueString(), object.Key.ValueString(), reader, size, minio.PutObjectOptions{
+		ContentType:      object.ContentType.ValueString(),
+		CacheControl:     object.CacheControl.ValueString(),
+		UserMetadata:     metadata,
+		DisableMultipart: true,
+	})
+	if err != nil {
+		diags.AddError("failed to upload object", err.Error())
+		return
+	}
+
+	object.ETag = types.StringValue(info.ETag)
+}
+
+func contentOf(object *Object) (io.ReadCloser, int64, error) {
+	if object.Source.IsNull() {
+		content := object.Content.ValueString()
+		return io.NopCloser(strings.NewReader(content)), int64(len(content)), nil
+	}
+
+	file, err := os.Open(object.Source.ValueString())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, err
+	}
+
+	return file, stat.Size(), nil
+}
+
+// localETag returns the MD5 of the content to upload
+func localETag(object *Object) (string, error) {
+	reader, _, err := contentOf(object)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = reader.Close() }()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// metadataFrom keeps the configured keys, S3 returns them canonicalized
+func metadataFrom(configured, actual map[string]string) map[string]string {
+	metadata := map[string]string{}
+	for key, value := range actual {
+		name := strings.ToLower(key)
+		for configuredKey := range configured {
+			if strings.EqualFold(configuredKey, key) {
+				name = configuredKey
+			}
+		}
+		metadata[name] = value
+	}
+	return metadata
+}
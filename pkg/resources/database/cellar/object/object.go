@@ -0,0 +1,35 @@
+package object
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceCellarObject struct {
+	helper.Configurer
+}
+
+func NewResourceCellarObject() resource.Resource {
+	return &ResourceCellarObject{}
+}
+
+func (r *ResourceCellarObject) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_cellar_object"
+}
+
+// ImportState expects <cellar_id>/<bucket>/<key>, the key may contain /
+func (r *ResourceCellarObject) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <cellar_id>/<bucket>/<key>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("cellar_id"), parts[0])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("bucket"), parts[1])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("key"), parts[2])...)
+}
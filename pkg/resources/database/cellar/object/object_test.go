@@ -0,0 +1,90 @@
+package object_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccCellarObject_basic(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	cellarName := acctest.RandomWithPrefix("tf-test-cellar")
+	bucketName := acctest.RandomWithPrefix("tf-test-objects")
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+
+	source := filepath.Join(t.TempDir(), "robots.txt")
+	if err := os.WriteFile(source, []byte("User-agent: *\n"), 0o600); err != nil {
+		t.Fatalf("failed to write source file: %s", err)
+	}
+
+	cellarBlock := helper.NewRessource(
+		"clevercloud_cellar",
+		"cellar_objects",
+		helper.SetKeyValues(map[string]any{"name": cellarName}),
+	)
+	bucketBlock := helper.NewRessource(
+		"clevercloud_cellar_bucket",
+		"objects",
+		helper.SetKeyValues(map[string]any{
+			"id":        bucketName,
+			"cellar_id": "${clevercloud_cellar.cellar_objects.id}",
+		}))
+	configBlock := helper.NewRessource(
+		"clevercloud_cellar_object",
+		"config",
+		helper.SetKeyValues(map[string]any{
+			"cellar_id":     "${clevercloud_cellar.cellar_objects.id}",
+			"bucket":        "${clevercloud_cellar_bucket.objects.id}",
+			"key":           "config/app.json",
+			"content":       `{"env":"test"}`,
+			"content_type":  "application/json",
+			"cache_control": "no-cache",
+			"metadata":      map[string]any{"owner": "terraform"},
+		}))
+	robotsBlock := helper.NewRessource(
+		"clevercloud_cellar_object",
+		"robots",
+		helper.SetKeyValues(map[string]any{
+			"cellar_id": "${clevercloud_cellar.cellar_objects.id}",
+			"bucket":    "${clevercloud_cellar_bucket.objects.id}",
+			"key":       "robots.txt",
+			"source":    source,
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: "clevercloud_cellar_object.config",
+			Config:       providerBlock.Append(cellarBlock, bucketBlock, configBlock, robotsBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				// MD5 of the content
+				statecheck.ExpectKnownValue("clevercloud_cellar_object.config", tfjsonpath.New("etag"), knownvalue.StringExact("a76e8bf6e45cdf388a14e0a08bd22905")),
+				statecheck.ExpectKnownValue("clevercloud_cellar_object.config", tfjsonpath.New("metadata").AtMapKey("owner"), knownvalue.StringExact("terraform")),
+				statecheck.ExpectKnownValue("clevercloud_cellar_object.robots", tfjsonpath.New("content_type"), knownvalue.StringExact("application/octet-stream")),
+			},
+		}, {
+			// the changed file is uploaded again
+			ResourceName: "clevercloud_cellar_object.robots",
+			PreConfig: func() {
+				if err := os.WriteFile(source, []byte("User-agent: *\nDisallow: /\n"), 0o600); err != nil {
+					t.Fatalf("failed to write source file: %s", err)
+				}
+			},
+			Config: providerBlock.Append(cellarBlock, bucketBlock, configBlock, robotsBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_cellar_object.robots", tfjsonpath.New("etag"), knownvalue.StringExact("f71d20196d4caf35b6a670db8c70b03d")),
+			},
+		}},
+	})
+}
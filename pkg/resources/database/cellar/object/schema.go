@@ -0,0 +1,91 @@
+package object
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Object struct {
+	ID           types.String `tfsdk:"id"`
+	CellarID     types.String `tfsdk:"cellar_id"`
+	Bucket       types.String `tfsdk:"bucket"`
+	Key          types.String `tfsdk:"key"`
+	Source       types.String `tfsdk:"source"`
+	Content      types.String `tfsdk:"content"`
+	ContentType  types.String `tfsdk:"content_type"`
+	CacheControl types.String `tfsdk:"cache_control"`
+	Metadata     types.Map    `tfsdk:"metadata"`
+	ETag         types.String `tfsdk:"etag"`
+}
+
+//go:embed doc.md
+var resourceCellarObjectDoc string
+
+func (r ResourceCellarObject) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceCellarObjectDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Object identifier: <bucket>/<key>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"cellar_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Cellar addon ID the bucket belongs to",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"bucket": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Bucket name",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Object key, as `config/app.json`",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthBetween(1, 1024)},
+			},
+			"source": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the local file to upload",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source"), path.MatchRoot("content")),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Content of the object, as text",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "MIME type of the object, `application/octet-stream` when not set",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"cache_control": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cache-Control header returned with the object",
+			},
+			"metadata": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "User metadata, returned as `x-amz-meta-*` headers",
+			},
+			"etag": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "MD5 of the object content, a change made outside of Terraform is uploaded again",
+			},
+		},
+	}
+}
@@ -195,26 +195,6 @@ func (r *ResourceElasticsearch) readFromAddon(state *Elasticsearch, addon tmp.Ad
 }
 
 func (r *ResourceElasticsearch) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
-	// TODO
-	// during upgrade, give new plan, new version, new zone
-	// 8 -> 7 = Version changes are not supported
-	// POST https://api.clever-cloud.com/v2/organisations/user_xx/addons/addon_e1b59330-3b1b-4739-b23c-31af436df2e7/migrations
-	// {"planId":"plan_7675a239-057e-448e-85fb-77b5aa2ef47e","region":"par","version":"8"}
-	// {"migrationId":"migration_4f84a9f6-e9bd-4d09-b60d-9210e25795cb","requestDate":"2025-10-29T16:25:52.525017Z","steps":[],"status":"RUNNING"}
-
-	// GET https: //api.clever-cloud.com/v2/organisations/user_xxx/addons/addon_e1b59330-3b1b-4739-b23c-31af436df2e7/migrations/migration_4f84a9f6-e9bd-4d09-b60d-9210e25795cb
-	// {
-	// 	"migrationId":"migration_4f84a9f6-e9bd-4d09-b60d-9210e25795cb",
-	// 	"requestDate":"2025-10-29T16:25:52.525Z",
-	// 	"steps":[
-	// 		{"value":"RETRIEVE_ADDON","status":"OK","startDate":"2025-10-29T16:25:52.553912Z","endDate":"2025-10-29T16:25:52.5939Z"},
-	// 		{"value":"CHECK_NO_MIGRATION_ALREADY_RUNNING_FOR_ADDON","status":"OK","startDate":"2025-10-29T16:25:52.597531Z","endDate":"2025-10-29T16:25:52.616091Z"},
-	// 		{"value":"ASK_MIGRATION_INSTANCE_BOOT","status":"OK","startDate":"2025-10-29T16:25:53.091656Z","endDate":"2025-10-29T16:25:53.165511Z"},
-	// 		{"value":"QUEUE_MIGRATION_INSTANCE_BOOT","status":"OK","startDate":"2025-10-29T16:25:53.168737Z","endDate":"2025-10-29T16:25:53.781705Z"},
-	// 		{"value":"DEPLOY_MIGRATION_INSTANCE","status":"RUNNING","startDate":"2025-10-29T16:25:53.78642Z"}
-	// ],"status":"RUNNING"}
-	// status	"OK"
-
 	plan := helper.PlanFrom[Elasticsearch](ctx, req.Plan, &res.Diagnostics)
 	state := helper.StateFrom[Elasticsearch](ctx, req.State, &res.Diagnostics)
 	if res.Diagnostics.HasError() {
@@ -241,6 +221,39 @@ func (r *ResourceElasticsearch) Update(ctx context.Context, req resource.UpdateR
 	)
 
 	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+
+	// Handle plan or region changes via migration, version changes require a replacement
+	if !plan.Plan.Equal(state.Plan) || !plan.Region.Equal(state.Region) {
+		r.migrate(ctx, plan, &state, &res.Diagnostics)
+		res.Diagnostics.Append(res.State.Set(ctx, state)...)
+	}
+}
+
+func (r *ResourceElasticsearch) migrate(ctx context.Context, plan Elasticsearch, state *Elasticsearch, diags *diag.Diagnostics) {
+	billingPlan := addon.Migrate(ctx, r.Client(), r.Organization(), state.ID.ValueString(), addon.MigrationRequest{
+		ProviderID: "es-addon",
+		Plan:       plan.Plan.ValueString(),
+		Region:     plan.Region.ValueString(),
+		Version:    state.Version.ValueStringPointer(),
+	}, diags)
+	if billingPlan == nil {
+		return
+	}
+
+	state.Plan = pkg.FromStr(billingPlan.Slug)
+	state.Region = plan.Region
+
+	// A migration provisions new instances: connection details change
+	addonID, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), state.ID.ValueString())
+	if err != nil {
+		diags.AddWarning("failed to refresh connection info after migration", err.Error())
+		return
+	}
+	if esRes := tmp.GetElasticsearch(ctx, r.Client(), addonID); esRes.HasError() {
+		diags.AddWarning("failed to refresh connection info after migration", esRes.Error().Error())
+	} else {
+		r.readFromAPI(state, *esRes.Payload(), diags)
+	}
 }
 
 func (r *ResourceElasticsearch) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
@@ -0,0 +1,134 @@
+package ilmpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/elasticsearch"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+// Create a new resource
+func (r *ResourceILMPolicy) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[ILMPolicy](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putPolicy(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ElasticsearchID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceILMPolicy) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[ILMPolicy](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	policies := map[string]struct {
+		Policy json.RawMessage `json:"policy"`
+	}{}
+	err := cluster.Get(ctx, "/_ilm/policy/"+url.PathEscape(state.Name.ValueString()), &policies)
+	if elasticsearch.IsNotFound(err) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read ILM policy", err.Error())
+		return
+	}
+
+	policy, ok := policies[state.Name.ValueString()]
+	if !ok {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	// keep the configured document when Elasticsearch only added defaults
+	if remote := string(policy.Policy); !elasticsearch.SameDocument(state.Policy.ValueString(), remote) {
+		state.Policy = types.StringValue(remote)
+	}
+
+	state.ID = types.StringValue(state.ElasticsearchID.ValueString() + "/" + state.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, the policy is replaced by a new version
+func (r *ResourceILMPolicy) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[ILMPolicy](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[ILMPolicy](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putPolicy(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, Elasticsearch refuses it while indices use the policy
+func (r *ResourceILMPolicy) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[ILMPolicy](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := cluster.Delete(ctx, "/_ilm/policy/"+url.PathEscape(state.Name.ValueString()))
+	if err != nil && !elasticsearch.IsNotFound(err) {
+		res.Diagnostics.AddError("failed to delete ILM policy", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceILMPolicy) connect(ctx context.Context, policy *ILMPolicy, diags *diag.Diagnostics) *elasticsearch.Cluster {
+	cluster, err := elasticsearch.Connect(ctx, r.Client(), r.Organization(), policy.ElasticsearchID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Elasticsearch addon", err.Error())
+		return nil
+	}
+	return cluster
+}
+
+func putPolicy(ctx context.Context, cluster *elasticsearch.Cluster, policy *ILMPolicy, diags *diag.Diagnostics) {
+	body := map[string]json.RawMessage{"policy": json.RawMessage(policy.Policy.ValueString())}
+
+	if err := cluster.Put(ctx, "/_ilm/policy/"+url.PathEscape(policy.Name.ValueString()), body); err != nil {
+		diags.AddError("failed to put ILM policy", err.Error())
+	}
+}
@@ -0,0 +1,34 @@
+package ilmpolicy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceILMPolicy struct {
+	helper.Configurer
+}
+
+func NewResourceILMPolicy() resource.Resource {
+	return &ResourceILMPolicy{}
+}
+
+func (r *ResourceILMPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_elasticsearch_ilm_policy"
+}
+
+// ImportState expects <elasticsearch_id>/<policy name>
+func (r *ResourceILMPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	elasticsearchID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || elasticsearchID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <elasticsearch_id>/<policy name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("elasticsearch_id"), elasticsearchID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,64 @@
+package ilmpolicy_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccElasticsearchILMPolicy_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-es-ilm")
+	fullName := "clevercloud_elasticsearch_ilm_policy.retention"
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	elasticsearchBlock := helper.NewRessource(
+		"clevercloud_elasticsearch",
+		rName,
+		helper.SetKeyValues(map[string]any{"name": rName, "region": "par", "plan": "xs"}),
+	)
+	updatedPolicy := `{"phases": {"delete": {"min_age": "90d", "actions": {"delete": {}}}}}`
+	policyBlock := helper.NewRessource(
+		"clevercloud_elasticsearch_ilm_policy",
+		"retention",
+		helper.SetKeyValues(map[string]any{
+			"elasticsearch_id": fmt.Sprintf("${clevercloud_elasticsearch.%s.id}", rName),
+			"name":             "logs-retention",
+			"policy":           `{"phases": {"hot": {"actions": {"rollover": {"max_age": "1d"}}}, "delete": {"min_age": "30d", "actions": {"delete": {}}}}}`,
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, policyBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("id"), knownvalue.StringRegexp(regexp.MustCompile(`/logs-retention$`))),
+			},
+		}, {
+			// the phases completed by Elasticsearch must not show a diff
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, policyBlock).String(),
+			PlanOnly:     true,
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				elasticsearchBlock,
+				policyBlock.SetOneValue("policy", updatedPolicy),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policy"), knownvalue.StringExact(updatedPolicy)),
+			},
+		}},
+	})
+}
@@ -0,0 +1,77 @@
+package ilmpolicy
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ILMPolicy struct {
+	ID              types.String `tfsdk:"id"`
+	ElasticsearchID types.String `tfsdk:"elasticsearch_id"`
+	Name            types.String `tfsdk:"name"`
+	Policy          types.String `tfsdk:"policy"`
+}
+
+//go:embed doc.md
+var resourceILMPolicyDoc string
+
+func (r ResourceILMPolicy) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceILMPolicyDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Policy identifier: <elasticsearch_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"elasticsearch_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Elasticsearch addon ID the policy is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Policy name, referenced by the `index.lifecycle.name` setting",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"policy": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Policy definition, as a JSON object with the `phases` (`jsonencode({ phases = { ... } })`)",
+			},
+		},
+	}
+}
+
+func (r ResourceILMPolicy) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	policy := ILMPolicy{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &policy)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if policy.Policy.IsNull() || policy.Policy.IsUnknown() {
+		return
+	}
+
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(policy.Policy.ValueString()), &document); err != nil {
+		res.Diagnostics.AddAttributeError(path.Root("policy"), "invalid policy", "policy must be a JSON object")
+		return
+	}
+	if _, ok := document["phases"]; !ok {
+		res.Diagnostics.AddAttributeError(path.Root("policy"), "invalid policy", "policy must define its phases")
+	}
+}
@@ -0,0 +1,183 @@
+package indextemplate
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/elasticsearch"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+// indexTemplate is the body of the index template API
+type indexTemplate struct {
+	IndexPatterns []string        `json:"index_patterns"`
+	Priority      *int64          `json:"priority,omitempty"`
+	ComposedOf    []string        `json:"composed_of,omitempty"`
+	DataStream    *struct{}       `json:"data_stream,omitempty"`
+	Template      json.RawMessage `json:"template,omitempty"`
+}
+
+// Create a new resource
+func (r *ResourceIndexTemplate) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[IndexTemplate](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putTemplate(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ElasticsearchID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceIndexTemplate) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[IndexTemplate](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	templates := struct {
+		IndexTemplates []struct {
+			Name          string        `json:"name"`
+			IndexTemplate indexTemplate `json:"index_template"`
+		} `json:"index_templates"`
+	}{}
+	err := cluster.Get(ctx, "/_index_template/"+url.PathEscape(state.Name.ValueString()), &templates)
+	if elasticsearch.IsNotFound(err) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read index template", err.Error())
+		return
+	}
+	if len(templates.IndexTemplates) == 0 {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	template := templates.IndexTemplates[0].IndexTemplate
+
+	patterns, diags := types.ListValueFrom(ctx, types.StringType, template.IndexPatterns)
+	res.Diagnostics.Append(diags...)
+	state.IndexPatterns = patterns
+
+	state.Priority = types.Int64PointerValue(template.Priority)
+
+	// Elasticsearch answers an empty list when there is no component template
+	if len(template.ComposedOf) > 0 || !state.ComposedOf.IsNull() {
+		composedOf, diags := types.ListValueFrom(ctx, types.StringType, template.ComposedOf)
+		res.Diagnostics.Append(diags...)
+		state.ComposedOf = composedOf
+	}
+
+	state.DataStream = types.BoolValue(template.DataStream != nil)
+
+	// keep the configured document when Elasticsearch only added defaults
+	remote := string(template.Template)
+	if remote == "" {
+		remote = "{}"
+	}
+	if state.Template.IsNull() {
+		if remote != "{}" {
+			state.Template = types.StringValue(remote)
+		}
+	} else if !SameTemplate(state.Template.ValueString(), remote) {
+		state.Template = types.StringValue(remote)
+	}
+
+	state.ID = types.StringValue(state.ElasticsearchID.ValueString() + "/" + state.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, the template applies to the indices created afterwards
+func (r *ResourceIndexTemplate) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[IndexTemplate](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[IndexTemplate](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putTemplate(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, the existing indices are kept
+func (r *ResourceIndexTemplate) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[IndexTemplate](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := cluster.Delete(ctx, "/_index_template/"+url.PathEscape(state.Name.ValueString()))
+	if err != nil && !elasticsearch.IsNotFound(err) {
+		res.Diagnostics.AddError("failed to delete index template", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceIndexTemplate) connect(ctx context.Context, template *IndexTemplate, diags *diag.Diagnostics) *elasticsearch.Cluster {
+	cluster, err := elasticsearch.Connect(ctx, r.Client(), r.Organization(), template.ElasticsearchID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Elasticsearch addon", err.Error())
+		return nil
+	}
+	return cluster
+}
+
+func putTemplate(ctx context.Context, cluster *elasticsearch.Cluster, template *IndexTemplate, diags *diag.Diagnostics) {
+	body := indexTemplate{
+		Priority: template.Priority.ValueInt64Pointer(),
+	}
+	diags.Append(template.IndexPatterns.ElementsAs(ctx, &body.IndexPatterns, false)...)
+	if !template.ComposedOf.IsNull() {
+		diags.Append(template.ComposedOf.ElementsAs(ctx, &body.ComposedOf, false)...)
+	}
+	if diags.HasError() {
+		return
+	}
+	if template.DataStream.ValueBool() {
+		body.DataStream = &struct{}{}
+	}
+	if !template.Template.IsNull() {
+		body.Template = json.RawMessage(template.Template.ValueString())
+	}
+
+	if err := cluster.Put(ctx, "/_index_template/"+url.PathEscape(template.Name.ValueString()), body); err != nil {
+		diags.AddError("failed to put index template", err.Error())
+	}
+}
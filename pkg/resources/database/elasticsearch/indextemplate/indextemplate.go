@@ -0,0 +1,34 @@
+package indextemplate
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceIndexTemplate struct {
+	helper.Configurer
+}
+
+func NewResourceIndexTemplate() resource.Resource {
+	return &ResourceIndexTemplate{}
+}
+
+func (r *ResourceIndexTemplate) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_elasticsearch_index_template"
+}
+
+// ImportState expects <elasticsearch_id>/<template name>
+func (r *ResourceIndexTemplate) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	elasticsearchID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || elasticsearchID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <elasticsearch_id>/<template name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("elasticsearch_id"), elasticsearchID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,72 @@
+package indextemplate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccElasticsearchIndexTemplate_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-es-template")
+	elasticsearchID := fmt.Sprintf("${clevercloud_elasticsearch.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	elasticsearchBlock := helper.NewRessource(
+		"clevercloud_elasticsearch",
+		rName,
+		helper.SetKeyValues(map[string]any{"name": rName, "region": "par", "plan": "xs"}),
+	)
+	policyBlock := helper.NewRessource(
+		"clevercloud_elasticsearch_ilm_policy",
+		"retention",
+		helper.SetKeyValues(map[string]any{
+			"elasticsearch_id": elasticsearchID,
+			"name":             "logs-retention",
+			"policy":           `{"phases": {"delete": {"min_age": "30d", "actions": {"delete": {}}}}}`,
+		}))
+	templateBlock := helper.NewRessource(
+		"clevercloud_elasticsearch_index_template",
+		"logs",
+		helper.SetKeyValues(map[string]any{
+			"elasticsearch_id": elasticsearchID,
+			"name":             "logs",
+			"index_patterns":   []string{"logs-*"},
+			"priority":         100,
+			"template":         `{"settings": {"number_of_shards": 1, "index.lifecycle.name": "logs-retention"}}`,
+		}))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, policyBlock, templateBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_elasticsearch_index_template.logs", tfjsonpath.New("data_stream"), knownvalue.Bool(false)),
+			},
+		}, {
+			// the stored defaults and the settings format must not show a diff
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, policyBlock, templateBlock).String(),
+			PlanOnly:     true,
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				elasticsearchBlock,
+				policyBlock.SetOneValue("policy", `{"phases": {"delete": {"min_age": "90d", "actions": {"delete": {}}}}}`),
+				templateBlock.SetOneValue("priority", 200),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_elasticsearch_index_template.logs", tfjsonpath.New("priority"), knownvalue.Int64Exact(200)),
+			},
+		}},
+	})
+}
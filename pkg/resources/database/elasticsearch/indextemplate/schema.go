@@ -0,0 +1,106 @@
+package indextemplate
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type IndexTemplate struct {
+	ID              types.String `tfsdk:"id"`
+	ElasticsearchID types.String `tfsdk:"elasticsearch_id"`
+	Name            types.String `tfsdk:"name"`
+	IndexPatterns   types.List   `tfsdk:"index_patterns"`
+	Priority        types.Int64  `tfsdk:"priority"`
+	ComposedOf      types.List   `tfsdk:"composed_of"`
+	DataStream      types.Bool   `tfsdk:"data_stream"`
+	Template        types.String `tfsdk:"template"`
+}
+
+//go:embed doc.md
+var resourceIndexTemplateDoc string
+
+func (r ResourceIndexTemplate) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceIndexTemplateDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Template identifier: <elasticsearch_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"elasticsearch_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Elasticsearch addon ID the template is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Template name",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"index_patterns": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Wildcard patterns of the index names the template applies to (`logs-*`)",
+				Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"priority": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The template with the highest priority applies when several templates match an index",
+			},
+			"composed_of": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Component templates merged in this template, in order",
+			},
+			"data_stream": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Create data streams instead of indices for the matching names",
+			},
+			"template": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Settings, mappings and aliases of the created indices, as a JSON object (`jsonencode({ settings = { ... }, mappings = { ... } })`)",
+			},
+		},
+	}
+}
+
+func (r ResourceIndexTemplate) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	template := IndexTemplate{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &template)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if template.Template.IsNull() || template.Template.IsUnknown() {
+		return
+	}
+
+	document := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(template.Template.ValueString()), &document); err != nil {
+		res.Diagnostics.AddAttributeError(path.Root("template"), "invalid template", "template must be a JSON object")
+		return
+	}
+	for key := range document {
+		if key != "settings" && key != "mappings" && key != "aliases" && key != "lifecycle" {
+			res.Diagnostics.AddAttributeError(path.Root("template"), "invalid template", "unexpected field '"+key+"', expect settings, mappings, aliases or lifecycle")
+		}
+	}
+}
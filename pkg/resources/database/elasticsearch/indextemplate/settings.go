@@ -0,0 +1,52 @@
+package indextemplate
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.clever-cloud.com/terraform-provider/pkg/elasticsearch"
+)
+
+// SameTemplate compares the template blocks, see elasticsearch.SameDocument.
+// Elasticsearch answers the settings nested under "index": the settings are compared flattened
+func SameTemplate(configured, remote string) bool {
+	return elasticsearch.SameDocument(normalizeSettings(configured), normalizeSettings(remote))
+}
+
+// normalizeSettings rewrites the settings of a template with flat keys: {"index.number_of_shards": 1}
+func normalizeSettings(template string) string {
+	document := map[string]any{}
+	if json.Unmarshal([]byte(template), &document) != nil {
+		return template
+	}
+
+	settings, ok := document["settings"].(map[string]any)
+	if !ok {
+		return template
+	}
+
+	flat := map[string]any{}
+	flattenSettings("", settings, flat)
+	document["settings"] = flat
+
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return template
+	}
+	return string(normalized)
+}
+
+func flattenSettings(prefix string, settings map[string]any, flat map[string]any) {
+	for key, value := range settings {
+		key = prefix + key
+		if nested, ok := value.(map[string]any); ok {
+			flattenSettings(key+".", nested, flat)
+			continue
+		}
+
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+		flat[key] = value
+	}
+}
@@ -0,0 +1,30 @@
+package indextemplate
+
+import "testing"
+
+func TestSameTemplate(t *testing.T) {
+	remote := `{
+  "settings": {"index": {"number_of_shards": "1", "lifecycle": {"name": "logs-retention"}}},
+  "mappings": {"properties": {"message": {"type": "text"}}}
+}`
+
+	tests := []struct {
+		name       string
+		configured string
+		expected   bool
+	}{
+		{"nested settings", `{"settings": {"index": {"number_of_shards": 1}}}`, true},
+		{"settings without index prefix", `{"settings": {"number_of_shards": 1, "lifecycle.name": "logs-retention"}}`, true},
+		{"flat settings", `{"settings": {"index.lifecycle.name": "logs-retention"}, "mappings": {"properties": {"message": {"type": "text"}}}}`, true},
+		{"changed setting", `{"settings": {"number_of_shards": 2}}`, false},
+		{"changed mapping", `{"mappings": {"properties": {"message": {"type": "keyword"}}}}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SameTemplate(tt.configured, remote); got != tt.expected {
+				t.Errorf("expect %t, got %t", tt.expected, got)
+			}
+		})
+	}
+}
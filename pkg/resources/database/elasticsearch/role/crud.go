@@ -0,0 +1,165 @@
+package role
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/elasticsearch"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+// role is the body of the security role API
+type role struct {
+	Cluster []string      `json:"cluster"`
+	Indices []indicesRule `json:"indices"`
+}
+
+type indicesRule struct {
+	Names      []string `json:"names"`
+	Privileges []string `json:"privileges"`
+}
+
+// Create a new resource
+func (r *ResourceElasticsearchRole) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Role](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putRole(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ElasticsearchID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceElasticsearchRole) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	roles := map[string]role{}
+	err := cluster.Get(ctx, "/_security/role/"+url.PathEscape(state.Name.ValueString()), &roles)
+	if elasticsearch.IsNotFound(err) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read role", err.Error())
+		return
+	}
+
+	remote, ok := roles[state.Name.ValueString()]
+	if !ok {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	if len(remote.Cluster) > 0 || !state.Cluster.IsNull() {
+		clusterPrivileges, diags := types.SetValueFrom(ctx, types.StringType, remote.Cluster)
+		res.Diagnostics.Append(diags...)
+		state.Cluster = clusterPrivileges
+	}
+
+	state.Indices = []IndicesRule{}
+	for _, rule := range remote.Indices {
+		names, diags := types.SetValueFrom(ctx, types.StringType, rule.Names)
+		res.Diagnostics.Append(diags...)
+		privileges, diags := types.SetValueFrom(ctx, types.StringType, rule.Privileges)
+		res.Diagnostics.Append(diags...)
+
+		state.Indices = append(state.Indices, IndicesRule{Names: names, Privileges: privileges})
+	}
+
+	state.ID = types.StringValue(state.ElasticsearchID.ValueString() + "/" + state.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, the role is replaced
+func (r *ResourceElasticsearchRole) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Role](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putRole(ctx, cluster, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, the users keep the name of the role
+func (r *ResourceElasticsearchRole) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := cluster.Delete(ctx, "/_security/role/"+url.PathEscape(state.Name.ValueString()))
+	if err != nil && !elasticsearch.IsNotFound(err) {
+		res.Diagnostics.AddError("failed to delete role", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceElasticsearchRole) connect(ctx context.Context, role *Role, diags *diag.Diagnostics) *elasticsearch.Cluster {
+	cluster, err := elasticsearch.Connect(ctx, r.Client(), r.Organization(), role.ElasticsearchID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Elasticsearch addon", err.Error())
+		return nil
+	}
+	return cluster
+}
+
+func putRole(ctx context.Context, cluster *elasticsearch.Cluster, plan *Role, diags *diag.Diagnostics) {
+	body := role{Cluster: []string{}, Indices: []indicesRule{}}
+	if !plan.Cluster.IsNull() {
+		diags.Append(plan.Cluster.ElementsAs(ctx, &body.Cluster, false)...)
+	}
+	for _, rule := range plan.Indices {
+		indices := indicesRule{}
+		diags.Append(rule.Names.ElementsAs(ctx, &indices.Names, false)...)
+		diags.Append(rule.Privileges.ElementsAs(ctx, &indices.Privileges, false)...)
+		body.Indices = append(body.Indices, indices)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	if err := cluster.Put(ctx, "/_security/role/"+url.PathEscape(plan.Name.ValueString()), body); err != nil {
+		diags.AddError("failed to put role", err.Error())
+	}
+}
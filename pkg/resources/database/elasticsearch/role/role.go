@@ -0,0 +1,34 @@
+package role
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceElasticsearchRole struct {
+	helper.Configurer
+}
+
+func NewResourceElasticsearchRole() resource.Resource {
+	return &ResourceElasticsearchRole{}
+}
+
+func (r *ResourceElasticsearchRole) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_elasticsearch_role"
+}
+
+// ImportState expects <elasticsearch_id>/<role name>
+func (r *ResourceElasticsearchRole) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	elasticsearchID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || elasticsearchID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <elasticsearch_id>/<role name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("elasticsearch_id"), elasticsearchID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,62 @@
+package role_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccElasticsearchRole_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-es-role")
+	fullName := "clevercloud_elasticsearch_role.reader"
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	elasticsearchBlock := helper.NewRessource(
+		"clevercloud_elasticsearch",
+		rName,
+		helper.SetKeyValues(map[string]any{"name": rName, "region": "par", "plan": "xs"}),
+	)
+	newRoleBlock := func(privileges ...string) *helper.Ressource {
+		return helper.NewRessource(
+			"clevercloud_elasticsearch_role",
+			"reader",
+			helper.SetKeyValues(map[string]any{
+				"elasticsearch_id": fmt.Sprintf("${clevercloud_elasticsearch.%s.id}", rName),
+				"name":             "log-reader",
+				"cluster":          []string{"monitor"},
+			})).AddNestedBlocks("indices", []helper.Block{
+			helper.NewBlock(map[string]any{"names": []string{"logs-*"}, "privileges": privileges}, nil),
+		})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, newRoleBlock("read")).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("cluster"), knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("monitor")})),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("indices").AtSliceIndex(0).AtMapKey("privileges"), knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("read")})),
+			},
+		}, {
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, newRoleBlock("read", "view_index_metadata")).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("indices").AtSliceIndex(0).AtMapKey("privileges"), knownvalue.SetExact([]knownvalue.Check{
+					knownvalue.StringExact("read"),
+					knownvalue.StringExact("view_index_metadata"),
+				})),
+			},
+		}},
+	})
+}
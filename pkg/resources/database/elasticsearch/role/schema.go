@@ -0,0 +1,82 @@
+package role
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Role struct {
+	ID              types.String  `tfsdk:"id"`
+	ElasticsearchID types.String  `tfsdk:"elasticsearch_id"`
+	Name            types.String  `tfsdk:"name"`
+	Cluster         types.Set     `tfsdk:"cluster"`
+	Indices         []IndicesRule `tfsdk:"indices"`
+}
+
+type IndicesRule struct {
+	Names      types.Set `tfsdk:"names"`
+	Privileges types.Set `tfsdk:"privileges"`
+}
+
+//go:embed doc.md
+var resourceRoleDoc string
+
+func (r ResourceElasticsearchRole) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceRoleDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role identifier: <elasticsearch_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"elasticsearch_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Elasticsearch addon ID the role is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role name",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthBetween(1, 507)},
+			},
+			"cluster": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Cluster privileges (`monitor`, `manage_ilm`...)",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"indices": schema.ListNestedBlock{
+				MarkdownDescription: "Privileges on indices",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"names": schema.SetAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Index names or wildcard patterns (`logs-*`)",
+							Validators:          []validator.Set{setvalidator.SizeAtLeast(1)},
+						},
+						"privileges": schema.SetAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Index privileges (`read`, `write`, `create_index`...)",
+							Validators:          []validator.Set{setvalidator.SizeAtLeast(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
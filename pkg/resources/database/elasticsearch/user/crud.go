@@ -0,0 +1,168 @@
+package user
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/elasticsearch"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+// user is the body of the security user API, the password is never answered
+type user struct {
+	Password string   `json:"password,omitempty"`
+	Roles    []string `json:"roles"`
+	FullName *string  `json:"full_name"`
+	Email    *string  `json:"email"`
+}
+
+// Create a new resource
+func (r *ResourceElasticsearchUser) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	// write-only values are only available in the configuration
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	putUser(ctx, cluster, &plan, config.PasswordWO.ValueString(), &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.ElasticsearchID.ValueString() + "/" + plan.Username.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information, the password cannot be read
+func (r *ResourceElasticsearchUser) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	users := map[string]user{}
+	err := cluster.Get(ctx, "/_security/user/"+url.PathEscape(state.Username.ValueString()), &users)
+	if elasticsearch.IsNotFound(err) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read user", err.Error())
+		return
+	}
+
+	remote, ok := users[state.Username.ValueString()]
+	if !ok {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	roles, diags := types.SetValueFrom(ctx, types.StringType, remote.Roles)
+	res.Diagnostics.Append(diags...)
+	state.Roles = roles
+	state.FullName = fromOptional(remote.FullName)
+	state.Email = fromOptional(remote.Email)
+
+	state.ID = types.StringValue(state.ElasticsearchID.ValueString() + "/" + state.Username.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourceElasticsearchUser) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the password is only sent when its version changes, Elasticsearch keeps it otherwise
+	password := ""
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		password = config.PasswordWO.ValueString()
+	}
+
+	putUser(ctx, cluster, &plan, password, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceElasticsearchUser) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	cluster := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := cluster.Delete(ctx, "/_security/user/"+url.PathEscape(state.Username.ValueString()))
+	if err != nil && !elasticsearch.IsNotFound(err) {
+		res.Diagnostics.AddError("failed to delete user", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceElasticsearchUser) connect(ctx context.Context, user *User, diags *diag.Diagnostics) *elasticsearch.Cluster {
+	cluster, err := elasticsearch.Connect(ctx, r.Client(), r.Organization(), user.ElasticsearchID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Elasticsearch addon", err.Error())
+		return nil
+	}
+	return cluster
+}
+
+// putUser creates or updates the user, an empty password keeps the current one
+func putUser(ctx context.Context, cluster *elasticsearch.Cluster, plan *User, password string, diags *diag.Diagnostics) {
+	body := user{
+		Password: password,
+		Roles:    []string{},
+		FullName: plan.FullName.ValueStringPointer(),
+		Email:    plan.Email.ValueStringPointer(),
+	}
+	diags.Append(plan.Roles.ElementsAs(ctx, &body.Roles, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	if err := cluster.Put(ctx, "/_security/user/"+url.PathEscape(plan.Username.ValueString()), body); err != nil {
+		diags.AddError("failed to put user", err.Error())
+	}
+}
+
+// fromOptional returns null for an absent or empty value
+func fromOptional(value *string) types.String {
+	if value == nil {
+		return types.StringNull()
+	}
+	return pkg.FromStr(*value)
+}
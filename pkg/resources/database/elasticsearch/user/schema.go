@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type User struct {
+	ID                types.String `tfsdk:"id"`
+	ElasticsearchID   types.String `tfsdk:"elasticsearch_id"`
+	Username          types.String `tfsdk:"username"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Roles             types.Set    `tfsdk:"roles"`
+	FullName          types.String `tfsdk:"full_name"`
+	Email             types.String `tfsdk:"email"`
+}
+
+//go:embed doc.md
+var resourceUserDoc string
+
+func (r ResourceElasticsearchUser) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceUserDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "User identifier: <elasticsearch_id>/<username>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"elasticsearch_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Elasticsearch addon ID the user is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"username": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Login of the user",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthBetween(1, 507)},
+			},
+			"password_wo": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Password of the user, at least 6 characters, never stored in the state (requires Terraform 1.11+)",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(6)},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Change this value to apply a new `password_wo`",
+			},
+			"roles": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Roles of the user, built-in roles or `clevercloud_elasticsearch_role` names",
+			},
+			"full_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Full name of the user",
+			},
+			"email": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Email of the user",
+			},
+		},
+	}
+}
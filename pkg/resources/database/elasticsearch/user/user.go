@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceElasticsearchUser struct {
+	helper.Configurer
+}
+
+func NewResourceElasticsearchUser() resource.Resource {
+	return &ResourceElasticsearchUser{}
+}
+
+func (r *ResourceElasticsearchUser) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_elasticsearch_user"
+}
+
+// ImportState expects <elasticsearch_id>/<username>
+func (r *ResourceElasticsearchUser) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	elasticsearchID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || elasticsearchID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <elasticsearch_id>/<username>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("elasticsearch_id"), elasticsearchID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("username"), name)...)
+}
@@ -0,0 +1,76 @@
+package user_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccElasticsearchUser_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-es-user")
+	fullName := "clevercloud_elasticsearch_user.grafana"
+	elasticsearchID := fmt.Sprintf("${clevercloud_elasticsearch.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	elasticsearchBlock := helper.NewRessource(
+		"clevercloud_elasticsearch",
+		rName,
+		helper.SetKeyValues(map[string]any{"name": rName, "region": "par", "plan": "xs"}),
+	)
+	roleBlock := helper.NewRessource(
+		"clevercloud_elasticsearch_role",
+		"reader",
+		helper.SetKeyValues(map[string]any{
+			"elasticsearch_id": elasticsearchID,
+			"name":             "log-reader",
+		})).AddNestedBlocks("indices", []helper.Block{
+		helper.NewBlock(map[string]any{"names": []string{"logs-*"}, "privileges": []string{"read"}}, nil),
+	})
+	userBlock := helper.NewRessource(
+		"clevercloud_elasticsearch_user",
+		"grafana",
+		helper.SetKeyValues(map[string]any{
+			"elasticsearch_id":    elasticsearchID,
+			"username":            "grafana",
+			"password_wo":         acctest.RandString(16),
+			"password_wo_version": 1,
+			"roles":               []string{"${clevercloud_elasticsearch_role.reader.name}"},
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(elasticsearchBlock, roleBlock, userBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("roles"), knownvalue.SetExact([]knownvalue.Check{knownvalue.StringExact("log-reader")})),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("password_wo"), knownvalue.Null()),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("full_name"), knownvalue.Null()),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				elasticsearchBlock,
+				roleBlock,
+				userBlock.
+					SetOneValue("full_name", "Grafana datasource").
+					SetOneValue("password_wo", acctest.RandString(16)).
+					SetOneValue("password_wo_version", 2),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("full_name"), knownvalue.StringExact("Grafana datasource")),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("password_wo_version"), knownvalue.Int64Exact(2)),
+			},
+		}},
+	})
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
-	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
+	fsbucketftp "go.clever-cloud.com/terraform-provider/pkg/fsbucket"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
 )
@@ -59,15 +59,10 @@ func (r *ResourceFSBucket) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	envVars := envRes.Payload()
-	envMap := pkg.Reduce(*envVars, map[string]types.String{}, func(m map[string]types.String, v tmp.EnvVar) map[string]types.String {
-		m[v.Name] = pkg.FromStr(v.Value)
-		return m
-	})
-
-	fsbucket.Host = envMap["BUCKET_HOST"]
-	fsbucket.FTPUsername = envMap["BUCKET_FTP_USERNAME"]
-	fsbucket.FTPPassword = envMap["BUCKET_FTP_PASSWORD"]
+	creds := fsbucketftp.FromEnvVars(*envRes.Payload())
+	fsbucket.Host = pkg.FromStr(creds.Host)
+	fsbucket.FTPUsername = pkg.FromStr(creds.Username)
+	fsbucket.FTPPassword = pkg.FromStr(creds.Password)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, fsbucket)...)
 }
@@ -102,17 +97,13 @@ func (r *ResourceFSBucket) Read(ctx context.Context, req resource.ReadRequest, r
 		resp.Diagnostics.AddError("failed to get addon env", addonEnvRes.Error().Error())
 		return
 	}
-	addonEnv := addonEnvRes.Payload()
-	addonMap := pkg.Reduce(*addonEnv, map[string]types.String{}, func(m map[string]types.String, v tmp.EnvVar) map[string]types.String {
-		m[v.Name] = pkg.FromStr(v.Value)
-		return m
-	})
+	creds := fsbucketftp.FromEnvVars(*addonEnvRes.Payload())
 
 	fsbucket.Name = pkg.FromStr(addon.Name)
 	fsbucket.Region = pkg.FromStr(addon.Region)
-	fsbucket.Host = addonMap["BUCKET_HOST"]
-	fsbucket.FTPUsername = addonMap["BUCKET_FTP_USERNAME"]
-	fsbucket.FTPPassword = addonMap["BUCKET_FTP_PASSWORD"]
+	fsbucket.Host = pkg.FromStr(creds.Host)
+	fsbucket.FTPUsername = pkg.FromStr(creds.Username)
+	fsbucket.FTPPassword = pkg.FromStr(creds.Password)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, fsbucket)...)
 }
@@ -0,0 +1,35 @@
+package collection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceMongoDBCollection struct {
+	helper.Configurer
+}
+
+func NewResourceMongoDBCollection() resource.Resource {
+	return &ResourceMongoDBCollection{}
+}
+
+func (r *ResourceMongoDBCollection) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_mongodb_collection"
+}
+
+// ImportState expects <mongodb_id>/<collection name>
+func (r *ResourceMongoDBCollection) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	mongodbID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || mongodbID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <mongodb_id>/<collection name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("mongodb_id"), mongodbID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,79 @@
+package collection_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccMongoDBCollection_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-mg-coll")
+	mongodbID := fmt.Sprintf("${clevercloud_mongodb.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	mongodbBlock := helper.NewRessource(
+		"clevercloud_mongodb",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "xs_med",
+		}))
+	collectionBlock := helper.NewRessource(
+		"clevercloud_mongodb_collection",
+		"users",
+		helper.SetKeyValues(map[string]any{
+			"mongodb_id":  mongodbID,
+			"name":        "users",
+			"json_schema": `{"bsonType": "object", "required": ["email"]}`,
+		}))
+	indexBlock := helper.NewRessource(
+		"clevercloud_mongodb_index",
+		"email",
+		helper.SetKeyValues(map[string]any{
+			"mongodb_id":     mongodbID,
+			"collection":     "${clevercloud_mongodb_collection.users.name}",
+			"keys":           []map[string]string{{"field": "email"}},
+			"unique":         true,
+			"partial_filter": `{"status": "active"}`,
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(mongodbBlock, collectionBlock, indexBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mongodb_collection.users", tfjsonpath.New("validation_level"), knownvalue.StringExact("strict")),
+				statecheck.ExpectKnownValue("clevercloud_mongodb_collection.users", tfjsonpath.New("capped"), knownvalue.Bool(false)),
+				statecheck.ExpectKnownValue("clevercloud_mongodb_index.email", tfjsonpath.New("name"), knownvalue.StringExact("email_1")),
+				statecheck.ExpectKnownValue("clevercloud_mongodb_index.email", tfjsonpath.New("keys"), knownvalue.ListExact([]knownvalue.Check{
+					knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"field": knownvalue.StringExact("email"),
+						"type":  knownvalue.StringExact("asc"),
+					}),
+				})),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				mongodbBlock,
+				collectionBlock.SetOneValue("validation_action", "warn"),
+				indexBlock,
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mongodb_collection.users", tfjsonpath.New("validation_action"), knownvalue.StringExact("warn")),
+			},
+		}},
+	})
+}
@@ -0,0 +1,204 @@
+package collection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// specification of a collection, as listed by listCollections
+type specification struct {
+	Options struct {
+		Capped           bool     `bson:"capped"`
+		Validator        bson.Raw `bson:"validator"`
+		ValidationLevel  string   `bson:"validationLevel"`
+		ValidationAction string   `bson:"validationAction"`
+	} `bson:"options"`
+}
+
+// Create a new resource
+func (r *ResourceMongoDBCollection) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Collection](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	validation := validationFrom(&plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	command := bson.D{{Key: "create", Value: plan.Name.ValueString()}}
+	if plan.Capped.ValueBool() {
+		command = append(command,
+			bson.E{Key: "capped", Value: true},
+			bson.E{Key: "size", Value: plan.Size.ValueInt64()},
+		)
+		if !plan.MaxDocuments.IsNull() {
+			command = append(command, bson.E{Key: "max", Value: plan.MaxDocuments.ValueInt64()})
+		}
+	}
+	command = append(command, validation...)
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if err := addon.Database().RunCommand(ctx, command).Err(); err != nil {
+		// 48: NamespaceExists
+		if mongodb.IsCode(err, 48) {
+			res.Diagnostics.AddError("collection already exists", fmt.Sprintf("import it with: terraform import <address> %s/%s", plan.MongoDBID.ValueString(), plan.Name.ValueString()))
+			return
+		}
+		res.Diagnostics.AddError("failed to create collection", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.MongoDBID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceMongoDBCollection) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Collection](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	cursor, err := addon.Database().ListCollections(ctx, bson.D{{Key: "name", Value: state.Name.ValueString()}})
+	if err != nil {
+		res.Diagnostics.AddError("failed to read collection", err.Error())
+		return
+	}
+	specs := []specification{}
+	if err := cursor.All(ctx, &specs); err != nil {
+		res.Diagnostics.AddError("failed to read collection", err.Error())
+		return
+	}
+	if len(specs) == 0 {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	options := specs[0].Options
+
+	// size and max are kept from the state, MongoDB rounds them
+	state.Capped = types.BoolValue(options.Capped)
+	state.ValidationLevel = types.StringValue(valueOr(options.ValidationLevel, "strict"))
+	state.ValidationAction = types.StringValue(valueOr(options.ValidationAction, "error"))
+
+	jsonSchema, ok := options.Validator.Lookup("$jsonSchema").DocumentOK()
+	switch {
+	case !ok:
+		state.JSONSchema = types.StringNull()
+	// keep the configured formatting when the schema did not change
+	case state.JSONSchema.IsNull() || !mongodb.SameDocument(state.JSONSchema.ValueString(), jsonSchema):
+		state.JSONSchema = types.StringValue(mongodb.ExtJSON(jsonSchema))
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, only the validation is updated in place
+func (r *ResourceMongoDBCollection) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Collection](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Collection](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	validation := validationFrom(&plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	// an empty validator removes the previous one
+	if plan.JSONSchema.IsNull() {
+		validation = append(validation, bson.E{Key: "validator", Value: bson.D{}})
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	command := append(bson.D{{Key: "collMod", Value: plan.Name.ValueString()}}, validation...)
+	if err := addon.Database().RunCommand(ctx, command).Err(); err != nil {
+		res.Diagnostics.AddError("failed to update collection validation", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, the documents of the collection are deleted
+func (r *ResourceMongoDBCollection) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Collection](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	err := addon.Database().RunCommand(ctx, bson.D{{Key: "drop", Value: state.Name.ValueString()}}).Err()
+	// 26: NamespaceNotFound
+	if err != nil && !mongodb.IsCode(err, 26) {
+		res.Diagnostics.AddError("failed to drop collection", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceMongoDBCollection) connect(ctx context.Context, collection *Collection, diags *diag.Diagnostics) *mongodb.Addon {
+	addon, err := mongodb.Connect(ctx, r.Client(), r.Organization(), collection.MongoDBID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to MongoDB addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// validationFrom returns the validation options shared by create and collMod
+func validationFrom(collection *Collection, diags *diag.Diagnostics) bson.D {
+	validation := bson.D{
+		{Key: "validationLevel", Value: collection.ValidationLevel.ValueString()},
+		{Key: "validationAction", Value: collection.ValidationAction.ValueString()},
+	}
+
+	if !collection.JSONSchema.IsNull() {
+		jsonSchema, err := mongodb.ParseDocument(collection.JSONSchema.ValueString())
+		if err != nil {
+			diags.AddError("invalid JSON schema", err.Error())
+			return nil
+		}
+		validation = append(validation, bson.E{Key: "validator", Value: bson.D{{Key: "$jsonSchema", Value: jsonSchema}}})
+	}
+
+	return validation
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,129 @@
+package collection
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/mongodb"
+)
+
+type Collection struct {
+	ID               types.String `tfsdk:"id"`
+	MongoDBID        types.String `tfsdk:"mongodb_id"`
+	Name             types.String `tfsdk:"name"`
+	JSONSchema       types.String `tfsdk:"json_schema"`
+	ValidationLevel  types.String `tfsdk:"validation_level"`
+	ValidationAction types.String `tfsdk:"validation_action"`
+	Capped           types.Bool   `tfsdk:"capped"`
+	Size             types.Int64  `tfsdk:"size"`
+	MaxDocuments     types.Int64  `tfsdk:"max_documents"`
+}
+
+// system. collections are reserved, $ is forbidden
+var NameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,119}$`)
+
+//go:embed doc.md
+var resourceMongoDBCollectionDoc string
+
+func (r ResourceMongoDBCollection) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceMongoDBCollectionDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Collection identifier: <mongodb_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"mongodb_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "MongoDB addon ID the collection is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Collection name",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a MongoDB collection name", NameRegex)},
+			},
+			"json_schema": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "JSON schema documents must match, as extended JSON (`jsonencode({ bsonType = \"object\", required = [\"email\"] })`)",
+			},
+			"validation_level": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("strict"),
+				MarkdownDescription: "Documents validated: `strict` (all), `moderate` (only valid existing documents) or `off`",
+				Validators:          []validator.String{stringvalidator.OneOf("strict", "moderate", "off")},
+			},
+			"validation_action": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("error"),
+				MarkdownDescription: "What to do with invalid documents: `error` (reject) or `warn` (log)",
+				Validators:          []validator.String{stringvalidator.OneOf("error", "warn")},
+			},
+			"capped": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Fixed size collection, the oldest documents are overwritten when full",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum size in bytes of a capped collection",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"max_documents": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of documents of a capped collection",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r ResourceMongoDBCollection) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	collection := Collection{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &collection)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if !collection.JSONSchema.IsNull() && !collection.JSONSchema.IsUnknown() {
+		if _, err := mongodb.ParseDocument(collection.JSONSchema.ValueString()); err != nil {
+			res.Diagnostics.AddAttributeError(path.Root("json_schema"), "invalid JSON schema", err.Error())
+		}
+	}
+
+	if collection.Capped.IsUnknown() {
+		return
+	}
+	capped := collection.Capped.ValueBool()
+	if capped && collection.Size.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("size"), "missing size", "size is required on a capped collection")
+	}
+	if !capped && !collection.Size.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("size"), "invalid size", "size can only be set on a capped collection")
+	}
+	if !capped && !collection.MaxDocuments.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("max_documents"), "invalid max_documents", "max_documents can only be set on a capped collection")
+	}
+}
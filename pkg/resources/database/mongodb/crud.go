@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
@@ -196,7 +197,42 @@ func (r *ResourceMongoDB) Update(ctx context.Context, req resource.UpdateRequest
 		&resp.Diagnostics,
 	)
 
+	// only used on deletion
+	state.FinalSnapshot = plan.FinalSnapshot
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	// Handle plan or region changes via migration
+	if !plan.Plan.Equal(state.Plan) || !plan.Region.Equal(state.Region) {
+		r.migrate(ctx, plan, &state, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+func (r *ResourceMongoDB) migrate(ctx context.Context, plan MongoDB, state *MongoDB, diags *diag.Diagnostics) {
+	billingPlan := addon.Migrate(ctx, r.Client(), r.Organization(), state.ID.ValueString(), addon.MigrationRequest{
+		ProviderID: "mongodb-addon",
+		Plan:       plan.Plan.ValueString(),
+		Region:     plan.Region.ValueString(),
+	}, diags)
+	if billingPlan == nil {
+		return
+	}
+
+	state.Plan = pkg.FromStr(billingPlan.Slug)
+	state.Region = plan.Region
+
+	// A migration provisions a new instance: connection details change
+	addonID, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), state.ID.ValueString())
+	if err != nil {
+		diags.AddWarning("failed to refresh connection info after migration", err.Error())
+		return
+	}
+	if mgRes := tmp.GetMongoDB(ctx, r.Client(), addonID); mgRes.HasError() {
+		diags.AddWarning("failed to refresh connection info after migration", mgRes.Error().Error())
+	} else {
+		r.readFromAPI(state, *mgRes.Payload())
+	}
 }
 
 // Delete resource
@@ -208,6 +244,13 @@ func (r *ResourceMongoDB) Delete(ctx context.Context, req resource.DeleteRequest
 
 	tflog.Debug(ctx, "MongoDB DELETE", map[string]any{"mg": mg})
 
+	if mg.FinalSnapshot != nil {
+		mg.FinalSnapshot.Export(ctx, r.Client(), r.Organization(), mg.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	addonId, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), mg.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("failed to get addon ID", err.Error())
@@ -0,0 +1,212 @@
+package index
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// specification of an index, as listed by listIndexes
+type specification struct {
+	Name                    string   `bson:"name"`
+	Key                     bson.D   `bson:"key"`
+	Unique                  bool     `bson:"unique"`
+	ExpireAfterSeconds      *int64   `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+}
+
+// Create a new resource
+func (r *ResourceMongoDBIndex) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Index](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	keys := keysFrom(ctx, plan.Keys, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Name.IsNull() || plan.Name.IsUnknown() {
+		plan.Name = types.StringValue(DefaultName(keys))
+	}
+
+	spec := bson.D{
+		{Key: "key", Value: keysDocument(keys)},
+		{Key: "name", Value: plan.Name.ValueString()},
+	}
+	if plan.Unique.ValueBool() {
+		spec = append(spec, bson.E{Key: "unique", Value: true})
+	}
+	if !plan.ExpireAfterSeconds.IsNull() {
+		spec = append(spec, bson.E{Key: "expireAfterSeconds", Value: plan.ExpireAfterSeconds.ValueInt64()})
+	}
+	if !plan.PartialFilter.IsNull() {
+		filter, err := mongodb.ParseDocument(plan.PartialFilter.ValueString())
+		if err != nil {
+			res.Diagnostics.AddError("invalid partial filter", err.Error())
+			return
+		}
+		spec = append(spec, bson.E{Key: "partialFilterExpression", Value: filter})
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	err := addon.Database().RunCommand(ctx, bson.D{
+		{Key: "createIndexes", Value: plan.Collection.ValueString()},
+		{Key: "indexes", Value: bson.A{spec}},
+	}).Err()
+	if err != nil {
+		// 85: IndexOptionsConflict, 86: IndexKeySpecsConflict
+		if mongodb.IsCode(err, 85, 86) {
+			res.Diagnostics.AddError(
+				"index already exists",
+				fmt.Sprintf("%s\nimport it with: terraform import <address> %s/%s/<index name>", err.Error(), plan.MongoDBID.ValueString(), plan.Collection.ValueString()),
+			)
+			return
+		}
+		res.Diagnostics.AddError("failed to create index", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strings.Join([]string{plan.MongoDBID.ValueString(), plan.Collection.ValueString(), plan.Name.ValueString()}, "/"))
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceMongoDBIndex) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Index](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	cursor, err := addon.Database().Collection(state.Collection.ValueString()).Indexes().List(ctx)
+	// 26: NamespaceNotFound, the collection was dropped
+	if mongodb.IsCode(err, 26) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to list indexes", err.Error())
+		return
+	}
+
+	specs := []specification{}
+	if err := cursor.All(ctx, &specs); err != nil {
+		res.Diagnostics.AddError("failed to list indexes", err.Error())
+		return
+	}
+
+	spec := pkg.First(specs, func(spec specification) bool {
+		return spec.Name == state.Name.ValueString()
+	})
+	if spec == nil {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	// text indexes are stored on internal _fts and _ftsx fields
+	isText := pkg.First(spec.Key, func(e bson.E) bool { return e.Key == "_fts" }) != nil
+	if !isText {
+		keys := pkg.Map(spec.Key, func(e bson.E) IndexKey {
+			key := KeyFrom(e.Key, e.Value)
+			return IndexKey{Field: types.StringValue(key.Field), Type: types.StringValue(key.Type)}
+		})
+		var diags diag.Diagnostics
+		state.Keys, diags = types.ListValueFrom(ctx, KeyType, keys)
+		res.Diagnostics.Append(diags...)
+	}
+
+	state.Unique = types.BoolValue(spec.Unique)
+	state.ExpireAfterSeconds = types.Int64PointerValue(spec.ExpireAfterSeconds)
+	switch {
+	case spec.PartialFilterExpression == nil:
+		state.PartialFilter = types.StringNull()
+	// keep the configured formatting when the filter did not change
+	case state.PartialFilter.IsNull() || !mongodb.SameDocument(state.PartialFilter.ValueString(), spec.PartialFilterExpression):
+		state.PartialFilter = types.StringValue(mongodb.ExtJSON(spec.PartialFilterExpression))
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, every attribute requires a replacement
+func (r *ResourceMongoDBIndex) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Index](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Index](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Name = state.Name
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceMongoDBIndex) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Index](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	err := addon.Database().RunCommand(ctx, bson.D{
+		{Key: "dropIndexes", Value: state.Collection.ValueString()},
+		{Key: "index", Value: state.Name.ValueString()},
+	}).Err()
+	// 26: NamespaceNotFound, 27: IndexNotFound
+	if err != nil && !mongodb.IsCode(err, 26, 27) {
+		res.Diagnostics.AddError("failed to drop index", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceMongoDBIndex) connect(ctx context.Context, index *Index, diags *diag.Diagnostics) *mongodb.Addon {
+	addon, err := mongodb.Connect(ctx, r.Client(), r.Organization(), index.MongoDBID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to MongoDB addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+func keysFrom(ctx context.Context, list types.List, diags *diag.Diagnostics) []Key {
+	keys := []IndexKey{}
+	diags.Append(list.ElementsAs(ctx, &keys, false)...)
+
+	return pkg.Map(keys, func(key IndexKey) Key {
+		return Key{Field: key.Field.ValueString(), Type: key.Type.ValueString()}
+	})
+}
+
+func keysDocument(keys []Key) bson.D {
+	return pkg.Map(keys, func(key Key) bson.E {
+		return bson.E{Key: key.Field, Value: key.Value()}
+	})
+}
@@ -0,0 +1,37 @@
+package index
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceMongoDBIndex struct {
+	helper.Configurer
+}
+
+func NewResourceMongoDBIndex() resource.Resource {
+	return &ResourceMongoDBIndex{}
+}
+
+func (r *ResourceMongoDBIndex) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_mongodb_index"
+}
+
+// ImportState expects <mongodb_id>/<collection>/<index name>
+func (r *ResourceMongoDBIndex) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <mongodb_id>/<collection>/<index name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("mongodb_id"), parts[0])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("collection"), parts[1])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("unique"), false)...)
+}
@@ -0,0 +1,66 @@
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+const (
+	KeyTypeAsc      = "asc"
+	KeyTypeDesc     = "desc"
+	KeyTypeText     = "text"
+	KeyTypeHashed   = "hashed"
+	KeyType2DSphere = "2dsphere"
+)
+
+var KeyTypes = []string{KeyTypeAsc, KeyTypeDesc, KeyTypeText, KeyTypeHashed, KeyType2DSphere}
+
+// Key is an indexed field
+type Key struct {
+	Field string
+	Type  string
+}
+
+// Value is the value of the field in the index key document
+func (k Key) Value() any {
+	switch k.Type {
+	case KeyTypeAsc:
+		return int32(1)
+	case KeyTypeDesc:
+		return int32(-1)
+	default:
+		return k.Type
+	}
+}
+
+// KeyFrom converts a field of an index key document read from the server
+func KeyFrom(field string, value any) Key {
+	switch v := value.(type) {
+	case string:
+		return Key{Field: field, Type: v}
+	case int32:
+		return Key{Field: field, Type: direction(float64(v))}
+	case int64:
+		return Key{Field: field, Type: direction(float64(v))}
+	case float64:
+		return Key{Field: field, Type: direction(v)}
+	default:
+		return Key{Field: field, Type: fmt.Sprint(v)}
+	}
+}
+
+func direction(v float64) string {
+	if v < 0 {
+		return KeyTypeDesc
+	}
+	return KeyTypeAsc
+}
+
+// DefaultName is the name MongoDB drivers give to an index: <field>_<value> joined with underscores
+func DefaultName(keys []Key) string {
+	return strings.Join(pkg.Map(keys, func(key Key) string {
+		return key.Field + "_" + fmt.Sprint(key.Value())
+	}), "_")
+}
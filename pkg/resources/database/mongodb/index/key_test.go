@@ -0,0 +1,45 @@
+package index
+
+import "testing"
+
+func TestDefaultName(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []Key
+		expected string
+	}{
+		{"single field", []Key{{Field: "email", Type: KeyTypeAsc}}, "email_1"},
+		{"compound", []Key{{Field: "user_id", Type: KeyTypeAsc}, {Field: "created_at", Type: KeyTypeDesc}}, "user_id_1_created_at_-1"},
+		{"embedded field", []Key{{Field: "address.location", Type: KeyType2DSphere}}, "address.location_2dsphere"},
+		{"text", []Key{{Field: "body", Type: KeyTypeText}}, "body_text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultName(tt.keys); got != tt.expected {
+				t.Errorf("expect %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestKeyFrom(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"int32 ascending", int32(1), KeyTypeAsc},
+		{"int64 descending", int64(-1), KeyTypeDesc},
+		{"double descending", float64(-1), KeyTypeDesc},
+		{"hashed", "hashed", KeyTypeHashed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyFrom("field", tt.value); got.Type != tt.expected {
+				t.Errorf("expect %s, got %s", tt.expected, got.Type)
+			}
+		})
+	}
+}
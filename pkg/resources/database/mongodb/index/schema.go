@@ -0,0 +1,150 @@
+package index
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/mongodb"
+)
+
+type Index struct {
+	ID                 types.String `tfsdk:"id"`
+	MongoDBID          types.String `tfsdk:"mongodb_id"`
+	Collection         types.String `tfsdk:"collection"`
+	Name               types.String `tfsdk:"name"`
+	Keys               types.List   `tfsdk:"keys"`
+	Unique             types.Bool   `tfsdk:"unique"`
+	ExpireAfterSeconds types.Int64  `tfsdk:"expire_after_seconds"`
+	PartialFilter      types.String `tfsdk:"partial_filter"`
+}
+
+type IndexKey struct {
+	Field types.String `tfsdk:"field"`
+	Type  types.String `tfsdk:"type"`
+}
+
+var KeyType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"field": types.StringType,
+	"type":  types.StringType,
+}}
+
+//go:embed doc.md
+var resourceMongoDBIndexDoc string
+
+func (r ResourceMongoDBIndex) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceMongoDBIndexDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Index identifier: <mongodb_id>/<collection>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"mongodb_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "MongoDB addon ID the index is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"collection": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Collection to index, created when missing",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Index name, defaults to the fields and types joined with underscores (`email_1`)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"keys": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Indexed fields, in order",
+				PlanModifiers:       []planmodifier.List{listplanmodifier.RequiresReplace()},
+				Validators:          []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"field": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Field path, dots reach embedded documents (`address.city`)",
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+						"type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(KeyTypeAsc),
+							MarkdownDescription: "One of `asc`, `desc`, `text`, `hashed` or `2dsphere`",
+							Validators:          []validator.String{stringvalidator.OneOf(KeyTypes...)},
+						},
+					},
+				},
+			},
+			"unique": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Reject documents with an already indexed value",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"expire_after_seconds": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Make a TTL index: documents are deleted this many seconds after the date of the (single) indexed field",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"partial_filter": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only index documents matching this filter, as extended JSON (`jsonencode({ status = \"active\" })`)",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r ResourceMongoDBIndex) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	index := Index{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &index)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if !index.PartialFilter.IsNull() && !index.PartialFilter.IsUnknown() {
+		if _, err := mongodb.ParseDocument(index.PartialFilter.ValueString()); err != nil {
+			res.Diagnostics.AddAttributeError(path.Root("partial_filter"), "invalid partial filter", err.Error())
+		}
+	}
+
+	if index.ExpireAfterSeconds.IsNull() || index.Keys.IsUnknown() {
+		return
+	}
+
+	keys := []IndexKey{}
+	res.Diagnostics.Append(index.Keys.ElementsAs(ctx, &keys, false)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// a missing type defaults to asc
+	if len(keys) != 1 || (keys[0].Type.ValueString() != "" && keys[0].Type.ValueString() != KeyTypeAsc && keys[0].Type.ValueString() != KeyTypeDesc) {
+		res.Diagnostics.AddAttributeError(path.Root("expire_after_seconds"), "invalid TTL index", "a TTL index must have a single asc or desc key on a date field")
+	}
+}
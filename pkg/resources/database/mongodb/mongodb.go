@@ -7,6 +7,8 @@ import (
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
 )
 
+var _ resource.ResourceWithModifyPlan = &ResourceMongoDB{}
+
 type ResourceMongoDB struct {
 	helper.Configurer
 }
@@ -18,3 +20,19 @@ func NewResourceMongoDB() resource.Resource {
 func (r *ResourceMongoDB) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
 	res.TypeName = req.ProviderTypeName + "_mongodb"
 }
+
+// ModifyPlan validates that mongodump is installed when final_snapshot is set
+func (r *ResourceMongoDB) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() { // Skip validation when deleting
+		return
+	}
+
+	plan := helper.From[MongoDB](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.FinalSnapshot != nil {
+		plan.FinalSnapshot.ValidateTool("mongodump", &res.Diagnostics)
+	}
+}
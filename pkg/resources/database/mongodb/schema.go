@@ -22,6 +22,8 @@ type MongoDB struct {
 	Uri            types.String `tfsdk:"uri"`
 	Encryption     types.Bool   `tfsdk:"encryption"`
 	DirectHostOnly types.Bool   `tfsdk:"direct_host_only"`
+
+	FinalSnapshot *addon.FinalSnapshot `tfsdk:"final_snapshot"`
 }
 
 //go:embed doc.md
@@ -51,6 +53,7 @@ func (r ResourceMongoDB) Schema(_ context.Context, req resource.SchemaRequest, r
 				MarkdownDescription: "Connect directly to the database host, bypassing the reverse proxy. Lower latency but no automatic failover on migration.",
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
 			},
+			"final_snapshot": addon.FinalSnapshotAttribute,
 		}),
 	}
 }
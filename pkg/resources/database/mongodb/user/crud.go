@@ -0,0 +1,181 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mongodb"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Create a new resource
+func (r *ResourceMongoDBUser) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	// write-only values are only available in the configuration
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	roles := rolesFrom(ctx, plan.Roles, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// users are authenticated against the addon database
+	err := addon.Database().RunCommand(ctx, bson.D{
+		{Key: "createUser", Value: plan.Name.ValueString()},
+		{Key: "pwd", Value: config.PasswordWO.ValueString()},
+		{Key: "roles", Value: roles},
+	}).Err()
+	if err != nil {
+		// 51003: DuplicateKey on the users collection
+		if mongodb.IsCode(err, 51003) {
+			res.Diagnostics.AddError("user already exists", fmt.Sprintf("import it with: terraform import <address> %s/%s", plan.MongoDBID.ValueString(), plan.Name.ValueString()))
+			return
+		}
+		res.Diagnostics.AddError("failed to create user", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.MongoDBID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceMongoDBUser) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	info := struct {
+		Users []struct {
+			Roles []struct {
+				Role string `bson:"role"`
+				DB   string `bson:"db"`
+			} `bson:"roles"`
+		} `bson:"users"`
+	}{}
+	err := addon.Database().RunCommand(ctx, bson.D{{Key: "usersInfo", Value: state.Name.ValueString()}}).Decode(&info)
+	if err != nil {
+		res.Diagnostics.AddError("failed to read user", err.Error())
+		return
+	}
+	if len(info.Users) == 0 {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	roles := []Role{}
+	for _, role := range info.Users[0].Roles {
+		roles = append(roles, Role{Role: types.StringValue(role.Role), Database: types.StringValue(role.DB)})
+	}
+
+	var diags diag.Diagnostics
+	state.Roles, diags = types.SetValueFrom(ctx, RoleType, roles)
+	res.Diagnostics.Append(diags...)
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourceMongoDBUser) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	roles := rolesFrom(ctx, plan.Roles, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// roles are replaced as a whole
+	command := bson.D{
+		{Key: "updateUser", Value: plan.Name.ValueString()},
+		{Key: "roles", Value: roles},
+	}
+	// the password is only sent when its version changes
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		command = append(command, bson.E{Key: "pwd", Value: config.PasswordWO.ValueString()})
+	}
+
+	if err := addon.Database().RunCommand(ctx, command).Err(); err != nil {
+		res.Diagnostics.AddError("failed to update user", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceMongoDBUser) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	err := addon.Database().RunCommand(ctx, bson.D{{Key: "dropUser", Value: state.Name.ValueString()}}).Err()
+	// 11: UserNotFound
+	if err != nil && !mongodb.IsCode(err, 11) {
+		res.Diagnostics.AddError("failed to drop user", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceMongoDBUser) connect(ctx context.Context, user *User, diags *diag.Diagnostics) *mongodb.Addon {
+	addon, err := mongodb.Connect(ctx, r.Client(), r.Organization(), user.MongoDBID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to MongoDB addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// rolesFrom converts the roles attribute to the documents expected by createUser and updateUser
+func rolesFrom(ctx context.Context, set types.Set, diags *diag.Diagnostics) bson.A {
+	roles := []Role{}
+	diags.Append(set.ElementsAs(ctx, &roles, false)...)
+
+	documents := bson.A{}
+	for _, role := range roles {
+		documents = append(documents, bson.D{
+			{Key: "role", Value: role.Role.ValueString()},
+			{Key: "db", Value: role.Database.ValueString()},
+		})
+	}
+	return documents
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+type User struct {
+	ID                types.String `tfsdk:"id"`
+	MongoDBID         types.String `tfsdk:"mongodb_id"`
+	Name              types.String `tfsdk:"name"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Roles             types.Set    `tfsdk:"roles"`
+}
+
+type Role struct {
+	Role     types.String `tfsdk:"role"`
+	Database types.String `tfsdk:"database"`
+}
+
+var RoleType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"role":     types.StringType,
+	"database": types.StringType,
+}}
+
+// MongoDB accepts any name, keep them usable in connection URIs
+var NameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+//go:embed doc.md
+var resourceMongoDBUserDoc string
+
+func (r ResourceMongoDBUser) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceMongoDBUserDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "User identifier: <mongodb_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"mongodb_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "MongoDB addon ID the user is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "User name, up to 64 letters, digits, dots, dashes and underscores",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a MongoDB user name", NameRegex)},
+			},
+			"password_wo": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "User password, never stored in the state (requires Terraform 1.11+)",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Change this value to apply a new `password_wo`",
+			},
+			"roles": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Roles granted to the user, each on a database",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Built-in (`read`, `readWrite`, `dbAdmin`...) or custom role name",
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+						"database": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Database the role applies to",
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
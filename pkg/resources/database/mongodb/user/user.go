@@ -0,0 +1,35 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceMongoDBUser struct {
+	helper.Configurer
+}
+
+func NewResourceMongoDBUser() resource.Resource {
+	return &ResourceMongoDBUser{}
+}
+
+func (r *ResourceMongoDBUser) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_mongodb_user"
+}
+
+// ImportState expects <mongodb_id>/<user name>
+func (r *ResourceMongoDBUser) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	mongodbID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || mongodbID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <mongodb_id>/<user name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("mongodb_id"), mongodbID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
@@ -0,0 +1,71 @@
+package user_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccMongoDBUser_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-mg-user")
+	database := fmt.Sprintf("${clevercloud_mongodb.%s.database}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	mongodbBlock := helper.NewRessource(
+		"clevercloud_mongodb",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "xs_med",
+		}))
+	userBlock := helper.NewRessource(
+		"clevercloud_mongodb_user",
+		"reporting",
+		helper.SetKeyValues(map[string]any{
+			"mongodb_id":          fmt.Sprintf("${clevercloud_mongodb.%s.id}", rName),
+			"name":                "reporting",
+			"password_wo":         acctest.RandString(24),
+			"password_wo_version": 1,
+			"roles":               []map[string]string{{"role": "read", "database": database}},
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(mongodbBlock, userBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mongodb_user.reporting", tfjsonpath.New("password_wo"), knownvalue.Null()),
+				statecheck.ExpectKnownValue("clevercloud_mongodb_user.reporting", tfjsonpath.New("roles"), knownvalue.SetSizeExact(1)),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				mongodbBlock,
+				userBlock.
+					SetOneValue("password_wo", acctest.RandString(24)).
+					SetOneValue("password_wo_version", 2).
+					SetOneValue("roles", []map[string]string{{"role": "readWrite", "database": database}}),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mongodb_user.reporting", tfjsonpath.New("roles"), knownvalue.SetExact([]knownvalue.Check{
+					knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"role":     knownvalue.StringExact("readWrite"),
+						"database": knownvalue.NotNull(),
+					}),
+				})),
+			},
+		}},
+	})
+}
@@ -260,7 +260,49 @@ func (r *ResourceMySQL) Update(ctx context.Context, req resource.UpdateRequest,
 		&resp.Diagnostics,
 	)
 
+	// only used on deletion
+	state.FinalSnapshot = plan.FinalSnapshot
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	// Handle plan, region, or version changes via migration
+	versionChanged := !plan.Version.IsNull() && !plan.Version.IsUnknown() && !plan.Version.Equal(state.Version)
+	if !plan.Plan.Equal(state.Plan) || !plan.Region.Equal(state.Region) || versionChanged {
+		r.migrate(ctx, plan, &state, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+func (r *ResourceMySQL) migrate(ctx context.Context, plan MySQL, state *MySQL, diags *diag.Diagnostics) {
+	version := state.Version.ValueStringPointer()
+	if !plan.Version.IsNull() && !plan.Version.IsUnknown() {
+		version = plan.Version.ValueStringPointer()
+	}
+
+	billingPlan := addon.Migrate(ctx, r.Client(), r.Organization(), state.ID.ValueString(), addon.MigrationRequest{
+		ProviderID: "mysql-addon",
+		Plan:       plan.Plan.ValueString(),
+		Region:     plan.Region.ValueString(),
+		Version:    version,
+	}, diags)
+	if billingPlan == nil {
+		return
+	}
+
+	state.Plan = pkg.FromStr(billingPlan.Slug)
+	state.Region = plan.Region
+
+	// A migration provisions a new instance: connection details change
+	addonID, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), state.ID.ValueString())
+	if err != nil {
+		diags.AddWarning("failed to refresh connection info after migration", err.Error())
+		return
+	}
+	if myRes := tmp.GetMySQL(ctx, r.Client(), addonID); myRes.HasError() {
+		diags.AddWarning("failed to refresh connection info after migration", myRes.Error().Error())
+	} else {
+		r.readFromAPI(state, *myRes.Payload())
+	}
 }
 
 // Delete resource
@@ -271,6 +313,13 @@ func (r *ResourceMySQL) Delete(ctx context.Context, req resource.DeleteRequest,
 	}
 	tflog.Debug(ctx, "MySQL DELETE", map[string]any{"my": my})
 
+	if my.FinalSnapshot != nil {
+		my.FinalSnapshot.Export(ctx, r.Client(), r.Organization(), my.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	addonId, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), my.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("failed to get addon ID", err.Error())
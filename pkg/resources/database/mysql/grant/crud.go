@@ -0,0 +1,195 @@
+package grant
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+// Create a new resource
+func (r *ResourceMySQLGrant) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Grant](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	if plan.Database.IsNull() || plan.Database.IsUnknown() {
+		plan.Database = types.StringValue(addon.Credentials.Database)
+	}
+
+	r.apply(ctx, addon, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	parts := []string{plan.MySQLID.ValueString(), plan.User.ValueString() + "@" + plan.Host.ValueString(), plan.Database.ValueString()}
+	if !plan.Table.IsNull() {
+		parts = append(parts, plan.Table.ValueString())
+	}
+	plan.ID = types.StringValue(strings.Join(parts, "/"))
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceMySQLGrant) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	grantee := mysqldb.Grantee(state.User.ValueString(), state.Host.ValueString())
+
+	// every account has at least the USAGE privilege
+	var count int
+	err := addon.QueryRowContext(ctx, `SELECT COUNT(*) FROM information_schema.USER_PRIVILEGES WHERE GRANTEE = ?`, grantee).Scan(&count)
+	if err != nil {
+		res.Diagnostics.AddError("failed to read user", err.Error())
+		return
+	}
+	if count == 0 {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	var rows *sql.Rows
+	if state.Table.IsNull() {
+		rows, err = addon.QueryContext(ctx,
+			`SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ?`,
+			grantee, state.Database.ValueString(),
+		)
+	} else {
+		rows, err = addon.QueryContext(ctx,
+			`SELECT PRIVILEGE_TYPE FROM information_schema.TABLE_PRIVILEGES WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?`,
+			grantee, state.Database.ValueString(), state.Table.ValueString(),
+		)
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read privileges", err.Error())
+		return
+	}
+	defer rows.Close()
+
+	privileges := []string{}
+	for rows.Next() {
+		var privilege string
+		if err := rows.Scan(&privilege); err != nil {
+			res.Diagnostics.AddError("failed to read privileges", err.Error())
+			return
+		}
+		privileges = append(privileges, privilege)
+	}
+	if err := rows.Err(); err != nil {
+		res.Diagnostics.AddError("failed to read privileges", err.Error())
+		return
+	}
+
+	state.Privileges = pkg.FromSetString(privileges, &res.Diagnostics)
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourceMySQLGrant) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Grant](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	// only privileges are updated in place
+	r.apply(ctx, addon, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceMySQLGrant) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	revoke, _ := target(&state).Statements(mysqldb.Account(state.User.ValueString(), state.Host.ValueString()), nil)
+	if _, err := addon.ExecContext(ctx, revoke); err != nil && !isGone(err) {
+		res.Diagnostics.AddError("failed to revoke privileges", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceMySQLGrant) connect(ctx context.Context, grant *Grant, diags *diag.Diagnostics) *mysqldb.Addon {
+	addon, err := mysqldb.Connect(ctx, r.Client(), r.Organization(), grant.MySQLID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to MySQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// apply replaces the privileges of the account on the target,
+// MySQL commits each statement: a failed grant leaves no privileges
+func (r *ResourceMySQLGrant) apply(ctx context.Context, addon *mysqldb.Addon, grant *Grant, diags *diag.Diagnostics) {
+	privileges := pkg.SetToStringSlice(ctx, grant.Privileges, diags)
+	if diags.HasError() {
+		return
+	}
+
+	account := mysqldb.Account(grant.User.ValueString(), grant.Host.ValueString())
+	revoke, statement := target(grant).Statements(account, privileges)
+
+	if _, err := addon.ExecContext(ctx, revoke); err != nil && !isGone(err) {
+		diags.AddError("failed to revoke privileges", err.Error())
+		return
+	}
+	if _, err := addon.ExecContext(ctx, statement); err != nil {
+		diags.AddError("failed to grant privileges", err.Error())
+	}
+}
+
+func target(grant *Grant) Target {
+	return Target{
+		Database: grant.Database.ValueString(),
+		Table:    grant.Table.ValueString(),
+	}
+}
+
+// isGone reports whether there was nothing to revoke
+func isGone(err error) bool {
+	// 1141: ER_NONEXISTING_GRANT, 1147: ER_NONEXISTING_TABLE_GRANT
+	return mysqldb.IsCode(err, 1141, 1147)
+}
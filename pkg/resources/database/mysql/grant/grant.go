@@ -0,0 +1,25 @@
+package grant
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceMySQLGrant struct {
+	helper.Configurer
+}
+
+func NewResourceMySQLGrant() resource.Resource {
+	return &ResourceMySQLGrant{}
+}
+
+func (r *ResourceMySQLGrant) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_mysql_grant"
+}
+
+// ImportState is not supported, the grant is rebuilt from the configuration
+func (r *ResourceMySQLGrant) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	res.Diagnostics.AddError("import is not supported", "declare the grant in the configuration, applying it again is harmless")
+}
@@ -0,0 +1,109 @@
+package grant
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+type Grant struct {
+	ID         types.String `tfsdk:"id"`
+	MySQLID    types.String `tfsdk:"mysql_id"`
+	User       types.String `tfsdk:"user"`
+	Host       types.String `tfsdk:"host"`
+	Database   types.String `tfsdk:"database"`
+	Table      types.String `tfsdk:"table"`
+	Privileges types.Set    `tfsdk:"privileges"`
+}
+
+//go:embed doc.md
+var resourceMySQLGrantDoc string
+
+func (r ResourceMySQLGrant) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceMySQLGrantDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Grant identifier",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"mysql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "MySQL addon ID",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"user": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "User receiving the privileges",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"host": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("%"),
+				MarkdownDescription: "Host pattern of the user account",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a host pattern: %, a hostname or an IP, with an optional netmask", mysqldb.HostRegex)},
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Database of the privileges, defaults to the database of the addon",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Table of the privileges, the whole database when not set",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"privileges": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Privileges granted (SELECT, INSERT, EXECUTE...)",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(DatabasePrivileges...)),
+				},
+			},
+		},
+	}
+}
+
+func (r ResourceMySQLGrant) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	grant := Grant{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &grant)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if grant.Table.IsNull() || grant.Table.IsUnknown() || grant.Privileges.IsUnknown() {
+		return
+	}
+
+	privileges := []string{}
+	res.Diagnostics.Append(grant.Privileges.ElementsAs(ctx, &privileges, false)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if err := (Target{Table: grant.Table.ValueString()}).Validate(privileges); err != nil {
+		res.Diagnostics.AddError("invalid grant", err.Error())
+	}
+}
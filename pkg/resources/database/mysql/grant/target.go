@@ -0,0 +1,54 @@
+package grant
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+// Privileges which can be granted on a database
+var DatabasePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER",
+	"CREATE VIEW", "SHOW VIEW", "TRIGGER", "REFERENCES", "EXECUTE",
+	"CREATE ROUTINE", "ALTER ROUTINE", "CREATE TEMPORARY TABLES", "LOCK TABLES", "EVENT",
+}
+
+// Privileges which can be granted on a table
+var TablePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "INDEX", "ALTER",
+	"CREATE VIEW", "SHOW VIEW", "TRIGGER", "REFERENCES",
+}
+
+// Target designates the database, or one of its tables, a grant applies to
+type Target struct {
+	Database string
+	// empty for the whole database
+	Table string
+}
+
+func (t Target) Validate(privileges []string) error {
+	if t.Table == "" {
+		return nil
+	}
+
+	for _, privilege := range privileges {
+		if !slices.Contains(TablePrivileges, privilege) {
+			return fmt.Errorf("privilege '%s' cannot be granted on a table, expect one of %s", privilege, strings.Join(TablePrivileges, ", "))
+		}
+	}
+	return nil
+}
+
+// Statements returns the statements revoking every privilege of the account on the target,
+// and granting it the given privileges
+func (t Target) Statements(account string, privileges []string) (revoke, grant string) {
+	on := mysqldb.Ident(t.Database) + ".*"
+	if t.Table != "" {
+		on = mysqldb.Ident(t.Database, t.Table)
+	}
+
+	return "REVOKE ALL PRIVILEGES ON " + on + " FROM " + account,
+		"GRANT " + strings.Join(privileges, ", ") + " ON " + on + " TO " + account
+}
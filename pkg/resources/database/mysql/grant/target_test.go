@@ -0,0 +1,43 @@
+package grant
+
+import "testing"
+
+func TestTargetStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		target Target
+		revoke string
+		grant  string
+	}{{
+		name:   "database",
+		target: Target{Database: "app"},
+		revoke: "REVOKE ALL PRIVILEGES ON `app`.* FROM 'reader'@'%'",
+		grant:  "GRANT SELECT, SHOW VIEW ON `app`.* TO 'reader'@'%'",
+	}, {
+		name:   "table",
+		target: Target{Database: "app", Table: "orders"},
+		revoke: "REVOKE ALL PRIVILEGES ON `app`.`orders` FROM 'reader'@'%'",
+		grant:  "GRANT SELECT, SHOW VIEW ON `app`.`orders` TO 'reader'@'%'",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoke, grant := tt.target.Statements("'reader'@'%'", []string{"SELECT", "SHOW VIEW"})
+			if revoke != tt.revoke {
+				t.Errorf("expect revoke %s, got %s", tt.revoke, revoke)
+			}
+			if grant != tt.grant {
+				t.Errorf("expect grant %s, got %s", tt.grant, grant)
+			}
+		})
+	}
+}
+
+func TestTargetValidate(t *testing.T) {
+	if err := (Target{Database: "app"}).Validate([]string{"EXECUTE", "EVENT"}); err != nil {
+		t.Errorf("expect database privileges to be valid, got %s", err)
+	}
+	if err := (Target{Database: "app", Table: "orders"}).Validate([]string{"SELECT", "EXECUTE"}); err == nil {
+		t.Errorf("expect EXECUTE to be rejected on a table")
+	}
+}
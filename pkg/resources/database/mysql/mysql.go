@@ -33,6 +33,7 @@ func (r *ResourceMySQL) Metadata(ctx context.Context, req resource.MetadataReque
 }
 
 // ModifyPlan validates that encryption, backup, skip_log_bin, and direct_host_only options are only used with dedicated plans
+// and that mysqldump is installed when final_snapshot is set
 func (r *ResourceMySQL) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
 	if req.Plan.Raw.IsNull() { // Skip validation when deleting
 		return
@@ -43,6 +44,10 @@ func (r *ResourceMySQL) ModifyPlan(ctx context.Context, req resource.ModifyPlanR
 		return
 	}
 
+	if plan.FinalSnapshot != nil {
+		plan.FinalSnapshot.ValidateTool("mysqldump", &res.Diagnostics)
+	}
+
 	// Skip validation if provider not configured yet
 	if r.Client() == nil {
 		return
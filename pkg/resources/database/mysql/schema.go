@@ -34,6 +34,8 @@ type MySQL struct {
 	DirectHostOnly types.Bool `tfsdk:"direct_host_only"`
 	SkipLogBin     types.Bool `tfsdk:"skip_log_bin"`
 	ReadOnlyUsers  types.List `tfsdk:"read_only_users"`
+
+	FinalSnapshot *addon.FinalSnapshot `tfsdk:"final_snapshot"`
 }
 
 //go:embed doc.md
@@ -101,6 +103,7 @@ func (r ResourceMySQL) Schema(_ context.Context, req resource.SchemaRequest, res
 					},
 				},
 			},
+			"final_snapshot": addon.FinalSnapshotAttribute,
 		}),
 	}
 }
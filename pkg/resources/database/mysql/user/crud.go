@@ -0,0 +1,148 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+// Create a new resource
+func (r *ResourceMySQLUser) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	// write-only values are only available in the configuration
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	account := mysqldb.Account(plan.Name.ValueString(), plan.Host.ValueString())
+	query := "CREATE USER " + account + " IDENTIFIED BY " + mysqldb.Literal(config.PasswordWO.ValueString()) + " " + userOptions(&plan)
+	if _, err := addon.ExecContext(ctx, query); err != nil {
+		// 1396: ER_CANNOT_USER, the account already exists
+		if mysqldb.IsCode(err, 1396) {
+			res.Diagnostics.AddError("user already exists", "import it with: terraform import <address> "+plan.MySQLID.ValueString()+"/"+plan.Name.ValueString()+"@"+plan.Host.ValueString())
+			return
+		}
+		res.Diagnostics.AddError("failed to create user", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.MySQLID.ValueString() + "/" + plan.Name.ValueString() + "@" + plan.Host.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourceMySQLUser) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	var maxUserConnections int64
+	var locked string
+	err := addon.QueryRowContext(ctx,
+		`SELECT max_user_connections, account_locked FROM mysql.user WHERE User = ? AND Host = ?`,
+		state.Name.ValueString(), state.Host.ValueString(),
+	).Scan(&maxUserConnections, &locked)
+	if errors.Is(err, sql.ErrNoRows) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read user", err.Error())
+		return
+	}
+
+	state.MaxUserConnections = types.Int64Value(maxUserConnections)
+	state.Locked = types.BoolValue(locked == "Y")
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourceMySQLUser) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[User](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	config := helper.ConfigFrom[User](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	query := "ALTER USER " + mysqldb.Account(plan.Name.ValueString(), plan.Host.ValueString())
+	// the password is only sent when its version changes
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		query += " IDENTIFIED BY " + mysqldb.Literal(config.PasswordWO.ValueString())
+	}
+	if _, err := addon.ExecContext(ctx, query+" "+userOptions(&plan)); err != nil {
+		res.Diagnostics.AddError("failed to update user", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourceMySQLUser) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[User](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close()
+
+	account := mysqldb.Account(state.Name.ValueString(), state.Host.ValueString())
+	if _, err := addon.ExecContext(ctx, "DROP USER IF EXISTS "+account); err != nil {
+		res.Diagnostics.AddError("failed to drop user", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourceMySQLUser) connect(ctx context.Context, user *User, diags *diag.Diagnostics) *mysqldb.Addon {
+	addon, err := mysqldb.Connect(ctx, r.Client(), r.Organization(), user.MySQLID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to MySQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// userOptions renders the resource options of CREATE/ALTER USER
+func userOptions(user *User) string {
+	lock := "ACCOUNT UNLOCK"
+	if user.Locked.ValueBool() {
+		lock = "ACCOUNT LOCK"
+	}
+	return fmt.Sprintf("WITH MAX_USER_CONNECTIONS %d %s", user.MaxUserConnections.ValueInt64(), lock)
+}
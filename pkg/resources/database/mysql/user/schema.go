@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/mysqldb"
+)
+
+type User struct {
+	ID                 types.String `tfsdk:"id"`
+	MySQLID            types.String `tfsdk:"mysql_id"`
+	Name               types.String `tfsdk:"name"`
+	Host               types.String `tfsdk:"host"`
+	PasswordWO         types.String `tfsdk:"password_wo"`
+	PasswordWOVersion  types.Int64  `tfsdk:"password_wo_version"`
+	MaxUserConnections types.Int64  `tfsdk:"max_user_connections"`
+	Locked             types.Bool   `tfsdk:"locked"`
+}
+
+// MySQL user names are limited to 32 characters
+var NameRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,32}$`)
+
+//go:embed doc.md
+var resourceMySQLUserDoc string
+
+func (r ResourceMySQLUser) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourceMySQLUserDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "User identifier: <mysql_id>/<name>@<host>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"mysql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "MySQL addon ID the user is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "User name, up to 32 letters, digits, dots, dashes and underscores",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a MySQL user name", NameRegex)},
+			},
+			"host": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("%"),
+				MarkdownDescription: "Host pattern the user can connect from (`%` for any host, `10.0.%`...)",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a host pattern: %, a hostname or an IP, with an optional netmask", mysqldb.HostRegex)},
+			},
+			"password_wo": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "User password, never stored in the state (requires Terraform 1.11+)",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Change this value to apply a new `password_wo`",
+			},
+			"max_user_connections": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "Maximum concurrent connections of the user, 0 for no limit",
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"locked": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Lock the account, the user cannot connect anymore",
+			},
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourceMySQLUser struct {
+	helper.Configurer
+}
+
+func NewResourceMySQLUser() resource.Resource {
+	return &ResourceMySQLUser{}
+}
+
+func (r *ResourceMySQLUser) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_mysql_user"
+}
+
+// ImportState expects <mysql_id>/<user name>@<host>
+func (r *ResourceMySQLUser) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	mysqlID, account, _ := strings.Cut(req.ID, "/")
+	name, host, ok := strings.Cut(account, "@")
+	if !ok || mysqlID == "" || name == "" || host == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <mysql_id>/<user name>@<host>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("mysql_id"), mysqlID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("host"), host)...)
+}
@@ -0,0 +1,80 @@
+package user_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccMySQLUser_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-my-user")
+	mysqlID := fmt.Sprintf("${clevercloud_mysql.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	mysqlBlock := helper.NewRessource(
+		"clevercloud_mysql",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "s_sml",
+		}))
+	userBlock := helper.NewRessource(
+		"clevercloud_mysql_user",
+		"reporting",
+		helper.SetKeyValues(map[string]any{
+			"mysql_id":            mysqlID,
+			"name":                "reporting",
+			"password_wo":         acctest.RandString(24),
+			"password_wo_version": 1,
+		}))
+	grantBlock := helper.NewRessource(
+		"clevercloud_mysql_grant",
+		"reporting",
+		helper.SetKeyValues(map[string]any{
+			"mysql_id":   mysqlID,
+			"user":       "${clevercloud_mysql_user.reporting.name}",
+			"privileges": []string{"SELECT"},
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(mysqlBlock, userBlock, grantBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mysql_user.reporting", tfjsonpath.New("host"), knownvalue.StringExact("%")),
+				statecheck.ExpectKnownValue("clevercloud_mysql_user.reporting", tfjsonpath.New("password_wo"), knownvalue.Null()),
+				statecheck.ExpectKnownValue("clevercloud_mysql_user.reporting", tfjsonpath.New("max_user_connections"), knownvalue.Int64Exact(0)),
+				statecheck.ExpectKnownValue("clevercloud_mysql_user.reporting", tfjsonpath.New("locked"), knownvalue.Bool(false)),
+				statecheck.ExpectKnownValue("clevercloud_mysql_grant.reporting", tfjsonpath.New("privileges"), knownvalue.SetExact([]knownvalue.Check{
+					knownvalue.StringExact("SELECT"),
+				})),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				mysqlBlock,
+				userBlock.
+					SetOneValue("password_wo", acctest.RandString(24)).
+					SetOneValue("password_wo_version", 2).
+					SetOneValue("max_user_connections", 5),
+				grantBlock.SetOneValue("privileges", []string{"SELECT", "SHOW VIEW"}),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_mysql_grant.reporting", tfjsonpath.New("privileges"), knownvalue.SetSizeExact(2)),
+				statecheck.ExpectKnownValue("clevercloud_mysql_user.reporting", tfjsonpath.New("max_user_connections"), knownvalue.Int64Exact(5)),
+			},
+		}},
+	})
+}
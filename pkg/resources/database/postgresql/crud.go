@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
 	"go.clever-cloud.com/terraform-provider/pkg/resources"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/addon"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
@@ -267,6 +268,10 @@ func (r *ResourcePostgreSQL) Create(ctx context.Context, req resource.CreateRequ
 		pg.Locale = pkg.FromStr("en_GB")
 	}
 
+	if pg.RestoreFromBackup != nil {
+		r.restoreFromBackup(ctx, createdPg.ID, addonPG, pg.RestoreFromBackup, &resp.Diagnostics)
+	}
+
 	addon.SyncNetworkGroups(
 		ctx,
 		r,
@@ -356,6 +361,33 @@ func (r *ResourcePostgreSQL) Read(ctx context.Context, req resource.ReadRequest,
 	resp.Diagnostics.Append(resp.State.Set(ctx, pg)...)
 }
 
+// restoreFromBackup restores a backup into the database of the newly created addon
+func (r *ResourcePostgreSQL) restoreFromBackup(ctx context.Context, addonID string, creds *tmp.PostgreSQL, restore *RestoreFromBackup, diags *diag.Diagnostics) {
+	progress := func(msg string, args ...any) {
+		tflog.Info(ctx, fmt.Sprintf(msg, args...))
+	}
+
+	selector := "latest"
+	pkg.IfIsSetStr(restore.Backup, func(s string) { selector = s })
+
+	backup := pgsql.FindBackup(ctx, r.Client(), r.Organization(), restore.PostgreSQLID.ValueString(), selector, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// wait for the database to accept connections
+	conn, err := pgsql.Connect(ctx, r.Client(), r.Organization(), addonID, "")
+	if err != nil {
+		diags.AddAttributeError(path.Root("restore_from_backup"), "failed to connect to database", err.Error())
+		return
+	}
+	conn.Close(ctx)
+
+	if err := pgsql.RestoreBackup(ctx, backup, creds, false, progress); err != nil {
+		diags.AddAttributeError(path.Root("restore_from_backup"), "failed to restore backup", err.Error())
+	}
+}
+
 func (r *ResourcePostgreSQL) readFromAddon(state *PostgreSQL, addon tmp.AddonResponse) {
 	state.Name = pkg.FromStr(addon.Name)
 	state.Plan = pkg.FromStr(addon.Plan.Slug)
@@ -425,6 +457,11 @@ func (r *ResourcePostgreSQL) Update(ctx context.Context, req resource.UpdateRequ
 		&resp.Diagnostics,
 	)
 
+	// only used on creation
+	state.RestoreFromBackup = plan.RestoreFromBackup
+	// only used on deletion
+	state.FinalSnapshot = plan.FinalSnapshot
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 	// Handle plan, region, or version changes via migration
 	needsMigration := !plan.Plan.Equal(state.Plan) ||
@@ -437,155 +474,44 @@ func (r *ResourcePostgreSQL) Update(ctx context.Context, req resource.UpdateRequ
 }
 
 func (r *ResourcePostgreSQL) migrate(ctx context.Context, plan PostgreSQL, state *PostgreSQL, diags *diag.Diagnostics) {
-	addonsProvidersRes := tmp.GetAddonsProviders(ctx, r.Client())
-	if addonsProvidersRes.HasError() {
-		diags.AddError("failed to get addon providers", addonsProvidersRes.Error().Error())
-		return
+	version := plan.Version.ValueStringPointer()
+	if plan.Version.IsNull() || plan.Version.IsUnknown() {
+		version = state.Version.ValueStringPointer()
 	}
-	addonsProviders := addonsProvidersRes.Payload()
 
-	prov := pkg.LookupAddonProvider(*addonsProviders, "postgresql-addon")
-	billingPlan := pkg.LookupProviderPlan(prov, plan.Plan.ValueString())
+	billingPlan := addon.Migrate(ctx, r.Client(), r.Organization(), state.ID.ValueString(), addon.MigrationRequest{
+		ProviderID: "postgresql-addon",
+		Plan:       plan.Plan.ValueString(),
+		Region:     plan.Region.ValueString(),
+		Version:    version,
+	}, diags)
 	if billingPlan == nil {
-		diags.AddError("failed to find plan", "expect: "+strings.Join(pkg.ProviderPlansAsList(prov), ", ")+", got: "+plan.Plan.String())
 		return
 	}
 
+	state.Plan = pkg.FromStr(billingPlan.Slug)
+	state.Region = plan.Region
+	// no version requested: the addon keeps its own, refreshed below
+	state.Version = pkg.FromStrPtr(version)
+
+	// A migration provisions a new instance: connection details change.
+	// Refresh them explicitly since they are pinned via UseStateForUnknown.
 	addonID, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), state.ID.ValueString())
 	if err != nil {
-		diags.AddError("failed to get addon ID", err.Error())
+		diags.AddWarning("failed to refresh connection info after migration", err.Error())
 		return
 	}
-
-	// Check for already running migrations
-	migrationsRes := tmp.ListAddonMigrations(ctx, r.Client(), r.Organization(), addonID)
-	if migrationsRes.HasError() {
-		diags.AddError("failed to list migrations", migrationsRes.Error().Error())
-		return
-	}
-	migrations := migrationsRes.Payload()
-
-	runningMig := pkg.First(*migrations, func(mig tmp.AddonMigrationResponse) bool {
-		return mig.Status == "RUNNING"
-	})
-	if runningMig != nil {
-		diags.AddError(
-			"migration already in progress",
-			fmt.Sprintf("A migration (ID: %s) is already running for this addon. Please wait for it to complete before requesting a new migration.", runningMig.MigrationID),
-		)
-		return
-	}
-
-	// TODO:
-	// migration cannot be run if instance is not ready
-	// hard to know when instance is OK, because instance API return UP even if Postgres is not listening
-	/*for {
-		pgRes := tmp.ListInstances(ctx, r.Client(), r.Organization(), state.ID.ValueString())
-		if pgRes.HasError() {
-			tflog.Warn(ctx, "failed to get PG", map[string]any{"error": pgRes.Error().Error()})
-			continue
-		}
-		instances := *pgRes.Payload()
-
-		if len(instances) == 0 {
-			continue
-		}
-
-		firstInstance := pkg.First(instances, func(instance tmp.AppInstance) bool { return true })
-		state := firstInstance.State
-		if state != "UP" { // BOOTING
-			tflog.Info(ctx, "pg status not OK", map[string]any{"state": state})
-			continue
-		}
-
-		fmt.Printf("\n\n%+v\n\n", firstInstance)
-		break
-	}*/
-
-	migrationReq := tmp.AddonMigrationRequest{Region: plan.Region.ValueString(), PlanID: billingPlan.ID}
-	if plan.Version.IsNull() || plan.Version.IsUnknown() {
-		migrationReq.Version = state.Version.ValueStringPointer()
+	if pgRes := tmp.GetPostgreSQL(ctx, r.Client(), addonID); pgRes.HasError() {
+		diags.AddWarning("failed to refresh connection info after migration", pgRes.Error().Error())
 	} else {
-		migrationReq.Version = plan.Version.ValueStringPointer()
-	}
-	tflog.Debug(ctx, "migration request", map[string]any{
-		"version": migrationReq.Version,
-		"region":  migrationReq.Region,
-		"plan":    migrationReq.PlanID,
-	})
-
-	migrationRes := tmp.MigrateAddon(ctx, r.Client(), r.Organization(), addonID, migrationReq)
-	if migrationRes.HasError() {
-		diags.AddError("failed to migrate PostgreSQL", migrationRes.Error().Error())
-		return
-	}
-	migration := migrationRes.Payload()
-
-	tflog.Info(ctx, "PostgreSQL migration started", map[string]any{
-		"migration_id": migration.MigrationID,
-		"status":       migration.Status,
-		"request_date": migration.RequestDate,
-	})
-
-	t := time.NewTicker(1 * time.Second)
-
-	// Wait for migration to complete
-	migrationID := migration.MigrationID
-	for {
-		// Check if context is done (timeout or cancellation)
-		select {
-		case <-ctx.Done():
-			diags.AddError("migration timeout", "Migration did not complete within the allowed time, check DB logs")
-			return
-		case <-t.C:
-			migrationsRes := tmp.GetAddonMigrations(ctx, r.Client(), r.Organization(), addonID, migrationID)
-			if migrationsRes.HasError() {
-				diags.AddWarning("failed to check migration status", migrationsRes.Error().Error())
-				continue
-			}
-			currentMigration := migrationsRes.Payload()
-
-			tflog.Info(ctx, "Migration status check", map[string]any{
-				"addon":        state.ID.ValueString(),
-				"migration_id": currentMigration.MigrationID,
-				"status":       currentMigration.Status,
-			})
-			for _, step := range currentMigration.Steps {
-				tflog.Debug(ctx, step.Name, map[string]any{
-					"message": step.Message,
-					"value":   step.Value,
-					"status":  step.Status,
-				})
-			}
-
-			if currentMigration.Status == "OK" {
-				state.Plan = pkg.FromStr(billingPlan.Slug)
-				state.Region = pkg.FromStr(migrationReq.Region)
-				state.Version = pkg.FromStr(*migrationReq.Version)
-
-				// A migration provisions a new instance: connection details change.
-				// Refresh them explicitly since they are pinned via UseStateForUnknown.
-				if pgRes := tmp.GetPostgreSQL(ctx, r.Client(), addonID); pgRes.HasError() {
-					diags.AddWarning("failed to refresh connection info after migration", pgRes.Error().Error())
-				} else {
-					addonPG := pgRes.Payload()
-					state.Host = pkg.FromStr(addonPG.Host)
-					state.Port = pkg.FromI(int64(addonPG.Port))
-					state.Database = pkg.FromStr(addonPG.Database)
-					state.User = pkg.FromStr(addonPG.User)
-					state.Password = pkg.FromStr(addonPG.Password)
-					state.Version = pkg.FromStr(addonPG.Version)
-					state.Uri = pkg.FromStr(addonPG.Uri())
-				}
-				return
-			} else if currentMigration.Status != "RUNNING" {
-				diags.AddError(
-					"migration failed",
-					fmt.Sprintf("Migration ended with status: %s", currentMigration.Status),
-				)
-				return
-			}
-		}
+		addonPG := pgRes.Payload()
+		state.Host = pkg.FromStr(addonPG.Host)
+		state.Port = pkg.FromI(int64(addonPG.Port))
+		state.Database = pkg.FromStr(addonPG.Database)
+		state.User = pkg.FromStr(addonPG.User)
+		state.Password = pkg.FromStr(addonPG.Password)
+		state.Version = pkg.FromStr(addonPG.Version)
+		state.Uri = pkg.FromStr(addonPG.Uri())
 	}
 }
 
@@ -595,6 +521,13 @@ func (r *ResourcePostgreSQL) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	if pg.FinalSnapshot != nil {
+		pg.FinalSnapshot.Export(ctx, r.Client(), r.Organization(), pg.ID.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	addonID, err := tmp.RealIDToAddonID(ctx, r.Client(), r.Organization(), pg.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("failed to get addon ID", err.Error())
@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+// Create a new resource
+func (r *ResourcePostgreSQLDatabase) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Database](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := schemasFrom(ctx, plan.Schemas, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, "", &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// the addon database already exists, it is adopted
+	if plan.Name.IsNull() || plan.Name.IsUnknown() {
+		plan.Name = types.StringValue(addon.Credentials.Database)
+	}
+	name := pgsql.Ident(plan.Name.ValueString())
+
+	if plan.Name.ValueString() != addon.Credentials.Database {
+		// CREATE DATABASE cannot run in a transaction
+		if _, err := addon.Exec(ctx, "CREATE DATABASE "+name); err != nil {
+			res.Diagnostics.AddError("failed to create database", err.Error())
+			return
+		}
+	}
+
+	if !plan.Owner.IsNull() && !plan.Owner.IsUnknown() {
+		if _, err := addon.Exec(ctx, "ALTER DATABASE "+name+" OWNER TO "+pgsql.Ident(plan.Owner.ValueString())); err != nil {
+			res.Diagnostics.AddError("failed to set database owner", err.Error())
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(plan.PostgreSQLID.ValueString() + "/" + plan.Name.ValueString())
+	plan.Owner = types.StringValue(readOwner(ctx, addon, plan.Name.ValueString(), &res.Diagnostics))
+	if res.Diagnostics.HasError() {
+		return
+	}
+	// keep the database in the state even if schemas fail
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+
+	if len(wanted) > 0 {
+		r.applySchemas(ctx, &plan, wanted, map[string]string{}, &res.Diagnostics)
+	}
+}
+
+// Read resource information
+func (r *ResourcePostgreSQLDatabase) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Database](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, "", &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// imported without name
+	if state.Name.IsNull() {
+		state.Name = types.StringValue(addon.Credentials.Database)
+	}
+
+	owner := readOwner(ctx, addon, state.Name.ValueString(), &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	if owner == "" {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	state.Owner = types.StringValue(owner)
+
+	// only schemas listed in the configuration are managed
+	if !state.Schemas.IsNull() {
+		managed := schemasFrom(ctx, state.Schemas, &res.Diagnostics)
+		if res.Diagnostics.HasError() {
+			return
+		}
+
+		db := addon
+		if state.Name.ValueString() != addon.Credentials.Database {
+			db = r.connect(ctx, &state, state.Name.ValueString(), &res.Diagnostics)
+			if res.Diagnostics.HasError() {
+				return
+			}
+			defer db.Close(ctx)
+		}
+
+		schemas := readSchemas(ctx, db, slices.Collect(maps.Keys(managed)), &res.Diagnostics)
+		if res.Diagnostics.HasError() {
+			return
+		}
+
+		var diags diag.Diagnostics
+		state.Schemas, diags = types.MapValueFrom(ctx, types.StringType, schemas)
+		res.Diagnostics.Append(diags...)
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourcePostgreSQLDatabase) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Database](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Database](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	wanted := schemasFrom(ctx, plan.Schemas, &res.Diagnostics)
+	current := schemasFrom(ctx, state.Schemas, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, "", &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if !plan.Owner.IsUnknown() && !plan.Owner.Equal(state.Owner) {
+		if _, err := addon.Exec(ctx, "ALTER DATABASE "+pgsql.Ident(plan.Name.ValueString())+" OWNER TO "+pgsql.Ident(plan.Owner.ValueString())); err != nil {
+			res.Diagnostics.AddError("failed to set database owner", err.Error())
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.Owner = types.StringValue(readOwner(ctx, addon, plan.Name.ValueString(), &res.Diagnostics))
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.applySchemas(ctx, &plan, wanted, current, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourcePostgreSQLDatabase) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Database](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	current := schemasFrom(ctx, state.Schemas, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, "", &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// the addon database is only released, its managed schemas are dropped
+	if state.Name.ValueString() == addon.Credentials.Database {
+		r.applySchemas(ctx, &state, map[string]string{}, current, &res.Diagnostics)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	if _, err := addon.Exec(ctx, "DROP DATABASE IF EXISTS "+pgsql.Ident(state.Name.ValueString())); err != nil {
+		// 55006: object_in_use
+		if pgsql.IsCode(err, "55006") {
+			res.Diagnostics.AddError("database is still in use", err.Error()+"\nclose the connections of the applications before deleting it")
+			return
+		}
+		res.Diagnostics.AddError("failed to drop database", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePostgreSQLDatabase) connect(ctx context.Context, db *Database, database string, diags *diag.Diagnostics) *pgsql.Addon {
+	addon, err := pgsql.Connect(ctx, r.Client(), r.Organization(), db.PostgreSQLID.ValueString(), database)
+	if err != nil {
+		diags.AddError("failed to connect to PostgreSQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// applySchemas creates, transfers and drops schemas of the database,
+// a schema containing objects is not dropped.
+func (r *ResourcePostgreSQLDatabase) applySchemas(ctx context.Context, db *Database, wanted, current map[string]string, diags *diag.Diagnostics) {
+	if len(wanted) == 0 && len(current) == 0 {
+		return
+	}
+
+	conn := r.connect(ctx, db, db.Name.ValueString(), diags)
+	if diags.HasError() {
+		return
+	}
+	defer conn.Close(ctx)
+
+	err := pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		for _, name := range slices.Sorted(maps.Keys(wanted)) {
+			schema, owner := pgsql.Ident(name), pgsql.Ident(wanted[name])
+			if _, err := tx.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+schema+" AUTHORIZATION "+owner); err != nil {
+				return err
+			}
+			// IF NOT EXISTS keeps the owner of an existing schema
+			if _, err := tx.Exec(ctx, "ALTER SCHEMA "+schema+" OWNER TO "+owner); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(current)) {
+			if _, ok := wanted[name]; ok || name == "public" {
+				continue
+			}
+			if _, err := tx.Exec(ctx, "DROP SCHEMA IF EXISTS "+pgsql.Ident(name)+" RESTRICT"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		diags.AddError("failed to update schemas", err.Error())
+	}
+}
+
+// readOwner returns the owner of the database, empty if it does not exist
+func readOwner(ctx context.Context, addon *pgsql.Addon, name string, diags *diag.Diagnostics) string {
+	var owner string
+	err := addon.QueryRow(ctx,
+		`SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = $1`,
+		name,
+	).Scan(&owner)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ""
+	}
+	if err != nil {
+		diags.AddError("failed to read database", err.Error())
+	}
+	return owner
+}
+
+// readSchemas returns the owner of the given schemas which still exist
+func readSchemas(ctx context.Context, addon *pgsql.Addon, names []string, diags *diag.Diagnostics) map[string]string {
+	rows, err := addon.Query(ctx,
+		`SELECT nspname, pg_get_userbyid(nspowner) FROM pg_namespace WHERE nspname = ANY($1)`,
+		names,
+	)
+	if err != nil {
+		diags.AddError("failed to read schemas", err.Error())
+		return nil
+	}
+
+	schemas := map[string]string{}
+	var name, owner string
+	_, err = pgx.ForEachRow(rows, []any{&name, &owner}, func() error {
+		schemas[name] = owner
+		return nil
+	})
+	if err != nil {
+		diags.AddError("failed to read schemas", err.Error())
+	}
+	return schemas
+}
+
+func schemasFrom(ctx context.Context, schemas types.Map, diags *diag.Diagnostics) map[string]string {
+	m := map[string]string{}
+	if schemas.IsNull() || schemas.IsUnknown() {
+		return m
+	}
+	diags.Append(schemas.ElementsAs(ctx, &m, false)...)
+	return m
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/resources/database/postgresql/role"
+)
+
+type Database struct {
+	ID           types.String `tfsdk:"id"`
+	PostgreSQLID types.String `tfsdk:"postgresql_id"`
+	Name         types.String `tfsdk:"name"`
+	Owner        types.String `tfsdk:"owner"`
+	Schemas      types.Map    `tfsdk:"schemas"`
+}
+
+// NameRegex also matches the generated name of the addon database
+var NameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,62}$`)
+
+//go:embed doc.md
+var resourcePostgreSQLDatabaseDoc string
+
+func (r ResourcePostgreSQLDatabase) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePostgreSQLDatabaseDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Database identifier: <postgresql_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"postgresql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PostgreSQL addon ID the database lives in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Database name, defaults to the database of the addon",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{pkg.NewValidatorRegex("must be a database name", NameRegex)},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Role owning the database, defaults to the addon user",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"schemas": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Schemas of the database, by name, with their owner role",
+				Validators: []validator.Map{
+					pkg.NoNullMapValuesValidator(),
+					mapvalidator.KeysAre(pkg.NewValidatorRegex("must be a schema name", role.NameRegex)),
+				},
+			},
+		},
+	}
+}
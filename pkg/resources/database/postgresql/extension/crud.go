@@ -0,0 +1,236 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+// Create a new resource
+func (r *ResourcePostgreSQLExtension) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Extension](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if plan.Database.IsNull() || plan.Database.IsUnknown() {
+		plan.Database = types.StringValue(addon.Credentials.Database)
+	}
+
+	name := plan.Name.ValueString()
+	version := knownValue(plan.Version)
+	if err := checkAvailable(ctx, addon, name, version); err != nil {
+		res.Diagnostics.AddError("extension is not available", err.Error())
+		return
+	}
+
+	query := "CREATE EXTENSION " + pgsql.Ident(name)
+	if schema := knownValue(plan.Schema); schema != "" {
+		query += " SCHEMA " + pgsql.Ident(schema)
+	}
+	if version != "" {
+		query += " VERSION " + pgsql.Literal(version)
+	}
+
+	if _, err := addon.Exec(ctx, query); err != nil {
+		// 42710: duplicate_object
+		if pgsql.IsCode(err, "42710") {
+			res.Diagnostics.AddError(
+				"extension is already installed",
+				fmt.Sprintf("%s\nimport it with: terraform import <address> %s/%s/%s", err.Error(), plan.PostgreSQLID.ValueString(), plan.Database.ValueString(), name),
+			)
+			return
+		}
+		res.Diagnostics.AddError("failed to create extension", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(strings.Join([]string{plan.PostgreSQLID.ValueString(), plan.Database.ValueString(), name}, "/"))
+	if !readExtension(ctx, addon, &plan, &res.Diagnostics) && !res.Diagnostics.HasError() {
+		res.Diagnostics.AddError("failed to read extension", "extension is missing after its creation")
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePostgreSQLExtension) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Extension](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// dropped outside of Terraform
+	if !readExtension(ctx, addon, &state, &res.Diagnostics) {
+		if !res.Diagnostics.HasError() {
+			res.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourcePostgreSQLExtension) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Extension](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Extension](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	name := pgsql.Ident(plan.Name.ValueString())
+
+	if version := knownValue(plan.Version); version != "" && !plan.Version.Equal(state.Version) {
+		if err := checkAvailable(ctx, addon, plan.Name.ValueString(), version); err != nil {
+			res.Diagnostics.AddError("extension version is not available", err.Error())
+			return
+		}
+		if _, err := addon.Exec(ctx, "ALTER EXTENSION "+name+" UPDATE TO "+pgsql.Literal(version)); err != nil {
+			res.Diagnostics.AddError("failed to update extension", err.Error())
+			return
+		}
+	}
+
+	if schema := knownValue(plan.Schema); schema != "" && !plan.Schema.Equal(state.Schema) {
+		if _, err := addon.Exec(ctx, "ALTER EXTENSION "+name+" SET SCHEMA "+pgsql.Ident(schema)); err != nil {
+			res.Diagnostics.AddError("failed to move extension", err.Error())
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	if !readExtension(ctx, addon, &plan, &res.Diagnostics) && !res.Diagnostics.HasError() {
+		res.Diagnostics.AddError("failed to read extension", "extension was dropped during the update")
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourcePostgreSQLExtension) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Extension](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if _, err := addon.Exec(ctx, "DROP EXTENSION IF EXISTS "+pgsql.Ident(state.Name.ValueString())+" RESTRICT"); err != nil {
+		// 2BP01: dependent_objects_still_exist
+		if pgsql.IsCode(err, "2BP01") {
+			res.Diagnostics.AddError(
+				"extension is still in use",
+				fmt.Sprintf("%s\ndrop the columns, indexes or functions using it before deleting it", err.Error()),
+			)
+			return
+		}
+		res.Diagnostics.AddError("failed to drop extension", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePostgreSQLExtension) connect(ctx context.Context, extension *Extension, diags *diag.Diagnostics) *pgsql.Addon {
+	addon, err := pgsql.Connect(ctx, r.Client(), r.Organization(), extension.PostgreSQLID.ValueString(), extension.Database.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to PostgreSQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// checkAvailable ensures the extension, and the version when not empty,
+// can be installed on the addon
+func checkAvailable(ctx context.Context, addon *pgsql.Addon, name, version string) error {
+	rows, err := addon.Query(ctx,
+		`SELECT version FROM pg_available_extension_versions WHERE name = $1 ORDER BY version`,
+		name,
+	)
+	if err != nil {
+		return err
+	}
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(versions) == 0:
+		return fmt.Errorf("extension '%s' is not available on this addon, see pg_available_extensions", name)
+	case version != "" && !slices.Contains(versions, version):
+		return fmt.Errorf("version '%s' of extension '%s' is not available, expect one of %s", version, name, strings.Join(versions, ", "))
+	}
+
+	return nil
+}
+
+// readExtension sets the installed schema and version, false when the extension is not installed
+func readExtension(ctx context.Context, addon *pgsql.Addon, extension *Extension, diags *diag.Diagnostics) bool {
+	var schema, version string
+	err := addon.QueryRow(ctx, `
+		SELECT n.nspname, e.extversion FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = $1`,
+		extension.Name.ValueString(),
+	).Scan(&schema, &version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		diags.AddError("failed to read extension", err.Error())
+		return false
+	}
+
+	if extension.Database.IsNull() || extension.Database.IsUnknown() {
+		extension.Database = types.StringValue(addon.Credentials.Database)
+	}
+	extension.Schema = types.StringValue(schema)
+	extension.Version = types.StringValue(version)
+	return true
+}
+
+func knownValue(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+	return value.ValueString()
+}
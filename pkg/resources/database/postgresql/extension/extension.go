@@ -0,0 +1,36 @@
+package extension
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePostgreSQLExtension struct {
+	helper.Configurer
+}
+
+func NewResourcePostgreSQLExtension() resource.Resource {
+	return &ResourcePostgreSQLExtension{}
+}
+
+func (r *ResourcePostgreSQLExtension) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_postgresql_extension"
+}
+
+// ImportState expects <postgresql_id>/<database>/<extension name>
+func (r *ResourcePostgreSQLExtension) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <postgresql_id>/<database>/<extension name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("postgresql_id"), parts[0])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("database"), parts[1])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+}
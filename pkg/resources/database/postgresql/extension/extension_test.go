@@ -0,0 +1,59 @@
+package extension_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccPostgreSQLExtension_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-pg-ext")
+	fullName := "clevercloud_postgresql_extension.trigram"
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	postgresqlBlock := helper.NewRessource(
+		"clevercloud_postgresql",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "dev",
+		}))
+	extensionBlock := helper.NewRessource(
+		"clevercloud_postgresql_extension",
+		"trigram",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id": fmt.Sprintf("${clevercloud_postgresql.%s.id}", rName),
+			"name":          "pg_trgm",
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(postgresqlBlock, extensionBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("schema"), knownvalue.StringExact("public")),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("version"), knownvalue.StringRegexp(regexp.MustCompile(`^\d+\.\d+$`))),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				postgresqlBlock,
+				extensionBlock.SetOneValue("name", "not_an_extension"),
+			).String(),
+			ExpectError: regexp.MustCompile(`is not available on this addon`),
+		}},
+	})
+}
@@ -0,0 +1,76 @@
+package extension
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+type Extension struct {
+	ID           types.String `tfsdk:"id"`
+	PostgreSQLID types.String `tfsdk:"postgresql_id"`
+	Database     types.String `tfsdk:"database"`
+	Name         types.String `tfsdk:"name"`
+	Schema       types.String `tfsdk:"schema"`
+	Version      types.String `tfsdk:"version"`
+}
+
+// extension names as listed in pg_available_extensions (uuid-ossp, pg_trgm...)
+var nameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+//go:embed doc.md
+var resourcePostgreSQLExtensionDoc string
+
+func (r ResourcePostgreSQLExtension) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePostgreSQLExtensionDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Extension identifier: <postgresql_id>/<database>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"postgresql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PostgreSQL addon ID",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Database the extension is installed in, defaults to the database of the addon",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Extension name, as listed in pg_available_extensions",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be an extension name", nameRegex)},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Schema of the extension objects, defaults to the first schema of the search path",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"version": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Extension version, defaults to the default version of the addon",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
@@ -0,0 +1,282 @@
+package grant
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+// Create a new resource
+func (r *ResourcePostgreSQLGrant) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Grant](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if plan.Database.IsNull() || plan.Database.IsUnknown() {
+		plan.Database = types.StringValue(addon.Credentials.Database)
+	}
+
+	r.apply(ctx, addon, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	parts := []string{plan.PostgreSQLID.ValueString(), plan.Database.ValueString(), plan.Role.ValueString(), plan.ObjectType.ValueString()}
+	if !plan.Schema.IsNull() {
+		parts = append(parts, plan.Schema.ValueString())
+	}
+	plan.ID = types.StringValue(strings.Join(parts, "/"))
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePostgreSQLGrant) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	var exists bool
+	err := addon.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)`, state.Role.ValueString()).Scan(&exists)
+	if err != nil {
+		res.Diagnostics.AddError("failed to read role", err.Error())
+		return
+	}
+	if !exists {
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	target := r.target(ctx, addon, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	byObject, err := readPrivileges(ctx, addon, target, state.Role.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("failed to read privileges", err.Error())
+		return
+	}
+
+	objects := target.Objects
+	if target.ObjectType == ObjectTypeDatabase || target.ObjectType == ObjectTypeSchema || target.Default {
+		objects = []string{targetName(target)}
+	}
+	// an empty schema gives nothing to compare
+	if len(objects) > 0 || len(byObject) > 0 {
+		state.Privileges = pkg.FromSetString(CommonPrivileges(byObject, objects), &res.Diagnostics)
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourcePostgreSQLGrant) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Grant](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	// only privileges are updated in place
+	r.apply(ctx, addon, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourcePostgreSQLGrant) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Grant](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	target := r.target(ctx, addon, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	revoke, _ := target.Statements(state.Role.ValueString(), nil)
+	if _, err := addon.Exec(ctx, revoke); err != nil && !isGone(err) {
+		res.Diagnostics.AddError("failed to revoke privileges", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePostgreSQLGrant) connect(ctx context.Context, grant *Grant, diags *diag.Diagnostics) *pgsql.Addon {
+	addon, err := pgsql.Connect(ctx, r.Client(), r.Organization(), grant.PostgreSQLID.ValueString(), grant.Database.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to PostgreSQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+func (r *ResourcePostgreSQLGrant) target(ctx context.Context, addon *pgsql.Addon, grant *Grant, diags *diag.Diagnostics) Target {
+	target := Target{
+		ObjectType: grant.ObjectType.ValueString(),
+		Database:   grant.Database.ValueString(),
+		Schema:     grant.Schema.ValueString(),
+		Objects:    pkg.SetToStringSlice(ctx, grant.Objects, diags),
+		Default:    grant.DefaultPrivileges.ValueBool(),
+		ForRole:    grant.ForRole.ValueString(),
+	}
+	if target.Database == "" {
+		target.Database = addon.Credentials.Database
+	}
+	return target
+}
+
+// apply replaces the privileges of the role on the target
+func (r *ResourcePostgreSQLGrant) apply(ctx context.Context, addon *pgsql.Addon, grant *Grant, diags *diag.Diagnostics) {
+	target := r.target(ctx, addon, grant, diags)
+	privileges := pkg.SetToStringSlice(ctx, grant.Privileges, diags)
+	if diags.HasError() {
+		return
+	}
+
+	revoke, statement := target.Statements(grant.Role.ValueString(), privileges)
+	err := pgx.BeginFunc(ctx, addon, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, revoke); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, statement)
+		return err
+	})
+	if err != nil {
+		diags.AddError("failed to grant privileges", err.Error())
+	}
+}
+
+// readPrivileges returns the privileges explicitly granted to the role, by object.
+// Objects of the schema without privileges are listed with none.
+func readPrivileges(ctx context.Context, addon *pgsql.Addon, target Target, role string) (map[string][]string, error) {
+	const grantee = `(SELECT oid FROM pg_roles WHERE rolname = $2)`
+
+	var query string
+	args := []any{targetName(target), role}
+
+	switch {
+	case target.Default:
+		query = `SELECT n.nspname, a.privilege_type FROM pg_default_acl d
+			JOIN pg_namespace n ON n.oid = d.defaclnamespace
+			CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+			WHERE n.nspname = $1 AND a.grantee = ` + grantee + `
+			AND d.defaclobjtype::text = $3
+			AND d.defaclrole = (SELECT oid FROM pg_roles WHERE rolname = $4)`
+		forRole := target.ForRole
+		if forRole == "" {
+			forRole = addon.Credentials.User
+		}
+		args = append(args, defaultACLTypes[target.ObjectType], forRole)
+
+	case target.ObjectType == ObjectTypeDatabase:
+		query = `SELECT d.datname, a.privilege_type FROM pg_database d
+			CROSS JOIN LATERAL aclexplode(d.datacl) a
+			WHERE d.datname = $1 AND a.grantee = ` + grantee
+
+	case target.ObjectType == ObjectTypeSchema:
+		query = `SELECT n.nspname, a.privilege_type FROM pg_namespace n
+			CROSS JOIN LATERAL aclexplode(n.nspacl) a
+			WHERE n.nspname = $1 AND a.grantee = ` + grantee
+
+	case target.ObjectType == ObjectTypeFunction:
+		query = `SELECT p.proname, a.privilege_type FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+			LEFT JOIN LATERAL aclexplode(p.proacl) a ON a.grantee = ` + grantee + `
+			WHERE n.nspname = $1 AND p.prokind = 'f'`
+
+	default:
+		query = `SELECT c.relname, a.privilege_type FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			LEFT JOIN LATERAL aclexplode(c.relacl) a ON a.grantee = ` + grantee + `
+			WHERE n.nspname = $1 AND c.relkind::text = ANY($3)`
+		args = append(args, relationKinds[target.ObjectType])
+	}
+
+	rows, err := addon.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byObject := map[string][]string{}
+	var object string
+	var privilege *string
+	_, err = pgx.ForEachRow(rows, []any{&object, &privilege}, func() error {
+		privileges := byObject[object]
+		if privilege != nil {
+			privileges = append(privileges, *privilege)
+		}
+		byObject[object] = privileges
+		return nil
+	})
+	return byObject, err
+}
+
+// values of pg_class.relkind
+var relationKinds = map[string][]string{
+	// tables, partitioned tables, views, materialized views and foreign tables
+	ObjectTypeTable:    {"r", "p", "v", "m", "f"},
+	ObjectTypeSequence: {"S"},
+}
+
+// values of pg_default_acl.defaclobjtype
+var defaultACLTypes = map[string]string{
+	ObjectTypeTable:    "r",
+	ObjectTypeSequence: "S",
+	ObjectTypeFunction: "f",
+}
+
+// targetName is the object holding the privileges of a single object target
+func targetName(target Target) string {
+	if target.ObjectType == ObjectTypeDatabase {
+		return target.Database
+	}
+	return target.Schema
+}
+
+// isGone reports whether the role or the objects were already dropped
+func isGone(err error) bool {
+	return pgsql.IsCode(err, "42704") || // undefined_object
+		pgsql.IsCode(err, "3F000") || // invalid_schema_name
+		pgsql.IsCode(err, "42P01") || // undefined_table
+		pgsql.IsCode(err, "42883") // undefined_function
+}
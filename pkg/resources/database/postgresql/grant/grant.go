@@ -0,0 +1,25 @@
+package grant
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePostgreSQLGrant struct {
+	helper.Configurer
+}
+
+func NewResourcePostgreSQLGrant() resource.Resource {
+	return &ResourcePostgreSQLGrant{}
+}
+
+func (r *ResourcePostgreSQLGrant) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_postgresql_grant"
+}
+
+// ImportState is not supported, the grant is rebuilt from the configuration
+func (r *ResourcePostgreSQLGrant) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	res.Diagnostics.AddError("import is not supported", "declare the grant in the configuration, applying it again is harmless")
+}
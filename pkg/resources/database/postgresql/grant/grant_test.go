@@ -0,0 +1,98 @@
+package grant_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccPostgreSQLGrant_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-pg-grant")
+	postgresqlID := fmt.Sprintf("${clevercloud_postgresql.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	postgresqlBlock := helper.NewRessource(
+		"clevercloud_postgresql",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "xs_sml",
+		}))
+	roleBlock := helper.NewRessource(
+		"clevercloud_postgresql_role",
+		"reader",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id": postgresqlID,
+			"name":          "reader",
+			"password":      acctest.RandString(24),
+		}))
+	databaseBlock := helper.NewRessource(
+		"clevercloud_postgresql_database",
+		"main",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id": postgresqlID,
+			"schemas": map[string]any{
+				"app": fmt.Sprintf("${clevercloud_postgresql.%s.user}", rName),
+			},
+		}))
+	usageBlock := helper.NewRessource(
+		"clevercloud_postgresql_grant",
+		"usage",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id": postgresqlID,
+			"database":      "${clevercloud_postgresql_database.main.name}",
+			"role":          "${clevercloud_postgresql_role.reader.name}",
+			"object_type":   "schema",
+			"schema":        "app",
+			"privileges":    []string{"USAGE"},
+		}))
+	tablesBlock := helper.NewRessource(
+		"clevercloud_postgresql_grant",
+		"tables",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id":      postgresqlID,
+			"database":           "${clevercloud_postgresql_database.main.name}",
+			"role":               "${clevercloud_postgresql_role.reader.name}",
+			"object_type":        "table",
+			"schema":             "app",
+			"default_privileges": true,
+			"privileges":         []string{"SELECT"},
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(postgresqlBlock, roleBlock, databaseBlock, usageBlock, tablesBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_postgresql_database.main", tfjsonpath.New("schemas").AtMapKey("app"), knownvalue.NotNull()),
+				statecheck.ExpectKnownValue("clevercloud_postgresql_grant.usage", tfjsonpath.New("privileges"), knownvalue.SetExact([]knownvalue.Check{
+					knownvalue.StringExact("USAGE"),
+				})),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				postgresqlBlock,
+				roleBlock,
+				databaseBlock,
+				usageBlock,
+				tablesBlock.SetOneValue("privileges", []string{"SELECT", "INSERT"}),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_postgresql_grant.tables", tfjsonpath.New("privileges"), knownvalue.SetSizeExact(2)),
+			},
+		}},
+	})
+}
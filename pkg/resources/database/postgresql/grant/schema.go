@@ -0,0 +1,143 @@
+package grant
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type Grant struct {
+	ID                types.String `tfsdk:"id"`
+	PostgreSQLID      types.String `tfsdk:"postgresql_id"`
+	Database          types.String `tfsdk:"database"`
+	Role              types.String `tfsdk:"role"`
+	ObjectType        types.String `tfsdk:"object_type"`
+	Schema            types.String `tfsdk:"schema"`
+	Objects           types.Set    `tfsdk:"objects"`
+	Privileges        types.Set    `tfsdk:"privileges"`
+	DefaultPrivileges types.Bool   `tfsdk:"default_privileges"`
+	ForRole           types.String `tfsdk:"for_role"`
+}
+
+//go:embed doc.md
+var resourcePostgreSQLGrantDoc string
+
+func (r ResourcePostgreSQLGrant) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePostgreSQLGrantDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Grant identifier",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"postgresql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PostgreSQL addon ID",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Database of the objects, defaults to the database of the addon",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role receiving the privileges",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"object_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of the objects: database, schema, table, sequence or function",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf(ObjectTypes...)},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Schema of the objects, required for every type but database",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"objects": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
+				MarkdownDescription: "Tables, sequences or functions of the schema, all of them when empty",
+				PlanModifiers:       []planmodifier.Set{setplanmodifier.RequiresReplace()},
+			},
+			"privileges": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Privileges granted (SELECT, INSERT, USAGE...), the allowed ones depend on object_type",
+				Validators:          []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"default_privileges": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Grant the privileges on objects created later in the schema instead of existing ones",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"for_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "With default_privileges, role creating the objects, defaults to the addon user",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+		},
+	}
+}
+
+func (r ResourcePostgreSQLGrant) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	grant := Grant{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &grant)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if grant.ObjectType.IsUnknown() || grant.Privileges.IsUnknown() || grant.Objects.IsUnknown() {
+		return
+	}
+
+	privileges := []string{}
+	res.Diagnostics.Append(grant.Privileges.ElementsAs(ctx, &privileges, false)...)
+	objects := []string{}
+	if !grant.Objects.IsNull() {
+		res.Diagnostics.Append(grant.Objects.ElementsAs(ctx, &objects, false)...)
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	target := Target{
+		ObjectType: grant.ObjectType.ValueString(),
+		Schema:     grant.Schema.ValueString(),
+		Objects:    objects,
+		Default:    grant.DefaultPrivileges.ValueBool(),
+		ForRole:    grant.ForRole.ValueString(),
+	}
+	if grant.Schema.IsUnknown() {
+		target.Schema = "unknown"
+	}
+
+	if err := target.Validate(privileges); err != nil {
+		res.Diagnostics.AddError("invalid grant", err.Error())
+	}
+}
@@ -0,0 +1,138 @@
+package grant
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+const (
+	ObjectTypeDatabase = "database"
+	ObjectTypeSchema   = "schema"
+	ObjectTypeTable    = "table"
+	ObjectTypeSequence = "sequence"
+	ObjectTypeFunction = "function"
+)
+
+var ObjectTypes = []string{ObjectTypeDatabase, ObjectTypeSchema, ObjectTypeTable, ObjectTypeSequence, ObjectTypeFunction}
+
+// Privileges accepted by each object type
+var Privileges = map[string][]string{
+	ObjectTypeDatabase: {"CONNECT", "CREATE", "TEMPORARY"},
+	ObjectTypeSchema:   {"USAGE", "CREATE"},
+	ObjectTypeTable:    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	ObjectTypeSequence: {"USAGE", "SELECT", "UPDATE"},
+	ObjectTypeFunction: {"EXECUTE"},
+}
+
+// Target designates the objects a grant applies to
+type Target struct {
+	ObjectType string
+	// database name, for the database object type
+	Database string
+	Schema   string
+	// empty for all the objects of the schema
+	Objects []string
+	// grant on objects created later
+	Default bool
+	// role creating the objects, for default privileges
+	ForRole string
+}
+
+func (t Target) Validate(privileges []string) error {
+	allowed, ok := Privileges[t.ObjectType]
+	if !ok {
+		return fmt.Errorf("unknown object type '%s'", t.ObjectType)
+	}
+	for _, privilege := range privileges {
+		if !slices.Contains(allowed, privilege) {
+			return fmt.Errorf("privilege '%s' cannot be granted on a %s, expect one of %s", privilege, t.ObjectType, strings.Join(allowed, ", "))
+		}
+	}
+
+	onSchemaObjects := t.ObjectType != ObjectTypeDatabase && t.ObjectType != ObjectTypeSchema
+	switch {
+	case t.ObjectType == ObjectTypeDatabase && t.Schema != "":
+		return fmt.Errorf("schema cannot be set on a database grant")
+	case t.ObjectType != ObjectTypeDatabase && t.Schema == "":
+		return fmt.Errorf("schema is required on a %s grant", t.ObjectType)
+	case !onSchemaObjects && len(t.Objects) > 0:
+		return fmt.Errorf("objects can only be set on table, sequence and function grants")
+	case !onSchemaObjects && t.Default:
+		return fmt.Errorf("default_privileges can only be set on table, sequence and function grants")
+	case t.Default && len(t.Objects) > 0:
+		return fmt.Errorf("objects cannot be set with default_privileges, they apply to objects created later")
+	case !t.Default && t.ForRole != "":
+		return fmt.Errorf("for_role requires default_privileges")
+	}
+
+	return nil
+}
+
+// Statements returns the statements revoking every privilege of the role on the target,
+// and granting it the given privileges
+func (t Target) Statements(role string, privileges []string) (revoke, grant string) {
+	grantee := pgsql.Ident(role)
+	list := strings.Join(privileges, ", ")
+
+	if t.Default {
+		prefix := "ALTER DEFAULT PRIVILEGES"
+		if t.ForRole != "" {
+			prefix += " FOR ROLE " + pgsql.Ident(t.ForRole)
+		}
+		prefix += " IN SCHEMA " + pgsql.Ident(t.Schema)
+		objects := strings.ToUpper(t.ObjectType) + "S"
+
+		return prefix + " REVOKE ALL ON " + objects + " FROM " + grantee,
+			prefix + " GRANT " + list + " ON " + objects + " TO " + grantee
+	}
+
+	on := t.on()
+	return "REVOKE ALL ON " + on + " FROM " + grantee,
+		"GRANT " + list + " ON " + on + " TO " + grantee
+}
+
+func (t Target) on() string {
+	switch t.ObjectType {
+	case ObjectTypeDatabase:
+		return "DATABASE " + pgsql.Ident(t.Database)
+	case ObjectTypeSchema:
+		return "SCHEMA " + pgsql.Ident(t.Schema)
+	}
+
+	kind := strings.ToUpper(t.ObjectType)
+	if len(t.Objects) == 0 {
+		return "ALL " + kind + "S IN SCHEMA " + pgsql.Ident(t.Schema)
+	}
+
+	objects := pkg.Map(t.Objects, func(object string) string {
+		return pgsql.Ident(t.Schema, object)
+	})
+	return kind + " " + strings.Join(objects, ", ")
+}
+
+// CommonPrivileges returns the privileges held on every object,
+// an object missing from byObject holds none.
+func CommonPrivileges(byObject map[string][]string, objects []string) []string {
+	if len(objects) == 0 {
+		for object := range byObject {
+			objects = append(objects, object)
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+
+	common := slices.Clone(byObject[objects[0]])
+	for _, object := range objects[1:] {
+		common = pkg.Filter(common, func(privilege string) bool {
+			return slices.Contains(byObject[object], privilege)
+		})
+	}
+
+	slices.Sort(common)
+	return slices.Compact(common)
+}
@@ -0,0 +1,116 @@
+package grant
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTargetStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     Target
+		privileges []string
+		revoke     string
+		grant      string
+	}{{
+		name:       "database",
+		target:     Target{ObjectType: ObjectTypeDatabase, Database: "app"},
+		privileges: []string{"CONNECT", "TEMPORARY"},
+		revoke:     `REVOKE ALL ON DATABASE "app" FROM "reader"`,
+		grant:      `GRANT CONNECT, TEMPORARY ON DATABASE "app" TO "reader"`,
+	}, {
+		name:       "schema",
+		target:     Target{ObjectType: ObjectTypeSchema, Schema: "app"},
+		privileges: []string{"USAGE"},
+		revoke:     `REVOKE ALL ON SCHEMA "app" FROM "reader"`,
+		grant:      `GRANT USAGE ON SCHEMA "app" TO "reader"`,
+	}, {
+		name:       "all tables",
+		target:     Target{ObjectType: ObjectTypeTable, Schema: "app"},
+		privileges: []string{"SELECT"},
+		revoke:     `REVOKE ALL ON ALL TABLES IN SCHEMA "app" FROM "reader"`,
+		grant:      `GRANT SELECT ON ALL TABLES IN SCHEMA "app" TO "reader"`,
+	}, {
+		name:       "some sequences",
+		target:     Target{ObjectType: ObjectTypeSequence, Schema: "app", Objects: []string{"a_seq", "b_seq"}},
+		privileges: []string{"USAGE", "SELECT"},
+		revoke:     `REVOKE ALL ON SEQUENCE "app"."a_seq", "app"."b_seq" FROM "reader"`,
+		grant:      `GRANT USAGE, SELECT ON SEQUENCE "app"."a_seq", "app"."b_seq" TO "reader"`,
+	}, {
+		name:       "default privileges",
+		target:     Target{ObjectType: ObjectTypeFunction, Schema: "app", Default: true, ForRole: "migrator"},
+		privileges: []string{"EXECUTE"},
+		revoke:     `ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" IN SCHEMA "app" REVOKE ALL ON FUNCTIONS FROM "reader"`,
+		grant:      `ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" IN SCHEMA "app" GRANT EXECUTE ON FUNCTIONS TO "reader"`,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoke, grant := tt.target.Statements("reader", tt.privileges)
+			if revoke != tt.revoke {
+				t.Errorf("expect revoke %s, got %s", tt.revoke, revoke)
+			}
+			if grant != tt.grant {
+				t.Errorf("expect grant %s, got %s", tt.grant, grant)
+			}
+		})
+	}
+}
+
+func TestTargetValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     Target
+		privileges []string
+		valid      bool
+	}{
+		{"database", Target{ObjectType: ObjectTypeDatabase}, []string{"CONNECT"}, true},
+		{"database with schema", Target{ObjectType: ObjectTypeDatabase, Schema: "app"}, []string{"CONNECT"}, false},
+		{"table privilege on schema", Target{ObjectType: ObjectTypeSchema, Schema: "app"}, []string{"SELECT"}, false},
+		{"table without schema", Target{ObjectType: ObjectTypeTable}, []string{"SELECT"}, false},
+		{"objects on schema", Target{ObjectType: ObjectTypeSchema, Schema: "app", Objects: []string{"a"}}, []string{"USAGE"}, false},
+		{"default with objects", Target{ObjectType: ObjectTypeTable, Schema: "app", Objects: []string{"a"}, Default: true}, []string{"SELECT"}, false},
+		{"for role without default", Target{ObjectType: ObjectTypeTable, Schema: "app", ForRole: "migrator"}, []string{"SELECT"}, false},
+		{"default tables", Target{ObjectType: ObjectTypeTable, Schema: "app", Default: true, ForRole: "migrator"}, []string{"SELECT", "INSERT"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.Validate(tt.privileges)
+			if tt.valid && err != nil {
+				t.Errorf("expect valid target, got %s", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expect invalid target")
+			}
+		})
+	}
+}
+
+func TestCommonPrivileges(t *testing.T) {
+	byObject := map[string][]string{
+		"users":  {"SELECT", "INSERT"},
+		"orders": {"INSERT", "SELECT", "UPDATE"},
+		"logs":   nil,
+	}
+
+	tests := []struct {
+		name     string
+		objects  []string
+		expected []string
+	}{
+		{"listed objects", []string{"users", "orders"}, []string{"INSERT", "SELECT"}},
+		{"all objects", nil, []string{}},
+		{"missing object", []string{"users", "missing"}, []string{}},
+		{"single object", []string{"orders"}, []string{"INSERT", "SELECT", "UPDATE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CommonPrivileges(byObject, tt.objects)
+			if !slices.Equal(got, tt.expected) && !(len(got) == 0 && len(tt.expected) == 0) {
+				t.Errorf("expect %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"go.clever-cloud.com/terraform-provider/pkg"
 	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
 	"go.clever-cloud.com/terraform-provider/pkg/resources/addon"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
 )
@@ -124,6 +125,7 @@ func (r *ResourcePostgreSQL) UpgradeState(ctx context.Context) map[int64]resourc
 }
 
 // ModifyPlan validates that encryption, backup, and locale options are only used with dedicated plans
+// and that the client tools used by restore_from_backup and final_snapshot are installed
 func (r *ResourcePostgreSQL) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
 	tflog.Debug(ctx, "ModifyPlan called for PostgreSQL")
 
@@ -136,6 +138,16 @@ func (r *ResourcePostgreSQL) ModifyPlan(ctx context.Context, req resource.Modify
 		return
 	}
 
+	// the backup is only restored on creation, fail before creating the addon
+	if req.State.Raw.IsNull() && plan.RestoreFromBackup != nil {
+		if err := pgsql.LookPgRestore(); err != nil {
+			res.Diagnostics.AddAttributeError(path.Root("restore_from_backup"), "cannot restore backup", err.Error())
+		}
+	}
+	if plan.FinalSnapshot != nil {
+		plan.FinalSnapshot.ValidateTool("pg_dump", &res.Diagnostics)
+	}
+
 	// Skip validation if provider not configured yet
 	if r.Client() == nil {
 		tflog.Debug(ctx, "Skipping validation - provider not configured")
@@ -0,0 +1,225 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	pgx "github.com/jackc/pgx/v5"
+	"go.clever-cloud.com/terraform-provider/pkg"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pgsql"
+)
+
+// Create a new resource
+func (r *ResourcePostgreSQLRole) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Role](ctx, req.Plan, &res.Diagnostics)
+	// write-only values are only available in the configuration
+	config := helper.ConfigFrom[Role](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	memberOf := pkg.SetToStringSlice(ctx, plan.MemberOf, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := pgx.BeginFunc(ctx, addon, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "CREATE ROLE "+pgsql.Ident(plan.Name.ValueString())+" WITH "+roleOptions(&plan, config.PasswordWO, true)); err != nil {
+			return err
+		}
+		if len(memberOf) > 0 {
+			_, err := tx.Exec(ctx, "GRANT "+pgsql.Idents(memberOf)+" TO "+pgsql.Ident(plan.Name.ValueString()))
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		res.Diagnostics.AddError("failed to create role", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(plan.PostgreSQLID.ValueString() + "/" + plan.Name.ValueString())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePostgreSQLRole) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	var connectionLimit int32
+	var login, inherit, createDatabase bool
+	err := addon.QueryRow(ctx,
+		`SELECT rolcanlogin, rolinherit, rolcreatedb, rolconnlimit FROM pg_roles WHERE rolname = $1`,
+		state.Name.ValueString(),
+	).Scan(&login, &inherit, &createDatabase, &connectionLimit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read role", err.Error())
+		return
+	}
+
+	rows, err := addon.Query(ctx, `
+		SELECT granted.rolname FROM pg_auth_members m
+		JOIN pg_roles granted ON granted.oid = m.roleid
+		JOIN pg_roles member ON member.oid = m.member
+		WHERE member.rolname = $1`,
+		state.Name.ValueString(),
+	)
+	if err != nil {
+		res.Diagnostics.AddError("failed to read role memberships", err.Error())
+		return
+	}
+	memberOf, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		res.Diagnostics.AddError("failed to read role memberships", err.Error())
+		return
+	}
+
+	state.Login = types.BoolValue(login)
+	state.Inherit = types.BoolValue(inherit)
+	state.CreateDatabase = types.BoolValue(createDatabase)
+	state.ConnectionLimit = types.Int64Value(int64(connectionLimit))
+	state.MemberOf = pkg.FromSetString(memberOf, &res.Diagnostics)
+
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource
+func (r *ResourcePostgreSQLRole) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Role](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	config := helper.ConfigFrom[Role](ctx, req.Config, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	wanted := pkg.SetToStringSlice(ctx, plan.MemberOf, &res.Diagnostics)
+	current := pkg.SetToStringSlice(ctx, state.MemberOf, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	grant, revoke := pkg.Diff(wanted, current), pkg.Diff(current, wanted)
+
+	name := pgsql.Ident(plan.Name.ValueString())
+	err := pgx.BeginFunc(ctx, addon, func(tx pgx.Tx) error {
+		// the password is only sent when its version changes
+		setPassword := !plan.PasswordWOVersion.Equal(state.PasswordWOVersion)
+		if _, err := tx.Exec(ctx, "ALTER ROLE "+name+" WITH "+roleOptions(&plan, config.PasswordWO, setPassword)); err != nil {
+			return err
+		}
+		if len(revoke) > 0 {
+			if _, err := tx.Exec(ctx, "REVOKE "+pgsql.Idents(revoke)+" FROM "+name); err != nil {
+				return err
+			}
+		}
+		if len(grant) > 0 {
+			if _, err := tx.Exec(ctx, "GRANT "+pgsql.Idents(grant)+" TO "+name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		res.Diagnostics.AddError("failed to update role", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource
+func (r *ResourcePostgreSQLRole) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Role](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	defer addon.Close(ctx)
+
+	if _, err := addon.Exec(ctx, "DROP ROLE IF EXISTS "+pgsql.Ident(state.Name.ValueString())); err != nil {
+		// 2BP01: dependent_objects_still_exist
+		if pgsql.IsCode(err, "2BP01") {
+			res.Diagnostics.AddError(
+				"role still owns objects or has privileges",
+				fmt.Sprintf("%s\ntransfer its objects (REASSIGN OWNED BY) and remove its grants before deleting it", err.Error()),
+			)
+			return
+		}
+		res.Diagnostics.AddError("failed to drop role", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePostgreSQLRole) connect(ctx context.Context, role *Role, diags *diag.Diagnostics) *pgsql.Addon {
+	addon, err := pgsql.Connect(ctx, r.Client(), r.Organization(), role.PostgreSQLID.ValueString(), "")
+	if err != nil {
+		diags.AddError("failed to connect to PostgreSQL addon", err.Error())
+		return nil
+	}
+	return addon
+}
+
+// roleOptions renders the CREATE/ALTER ROLE options, the password comes from
+// the configuration since it is write-only
+func roleOptions(role *Role, password types.String, withPassword bool) string {
+	options := []string{
+		pick(role.Login.ValueBool(), "LOGIN", "NOLOGIN"),
+		pick(role.Inherit.ValueBool(), "INHERIT", "NOINHERIT"),
+		pick(role.CreateDatabase.ValueBool(), "CREATEDB", "NOCREATEDB"),
+		fmt.Sprintf("CONNECTION LIMIT %d", role.ConnectionLimit.ValueInt64()),
+	}
+
+	if withPassword {
+		if password.IsNull() {
+			options = append(options, "PASSWORD NULL")
+		} else {
+			options = append(options, "PASSWORD "+pgsql.Literal(password.ValueString()))
+		}
+	}
+
+	return strings.Join(options, " ")
+}
+
+func pick(condition bool, ifTrue, ifFalse string) string {
+	if condition {
+		return ifTrue
+	}
+	return ifFalse
+}
@@ -0,0 +1,35 @@
+package role
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePostgreSQLRole struct {
+	helper.Configurer
+}
+
+func NewResourcePostgreSQLRole() resource.Resource {
+	return &ResourcePostgreSQLRole{}
+}
+
+func (r *ResourcePostgreSQLRole) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_postgresql_role"
+}
+
+// ImportState expects <postgresql_id>/<role name>
+func (r *ResourcePostgreSQLRole) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	postgresqlID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || postgresqlID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <postgresql_id>/<role name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("postgresql_id"), postgresqlID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
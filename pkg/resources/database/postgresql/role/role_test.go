@@ -0,0 +1,80 @@
+package role_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccPostgreSQLRole_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-pg-role")
+	fullName := "clevercloud_postgresql_role.reporting"
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	postgresqlBlock := helper.NewRessource(
+		"clevercloud_postgresql",
+		rName,
+		helper.SetKeyValues(map[string]any{
+			"name":   rName,
+			"region": "par",
+			"plan":   "xs_sml",
+		}))
+	readersBlock := helper.NewRessource(
+		"clevercloud_postgresql_role",
+		"readers",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id": fmt.Sprintf("${clevercloud_postgresql.%s.id}", rName),
+			"name":          "readers",
+			"login":         false,
+		}))
+	roleBlock := helper.NewRessource(
+		"clevercloud_postgresql_role",
+		"reporting",
+		helper.SetKeyValues(map[string]any{
+			"postgresql_id":       fmt.Sprintf("${clevercloud_postgresql.%s.id}", rName),
+			"name":                "reporting",
+			"password_wo":         acctest.RandString(24),
+			"password_wo_version": 1,
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(postgresqlBlock, readersBlock, roleBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("login"), knownvalue.Bool(true)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("connection_limit"), knownvalue.Int64Exact(-1)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("member_of"), knownvalue.SetSizeExact(0)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("password_wo"), knownvalue.Null()),
+			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				postgresqlBlock,
+				readersBlock,
+				roleBlock.
+					SetOneValue("connection_limit", 5).
+					SetOneValue("member_of", []string{"${clevercloud_postgresql_role.readers.name}"}).
+					SetOneValue("password_wo", acctest.RandString(24)).
+					SetOneValue("password_wo_version", 2),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("connection_limit"), knownvalue.Int64Exact(5)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("member_of"), knownvalue.SetExact([]knownvalue.Check{
+					knownvalue.StringExact("readers"),
+				})),
+			},
+		}},
+	})
+}
@@ -0,0 +1,105 @@
+package role
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+type Role struct {
+	ID                types.String `tfsdk:"id"`
+	PostgreSQLID      types.String `tfsdk:"postgresql_id"`
+	Name              types.String `tfsdk:"name"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Login             types.Bool   `tfsdk:"login"`
+	Inherit           types.Bool   `tfsdk:"inherit"`
+	CreateDatabase    types.Bool   `tfsdk:"create_database"`
+	ConnectionLimit   types.Int64  `tfsdk:"connection_limit"`
+	MemberOf          types.Set    `tfsdk:"member_of"`
+}
+
+// NameRegex keeps role names usable without quoting
+var NameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+//go:embed doc.md
+var resourcePostgreSQLRoleDoc string
+
+func (r ResourcePostgreSQLRole) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePostgreSQLRoleDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role identifier: <postgresql_id>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"postgresql_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "PostgreSQL addon ID the role is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role name, lowercase letters, digits and underscores",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a lowercase role name", NameRegex)},
+			},
+			"password_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Role password, required to log in, never stored in the state (requires Terraform 1.11+)",
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Change this value to apply a new `password_wo`",
+			},
+			"login": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Allow the role to log in, disable it for group roles",
+			},
+			"inherit": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Inherit the privileges of the roles it is a member of",
+			},
+			"create_database": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Allow the role to create databases",
+			},
+			"connection_limit": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(-1),
+				MarkdownDescription: "Maximum concurrent connections of the role, -1 for no limit",
+				Validators:          []validator.Int64{int64validator.AtLeast(-1)},
+			},
+			"member_of": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, nil)),
+				MarkdownDescription: "Roles this role is granted, to inherit their privileges",
+			},
+		},
+	}
+}
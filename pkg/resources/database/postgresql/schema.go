@@ -38,6 +38,14 @@ type PostgreSQL struct {
 	Encryption     types.Bool   `tfsdk:"encryption"`
 	DirectHostOnly types.Bool   `tfsdk:"direct_host_only"`
 	Locale         types.String `tfsdk:"locale"`
+
+	RestoreFromBackup *RestoreFromBackup   `tfsdk:"restore_from_backup"`
+	FinalSnapshot     *addon.FinalSnapshot `tfsdk:"final_snapshot"`
+}
+
+type RestoreFromBackup struct {
+	PostgreSQLID types.String `tfsdk:"postgresql_id"`
+	Backup       types.String `tfsdk:"backup"`
 }
 
 //go:embed doc.md
@@ -82,6 +90,20 @@ func (r ResourcePostgreSQL) Schema(_ context.Context, req resource.SchemaRequest
 				MarkdownDescription: "Connect directly to the database host, bypassing the reverse proxy. Lower latency but no automatic failover on migration.",
 				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown(), boolplanmodifier.RequiresReplace()},
 			},
+			"restore_from_backup": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Restore a backup into the database when the addon is created, changes are ignored afterwards. `pg_restore` must be installed where Terraform runs.",
+				Attributes: map[string]schema.Attribute{
+					"postgresql_id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "PostgreSQL addon ID the backup comes from",
+					},
+					"backup": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Backup UUID, RFC3339 date to take the most recent backup before it, or `latest` (default)",
+					},
+				},
+			},
 			"locale": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -95,6 +117,7 @@ func (r ResourcePostgreSQL) Schema(_ context.Context, req resource.SchemaRequest
 					),
 				},
 			},
+			"final_snapshot": addon.FinalSnapshotAttribute,
 		}),
 	}
 }
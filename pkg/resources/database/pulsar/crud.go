@@ -71,6 +71,7 @@ func (r *ResourcePulsar) Create(ctx context.Context, req resource.CreateRequest,
 	readCluster(&plan, pulsarCluster, &resp.Diagnostics)
 
 	setRetention(ctx, &plan, &resp.Diagnostics)
+	setPolicies(ctx, &plan, nil, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
 	if resp.Diagnostics.HasError() {
@@ -119,6 +120,7 @@ func (r *ResourcePulsar) Read(ctx context.Context, req resource.ReadRequest, res
 	readOldAddon(&state, addon, &resp.Diagnostics)
 
 	readRetention(ctx, &state, &resp.Diagnostics)
+	readPolicies(ctx, &state, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
 }
@@ -249,6 +251,10 @@ func (r *ResourcePulsar) Update(ctx context.Context, req resource.UpdateRequest,
 	state.RetentionPeriod = plan.RetentionPeriod
 	state.RetentionSize = plan.RetentionSize
 	setRetention(ctx, &state, &resp.Diagnostics)
+
+	prior := state.Policies
+	state.Policies = plan.Policies
+	setPolicies(ctx, &state, prior, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	} else {
@@ -0,0 +1,411 @@
+package pulsar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/admin"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/admin/auth"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/admin/config"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/rest"
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Policies of the addon namespace, applied to all its topics.
+// Only the configured policies are read back, a removed policy is reset to the Pulsar default.
+type Policies struct {
+	MessageTTL           types.Int64   `tfsdk:"message_ttl"`
+	BacklogQuota         *BacklogQuota `tfsdk:"backlog_quota"`
+	Deduplication        types.Bool    `tfsdk:"deduplication"`
+	MaxProducersPerTopic types.Int64   `tfsdk:"max_producers_per_topic"`
+	MaxConsumersPerTopic types.Int64   `tfsdk:"max_consumers_per_topic"`
+	DispatchRate         *Rate         `tfsdk:"dispatch_rate"`
+	PublishRate          *Rate         `tfsdk:"publish_rate"`
+	SchemaCompatibility  types.String  `tfsdk:"schema_compatibility"`
+	OffloadThreshold     types.Int64   `tfsdk:"offload_threshold"`
+	OffloadDeletionLag   types.Int64   `tfsdk:"offload_deletion_lag"`
+}
+
+type BacklogQuota struct {
+	Limit  types.Int64  `tfsdk:"limit"`
+	Policy types.String `tfsdk:"policy"`
+}
+
+// Rate per second, an unset limit is unlimited
+type Rate struct {
+	Messages types.Int64 `tfsdk:"messages"`
+	Bytes    types.Int64 `tfsdk:"bytes"`
+}
+
+func (q *BacklogQuota) Equal(other *BacklogQuota) bool {
+	if q == nil || other == nil {
+		return q == other
+	}
+	return q.Limit.Equal(other.Limit) && q.Policy.Equal(other.Policy)
+}
+
+func (r *Rate) Equal(other *Rate) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+	return r.Messages.Equal(other.Messages) && r.Bytes.Equal(other.Bytes)
+}
+
+func rateAttribute(description string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		Attributes: map[string]schema.Attribute{
+			"messages": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Messages per second",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("bytes")),
+				},
+			},
+			"bytes": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Bytes per second",
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+	}
+}
+
+var PoliciesAttribute = schema.SingleNestedAttribute{
+	Optional:            true,
+	MarkdownDescription: "Namespace policies, applied to all the topics of the addon",
+	Attributes: map[string]schema.Attribute{
+		"message_ttl": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Seconds after which unacknowledged messages are acknowledged automatically",
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"backlog_quota": schema.SingleNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum size of the unacknowledged messages of a topic",
+			Attributes: map[string]schema.Attribute{
+				"limit": schema.Int64Attribute{
+					Required:            true,
+					MarkdownDescription: "Backlog size limit in bytes",
+					Validators:          []validator.Int64{int64validator.AtLeast(1)},
+				},
+				"policy": schema.StringAttribute{
+					Required: true,
+					MarkdownDescription: "What to do when the limit is reached: `producer_request_hold` (block producers), " +
+						"`producer_exception` (reject messages) or `consumer_backlog_eviction` (drop the oldest messages)",
+					Validators: []validator.String{stringvalidator.OneOf(
+						utils.ProducerRequestHold.String(),
+						utils.ProducerException.String(),
+						utils.ConsumerBacklogEviction.String(),
+					)},
+				},
+			},
+		},
+		"deduplication": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: "Drop messages already published by a producer",
+		},
+		"max_producers_per_topic": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum number of producers of a topic, 0 for unlimited",
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"max_consumers_per_topic": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum number of consumers of a topic, 0 for unlimited",
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"dispatch_rate": rateAttribute("Maximum rate of messages sent to the consumers of a topic"),
+		"publish_rate":  rateAttribute("Maximum rate of messages received from the producers of a topic"),
+		"schema_compatibility": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Which schema changes are accepted on the topics (`BACKWARD`, `FULL`...), a topic can override it",
+			Validators: []validator.String{stringvalidator.OneOf(
+				utils.SchemaCompatibilityStrategyAlwaysIncompatible.String(),
+				utils.SchemaCompatibilityStrategyAlwaysCompatible.String(),
+				utils.SchemaCompatibilityStrategyBackward.String(),
+				utils.SchemaCompatibilityStrategyForward.String(),
+				utils.SchemaCompatibilityStrategyFull.String(),
+				utils.SchemaCompatibilityStrategyBackwardTransitive.String(),
+				utils.SchemaCompatibilityStrategyForwardTransitive.String(),
+				utils.SchemaCompatibilityStrategyFullTransitive.String(),
+			)},
+		},
+		"offload_threshold": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Topic size in bytes above which messages are moved to the cold storage, 0 to offload as soon as possible",
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+		"offload_deletion_lag": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Seconds offloaded messages are kept on the brokers",
+			Validators:          []validator.Int64{int64validator.AtLeast(0)},
+		},
+	},
+}
+
+// setPolicies applies the policies which changed since prior (nil on creation)
+func setPolicies(ctx context.Context, plan *Pulsar, prior *Policies, diags *diag.Diagnostics) {
+	policies := plan.Policies
+	if policies == nil {
+		policies = &Policies{}
+	}
+	if prior == nil {
+		prior = &Policies{}
+	}
+
+	admin, err := plan.AdminClient()
+	if err != nil {
+		diags.AddError("failed to create Pulsar admin client", err.Error())
+		return
+	}
+	namespaces := admin.Namespaces()
+
+	namespace := plan.TenantAndNamespace()
+	ns, err := utils.GetNamespaceName(namespace)
+	if err != nil {
+		diags.AddError("invalid Pulsar namespace", err.Error())
+		return
+	}
+
+	apply := func(policy string, err error) {
+		tflog.Debug(ctx, "SetPolicy", map[string]any{"policy": policy, "tenantNs": namespace})
+		if err != nil {
+			diags.AddError(fmt.Sprintf("failed to set Pulsar %s", policy), err.Error())
+		}
+	}
+
+	// unset values are the Pulsar defaults: no TTL, no limit
+	if !policies.MessageTTL.Equal(prior.MessageTTL) {
+		apply("message TTL", namespaces.SetNamespaceMessageTTL(namespace, int(policies.MessageTTL.ValueInt64())))
+	}
+
+	if !policies.BacklogQuota.Equal(prior.BacklogQuota) {
+		if quota := policies.BacklogQuota; quota != nil {
+			backlogQuota := utils.NewBacklogQuota(quota.Limit.ValueInt64(), -1, utils.RetentionPolicy(quota.Policy.ValueString()))
+			apply("backlog quota", namespaces.SetBacklogQuota(namespace, backlogQuota, utils.DestinationStorage))
+		} else {
+			apply("backlog quota", namespaces.RemoveBacklogQuota(namespace))
+		}
+	}
+
+	// removed: the broker default applies again
+	if !policies.Deduplication.Equal(prior.Deduplication) {
+		if policies.Deduplication.IsNull() {
+			apply("deduplication", plan.removeDeduplication(ctx, ns))
+		} else {
+			apply("deduplication", namespaces.SetDeduplicationStatus(namespace, policies.Deduplication.ValueBool()))
+		}
+	}
+
+	if !policies.MaxProducersPerTopic.Equal(prior.MaxProducersPerTopic) {
+		apply("max producers per topic", namespaces.SetMaxProducersPerTopic(*ns, int(policies.MaxProducersPerTopic.ValueInt64())))
+	}
+
+	if !policies.MaxConsumersPerTopic.Equal(prior.MaxConsumersPerTopic) {
+		apply("max consumers per topic", namespaces.SetMaxConsumersPerTopic(*ns, int(policies.MaxConsumersPerTopic.ValueInt64())))
+	}
+
+	if !policies.DispatchRate.Equal(prior.DispatchRate) {
+		messages, bytes := policies.DispatchRate.limits()
+		apply("dispatch rate", namespaces.SetDispatchRate(*ns, utils.DispatchRate{
+			DispatchThrottlingRateInMsg:  int(messages),
+			DispatchThrottlingRateInByte: bytes,
+			RatePeriodInSecond:           1,
+		}))
+	}
+
+	if !policies.PublishRate.Equal(prior.PublishRate) {
+		messages, bytes := policies.PublishRate.limits()
+		apply("publish rate", namespaces.SetPublishRate(*ns, utils.PublishRate{
+			PublishThrottlingRateInMsg:  int(messages),
+			PublishThrottlingRateInByte: bytes,
+		}))
+	}
+
+	if !policies.SchemaCompatibility.Equal(prior.SchemaCompatibility) {
+		strategy := utils.SchemaCompatibilityStrategyUndefined
+		if !policies.SchemaCompatibility.IsNull() {
+			strategy = utils.SchemaCompatibilityStrategy(policies.SchemaCompatibility.ValueString())
+		}
+		apply("schema compatibility", namespaces.SetSchemaCompatibilityStrategy(*ns, strategy))
+	}
+
+	if !policies.OffloadThreshold.Equal(prior.OffloadThreshold) {
+		threshold := int64(-1)
+		if !policies.OffloadThreshold.IsNull() {
+			threshold = policies.OffloadThreshold.ValueInt64()
+		}
+		apply("offload threshold", namespaces.SetOffloadThreshold(*ns, threshold))
+	}
+
+	if !policies.OffloadDeletionLag.Equal(prior.OffloadDeletionLag) {
+		if policies.OffloadDeletionLag.IsNull() {
+			apply("offload deletion lag", namespaces.ClearOffloadDeleteLag(*ns))
+		} else {
+			apply("offload deletion lag", namespaces.SetOffloadDeleteLag(*ns, policies.OffloadDeletionLag.ValueInt64()*1000))
+		}
+	}
+}
+
+// removeDeduplication clears the deduplication override of the namespace,
+// the admin client only sets it
+func (p *Pulsar) removeDeduplication(ctx context.Context, ns *utils.NameSpaceName) error {
+	cfg := &pulsaradmin.Config{WebServiceURL: p.HTTPUrl.ValueString(), Token: p.Token.ValueString()}
+	provider, err := auth.GetAuthProvider(cfg)
+	if err != nil {
+		return err
+	}
+
+	client := &rest.Client{
+		ServiceURL:  cfg.WebServiceURL,
+		VersionInfo: admin.ReleaseVersion,
+		HTTPClient:  &http.Client{Timeout: admin.DefaultHTTPTimeOutDuration, Transport: provider},
+	}
+	return client.DeleteWithContext(ctx, utils.MakeHTTPPath(config.V2.String(), "/namespaces/"+ns.String()+"/deduplication"))
+}
+
+// readPolicies refreshes the configured policies
+func readPolicies(ctx context.Context, state *Pulsar, diags *diag.Diagnostics) {
+	policies := state.Policies
+	if policies == nil {
+		return
+	}
+
+	admin, err := state.AdminClient()
+	if err != nil {
+		diags.AddError("failed to create Pulsar admin client", err.Error())
+		return
+	}
+
+	current, err := admin.Namespaces().GetPolicies(state.TenantAndNamespace())
+	if err != nil {
+		diags.AddError("failed to get Pulsar namespace policies", err.Error())
+		return
+	}
+	tflog.Debug(ctx, "ReadPolicies", map[string]any{"policies": current})
+
+	if !policies.MessageTTL.IsNull() {
+		policies.MessageTTL = types.Int64Value(int64(valueOrZero(current.MessageTTLInSeconds)))
+	}
+
+	if policies.BacklogQuota != nil {
+		if quota, ok := current.BacklogQuotaMap[utils.DestinationStorage]; ok {
+			policies.BacklogQuota = &BacklogQuota{
+				Limit:  types.Int64Value(quota.LimitSize),
+				Policy: types.StringValue(quota.Policy.String()),
+			}
+		} else {
+			policies.BacklogQuota = nil
+		}
+	}
+
+	// unset on the namespace: the broker default applies
+	if !policies.Deduplication.IsNull() {
+		if current.DeduplicationEnabled != nil {
+			policies.Deduplication = types.BoolValue(*current.DeduplicationEnabled)
+		} else {
+			policies.Deduplication = types.BoolNull()
+		}
+	}
+
+	if !policies.MaxProducersPerTopic.IsNull() {
+		policies.MaxProducersPerTopic = types.Int64Value(int64(valueOrZero(current.MaxProducersPerTopic)))
+	}
+
+	if !policies.MaxConsumersPerTopic.IsNull() {
+		policies.MaxConsumersPerTopic = types.Int64Value(int64(valueOrZero(current.MaxConsumersPerTopic)))
+	}
+
+	// rates are set for each cluster, the addon namespace lives on a single one
+	if policies.DispatchRate != nil {
+		policies.DispatchRate = nil
+		for _, rate := range current.TopicDispatchRate {
+			policies.DispatchRate = rateFrom(int64(rate.DispatchThrottlingRateInMsg), rate.DispatchThrottlingRateInByte)
+		}
+	}
+
+	if policies.PublishRate != nil {
+		policies.PublishRate = nil
+		for _, rate := range current.PublishMaxMessageRate {
+			policies.PublishRate = rateFrom(int64(rate.PublishThrottlingRateInMsg), rate.PublishThrottlingRateInByte)
+		}
+	}
+
+	if !policies.SchemaCompatibility.IsNull() {
+		switch strategy := current.SchemaCompatibilityStrategy; strategy {
+		case "", utils.SchemaCompatibilityStrategyUndefined:
+			policies.SchemaCompatibility = types.StringNull()
+		default:
+			policies.SchemaCompatibility = types.StringValue(strategy.String())
+		}
+	}
+
+	if !policies.OffloadThreshold.IsNull() {
+		if current.OffloadThreshold < 0 {
+			policies.OffloadThreshold = types.Int64Null()
+		} else {
+			policies.OffloadThreshold = types.Int64Value(current.OffloadThreshold)
+		}
+	}
+
+	if !policies.OffloadDeletionLag.IsNull() {
+		if current.OffloadDeletionLagMs == nil {
+			policies.OffloadDeletionLag = types.Int64Null()
+		} else {
+			policies.OffloadDeletionLag = types.Int64Value(*current.OffloadDeletionLagMs / 1000)
+		}
+	}
+}
+
+// limits returns the messages and bytes limits, -1 when unlimited
+func (r *Rate) limits() (int64, int64) {
+	if r == nil {
+		return -1, -1
+	}
+
+	messages, bytes := int64(-1), int64(-1)
+	if !r.Messages.IsNull() {
+		messages = r.Messages.ValueInt64()
+	}
+	if !r.Bytes.IsNull() {
+		bytes = r.Bytes.ValueInt64()
+	}
+	return messages, bytes
+}
+
+// rateFrom returns nil when both limits are disabled
+func rateFrom(messages, bytes int64) *Rate {
+	if messages <= 0 && bytes <= 0 {
+		return nil
+	}
+
+	rate := &Rate{Messages: types.Int64Null(), Bytes: types.Int64Null()}
+	if messages > 0 {
+		rate.Messages = types.Int64Value(messages)
+	}
+	if bytes > 0 {
+		rate.Bytes = types.Int64Value(bytes)
+	}
+	return rate
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
@@ -60,6 +60,24 @@ func TestAccPulsar_basic(t *testing.T) {
 				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("retention_period"), knownvalue.Int64Exact(120)),
 				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("retention_size"), knownvalue.Int64Exact(1024)),
 			},
+		}, {
+			ResourceName: rName,
+			Config: providerBlock.Append(pulsarBlock.SetOneValue("policies", map[string]any{
+				"message_ttl":             3600,
+				"deduplication":           true,
+				"max_producers_per_topic": 10,
+				"schema_compatibility":    "BACKWARD",
+				"backlog_quota":           map[string]any{"limit": 1048576, "policy": "producer_exception"},
+				"publish_rate":            map[string]any{"messages": 1000},
+			})).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("message_ttl"), knownvalue.Int64Exact(3600)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("deduplication"), knownvalue.Bool(true)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("max_producers_per_topic"), knownvalue.Int64Exact(10)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("schema_compatibility"), knownvalue.StringExact("BACKWARD")),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("backlog_quota").AtMapKey("limit"), knownvalue.Int64Exact(1048576)),
+				statecheck.ExpectKnownValue(fullName, tfjsonpath.New("policies").AtMapKey("publish_rate").AtMapKey("messages"), knownvalue.Int64Exact(1000)),
+			},
 		}},
 	})
 }
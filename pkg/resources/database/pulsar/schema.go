@@ -28,6 +28,8 @@ type Pulsar struct {
 
 	RetentionSize   types.Int64 `tfsdk:"retention_size"`
 	RetentionPeriod types.Int64 `tfsdk:"retention_period"`
+
+	Policies *Policies `tfsdk:"policies"`
 }
 
 func (p *Pulsar) TenantAndNamespace() string {
@@ -57,6 +59,7 @@ func (r ResourcePulsar) Schema(_ context.Context, req resource.SchemaRequest, re
 			"token":            schema.StringAttribute{Computed: true, MarkdownDescription: "Pulsar authentication token", Sensitive: true},
 			"retention_size":   schema.Int64Attribute{Optional: true, MarkdownDescription: "Pulsar namespace retention policy in bytes"},
 			"retention_period": schema.Int64Attribute{Optional: true, MarkdownDescription: "Pulsar namespace retention policy in minutes"},
+			"policies":         PoliciesAttribute,
 		},
 	}
 }
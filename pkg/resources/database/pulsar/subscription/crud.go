@@ -0,0 +1,150 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pulsar"
+)
+
+// Create a new resource
+func (r *ResourcePulsarSubscription) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Subscription](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	position := utils.Latest
+	if plan.InitialPosition.ValueString() == "earliest" {
+		position = utils.Earliest
+	}
+
+	if err := addon.Subscriptions().CreateWithContext(ctx, *topic, plan.Name.ValueString(), position); err != nil {
+		if pulsar.IsCode(err, http.StatusConflict) {
+			res.Diagnostics.AddError("subscription already exists", fmt.Sprintf("import it with: terraform import <address> %s/%s/%s", plan.PulsarID.ValueString(), topic, plan.Name.ValueString()))
+			return
+		}
+		res.Diagnostics.AddError("failed to create subscription", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.PulsarID.ValueString(), topic, plan.Name.ValueString()))
+	// no consumer is connected yet
+	plan.Type = types.StringNull()
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePulsarSubscription) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Subscription](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	names, err := addon.Subscriptions().ListWithContext(ctx, *topic)
+	if pulsar.IsCode(err, http.StatusNotFound) || (err == nil && !slices.Contains(names, state.Name.ValueString())) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to list subscriptions", err.Error())
+		return
+	}
+
+	stats, err := subscriptionStats(ctx, addon, topic)
+	if err != nil {
+		res.Diagnostics.AddError("failed to read topic stats", err.Error())
+		return
+	}
+	// the type is set by the connected consumers
+	state.Type = types.StringNull()
+	if stat, ok := stats[state.Name.ValueString()]; ok && len(stat.Consumers) > 0 && stat.SubType != "" {
+		state.Type = types.StringValue(stat.SubType)
+	}
+
+	state.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", state.PulsarID.ValueString(), topic, state.Name.ValueString()))
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, every argument requires a replacement
+func (r *ResourcePulsarSubscription) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Subscription](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Subscription](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	plan.Type = state.Type
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, fails while consumers are connected
+func (r *ResourcePulsarSubscription) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Subscription](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := addon.Subscriptions().DeleteWithContext(ctx, *topic, state.Name.ValueString())
+	if err != nil && !pulsar.IsCode(err, http.StatusNotFound) {
+		res.Diagnostics.AddError("failed to delete subscription", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePulsarSubscription) connect(ctx context.Context, subscription *Subscription, diags *diag.Diagnostics) (*pulsar.Addon, *utils.TopicName) {
+	addon, err := pulsar.Connect(ctx, r.Client(), r.Organization(), subscription.PulsarID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Pulsar addon", err.Error())
+		return nil, nil
+	}
+
+	topic, err := addon.Topic(subscription.Topic.ValueString())
+	if err != nil {
+		diags.AddError("invalid topic", err.Error())
+		return nil, nil
+	}
+
+	return addon, topic
+}
+
+// subscriptionStats returns the stats of the topic subscriptions, aggregated over the partitions
+func subscriptionStats(ctx context.Context, addon *pulsar.Addon, topic *utils.TopicName) (map[string]utils.SubscriptionStats, error) {
+	metadata, err := addon.Topics().GetMetadataWithContext(ctx, *topic)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.Partitions > 0 {
+		stats, err := addon.Topics().GetPartitionedStatsWithContext(ctx, *topic, false)
+		return stats.Subscriptions, err
+	}
+
+	stats, err := addon.Topics().GetStatsWithContext(ctx, *topic)
+	return stats.Subscriptions, err
+}
@@ -0,0 +1,75 @@
+package subscription
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+type Subscription struct {
+	ID              types.String `tfsdk:"id"`
+	PulsarID        types.String `tfsdk:"pulsar_id"`
+	Topic           types.String `tfsdk:"topic"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	InitialPosition types.String `tfsdk:"initial_position"`
+}
+
+// the name ends the resource ID
+var NameRegex = regexp.MustCompile(`^[^/]{1,255}$`)
+
+//go:embed doc.md
+var resourcePulsarSubscriptionDoc string
+
+func (r ResourcePulsarSubscription) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePulsarSubscriptionDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Subscription identifier: <pulsar_id>/<topic full name>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"pulsar_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Pulsar addon ID of the topic",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"topic": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Topic to subscribe to: a full name (`clevercloud_pulsar_topic.x.full_name`) or the name of a persistent topic",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Subscription name, consumers use it to share the cursor",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a Pulsar subscription name", NameRegex)},
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Subscription type of the connected consumers: `Exclusive`, `Shared`, `Failover` or `Key_Shared`, null while no consumer is connected",
+			},
+			"initial_position": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("latest"),
+				MarkdownDescription: "Where the cursor starts on creation: `earliest` (oldest retained message) or `latest` (next published message)",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf("earliest", "latest")},
+			},
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package subscription
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePulsarSubscription struct {
+	helper.Configurer
+}
+
+func NewResourcePulsarSubscription() resource.Resource {
+	return &ResourcePulsarSubscription{}
+}
+
+func (r *ResourcePulsarSubscription) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_pulsar_subscription"
+}
+
+// ImportState expects <pulsar_id>/<topic>/<subscription name>, the topic being a local or a full name
+func (r *ResourcePulsarSubscription) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	pulsarID, rest, _ := strings.Cut(req.ID, "/")
+	separator := strings.LastIndex(rest, "/")
+	if pulsarID == "" || separator <= 0 || separator == len(rest)-1 {
+		res.Diagnostics.AddError("invalid import ID", "expect <pulsar_id>/<topic>/<subscription name>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("pulsar_id"), pulsarID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("topic"), rest[:separator])...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), rest[separator+1:])...)
+	// the initial position only applies on creation
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("initial_position"), "latest")...)
+}
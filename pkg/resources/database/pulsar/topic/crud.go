@@ -0,0 +1,155 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pulsar"
+)
+
+// Create a new resource
+func (r *ResourcePulsarTopic) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[Topic](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if err := addon.Topics().CreateWithContext(ctx, *topic, int(plan.Partitions.ValueInt64())); err != nil {
+		if pulsar.IsCode(err, http.StatusConflict) {
+			res.Diagnostics.AddError("topic already exists", fmt.Sprintf("import it with: terraform import <address> %s/%s", plan.PulsarID.ValueString(), topic))
+			return
+		}
+		res.Diagnostics.AddError("failed to create topic", err.Error())
+		return
+	}
+
+	plan.FullName = types.StringValue(topic.String())
+	plan.ID = types.StringValue(plan.PulsarID.ValueString() + "/" + topic.String())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePulsarTopic) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[Topic](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	namespace, err := addon.NamespaceName()
+	if err != nil {
+		res.Diagnostics.AddError("invalid Pulsar namespace", err.Error())
+		return
+	}
+
+	partitioned, nonPartitioned, err := addon.Topics().ListWithContext(ctx, *namespace)
+	if err != nil {
+		res.Diagnostics.AddError("failed to list topics", err.Error())
+		return
+	}
+
+	switch {
+	case slices.Contains(partitioned, topic.String()):
+		metadata, err := addon.Topics().GetMetadataWithContext(ctx, *topic)
+		if err != nil {
+			res.Diagnostics.AddError("failed to read topic", err.Error())
+			return
+		}
+		state.Partitions = types.Int64Value(int64(metadata.Partitions))
+
+	case slices.Contains(nonPartitioned, topic.String()):
+		state.Partitions = types.Int64Value(0)
+
+	default:
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	state.FullName = types.StringValue(topic.String())
+	state.ID = types.StringValue(state.PulsarID.ValueString() + "/" + topic.String())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, only partitions are added in place
+func (r *ResourcePulsarTopic) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[Topic](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[Topic](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Partitions.Equal(state.Partitions) {
+		if err := addon.Topics().UpdateWithContext(ctx, *topic, int(plan.Partitions.ValueInt64())); err != nil {
+			res.Diagnostics.AddError("failed to add topic partitions", err.Error())
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	plan.FullName = state.FullName
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, fails while producers or consumers are connected
+func (r *ResourcePulsarTopic) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[Topic](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	nonPartitioned := state.Partitions.ValueInt64() == 0
+	err := addon.Topics().DeleteWithContext(ctx, *topic, false, nonPartitioned)
+	if err != nil && !pulsar.IsCode(err, http.StatusNotFound) {
+		res.Diagnostics.AddError("failed to delete topic", err.Error())
+		return
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePulsarTopic) connect(ctx context.Context, topic *Topic, diags *diag.Diagnostics) (*pulsar.Addon, *utils.TopicName) {
+	addon, err := pulsar.Connect(ctx, r.Client(), r.Organization(), topic.PulsarID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Pulsar addon", err.Error())
+		return nil, nil
+	}
+
+	domain := "persistent"
+	if !topic.Persistent.ValueBool() {
+		domain = "non-persistent"
+	}
+
+	name, err := addon.Topic(fmt.Sprintf("%s://%s/%s/%s", domain, addon.Tenant, addon.Namespace, topic.Name.ValueString()))
+	if err != nil {
+		diags.AddError("invalid topic name", err.Error())
+		return nil, nil
+	}
+
+	return addon, name
+}
@@ -0,0 +1,108 @@
+package topic
+
+import (
+	"context"
+	_ "embed"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg"
+)
+
+type Topic struct {
+	ID         types.String `tfsdk:"id"`
+	PulsarID   types.String `tfsdk:"pulsar_id"`
+	Name       types.String `tfsdk:"name"`
+	Persistent types.Bool   `tfsdk:"persistent"`
+	Partitions types.Int64  `tfsdk:"partitions"`
+	FullName   types.String `tfsdk:"full_name"`
+}
+
+// letters, digits and _ . = : - as accepted by Pulsar
+var NameRegex = regexp.MustCompile(`^[A-Za-z0-9_.=:-]{1,255}$`)
+
+//go:embed doc.md
+var resourcePulsarTopicDoc string
+
+func (r ResourcePulsarTopic) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePulsarTopicDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Topic identifier: <pulsar_id>/<full_name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"pulsar_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Pulsar addon ID the topic is created in",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Topic name, inside the addon namespace",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{pkg.NewValidatorRegex("must be a Pulsar topic name", NameRegex)},
+			},
+			"persistent": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Store messages on disk, non-persistent topics only keep them in memory",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.RequiresReplace()},
+			},
+			"partitions": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(0),
+				MarkdownDescription: "Number of partitions, 0 for a non-partitioned topic. Partitions can only be added",
+				Validators:          []validator.Int64{int64validator.Between(0, 1024)},
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplaceIf(
+					requiresReplaceIfRepartitioned,
+					"Pulsar cannot remove partitions, nor partition a topic",
+					"Pulsar cannot remove partitions, nor partition a topic",
+				)},
+			},
+			"full_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Topic name to give to Pulsar clients: <persistent|non-persistent>://<tenant>/<namespace>/<name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// partitions are added in place, other changes need a new topic
+func requiresReplaceIfRepartitioned(ctx context.Context, req planmodifier.Int64Request, res *int64planmodifier.RequiresReplaceIfFuncResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	from, to := req.StateValue.ValueInt64(), req.PlanValue.ValueInt64()
+	res.RequiresReplace = from == 0 || to == 0 || to < from
+}
+
+func (r ResourcePulsarTopic) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	topic := Topic{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &topic)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unpartitioned non-persistent topics are deleted by Pulsar as soon as they are unused
+	if !topic.Persistent.IsNull() && !topic.Persistent.IsUnknown() && !topic.Persistent.ValueBool() && !topic.Partitions.IsUnknown() && topic.Partitions.ValueInt64() == 0 {
+		res.Diagnostics.AddAttributeError(path.Root("partitions"), "missing partitions", "a non-persistent topic must be partitioned")
+	}
+}
@@ -0,0 +1,47 @@
+package topic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePulsarTopic struct {
+	helper.Configurer
+}
+
+func NewResourcePulsarTopic() resource.Resource {
+	return &ResourcePulsarTopic{}
+}
+
+func (r *ResourcePulsarTopic) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_pulsar_topic"
+}
+
+// ImportState expects <pulsar_id>/<topic name>, the topic name being a local name (persistent topic)
+// or a full name (non-persistent://tenant/namespace/topic)
+func (r *ResourcePulsarTopic) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	pulsarID, name, ok := strings.Cut(req.ID, "/")
+	if !ok || pulsarID == "" || name == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <pulsar_id>/<topic name>")
+		return
+	}
+
+	persistent := true
+	if strings.Contains(name, "://") {
+		topic, err := utils.GetTopicName(name)
+		if err != nil {
+			res.Diagnostics.AddError("invalid topic name", err.Error())
+			return
+		}
+		name, persistent = topic.GetLocalName(), topic.IsPersistent()
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("pulsar_id"), pulsarID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("name"), name)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("persistent"), persistent)...)
+}
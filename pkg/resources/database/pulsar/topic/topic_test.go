@@ -0,0 +1,83 @@
+package topic_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/tests"
+)
+
+func TestAccPulsarTopic_basic(t *testing.T) {
+	ctx := t.Context()
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-test-pulsar-topic")
+	pulsarID := fmt.Sprintf("${clevercloud_pulsar.%s.id}", rName)
+	providerBlock := helper.NewProvider("clevercloud").SetOrganisation(tests.ORGANISATION)
+	pulsarBlock := helper.NewRessource(
+		"clevercloud_pulsar",
+		rName,
+		helper.SetKeyValues(map[string]any{"name": rName, "region": "par"}),
+	)
+	topicBlock := helper.NewRessource(
+		"clevercloud_pulsar_topic",
+		"orders",
+		helper.SetKeyValues(map[string]any{
+			"pulsar_id":  pulsarID,
+			"name":       "orders",
+			"partitions": 2,
+		}))
+	subscriptionBlock := helper.NewRessource(
+		"clevercloud_pulsar_subscription",
+		"billing",
+		helper.SetKeyValues(map[string]any{
+			"pulsar_id":        pulsarID,
+			"topic":            "${clevercloud_pulsar_topic.orders.full_name}",
+			"name":             "billing",
+			"initial_position": "earliest",
+		}))
+	schemaBlock := helper.NewRessource(
+		"clevercloud_pulsar_schema",
+		"orders",
+		helper.SetKeyValues(map[string]any{
+			"pulsar_id":              pulsarID,
+			"topic":                  "${clevercloud_pulsar_topic.orders.full_name}",
+			"type":                   "AVRO",
+			"compatibility_strategy": "BACKWARD",
+			"definition":             `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`,
+		}))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: tests.ProtoV6Provider,
+		PreCheck:                 tests.ExpectOrganisation(t),
+		CheckDestroy:             tests.CheckDestroy(ctx),
+		Steps: []resource.TestStep{{
+			ResourceName: rName,
+			Config:       providerBlock.Append(pulsarBlock, topicBlock, subscriptionBlock, schemaBlock).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_pulsar_topic.orders", tfjsonpath.New("persistent"), knownvalue.Bool(true)),
+				statecheck.ExpectKnownValue("clevercloud_pulsar_topic.orders", tfjsonpath.New("full_name"), knownvalue.StringRegexp(regexp.MustCompile(`^persistent://.+/.+/orders$`))),
+				statecheck.ExpectKnownValue("clevercloud_pulsar_subscription.billing", tfjsonpath.New("type"), knownvalue.Null()),
+				statecheck.ExpectKnownValue("clevercloud_pulsar_schema.orders", tfjsonpath.New("compatibility_strategy"), knownvalue.StringExact("BACKWARD")),
+			},
+		}, {
+			// partitions are added in place, a compatible field is added
+			ResourceName: rName,
+			Config: providerBlock.Append(
+				pulsarBlock,
+				topicBlock.SetOneValue("partitions", 4),
+				subscriptionBlock,
+				schemaBlock.SetOneValue("definition", `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}, {"name": "coupon", "type": ["null", "string"], "default": null}]}`),
+			).String(),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("clevercloud_pulsar_topic.orders", tfjsonpath.New("partitions"), knownvalue.Int64Exact(4)),
+			},
+		}},
+	})
+}
@@ -0,0 +1,196 @@
+package topicschema
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/apache/pulsar-client-go/pulsaradmin/pkg/utils"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+	"go.clever-cloud.com/terraform-provider/pkg/pulsar"
+)
+
+// Create a new resource
+func (r *ResourcePulsarSchema) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	plan := helper.PlanFrom[TopicSchema](ctx, req.Plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the strategy applies to the uploaded schema
+	if !plan.CompatibilityStrategy.IsNull() {
+		strategy := utils.SchemaCompatibilityStrategy(plan.CompatibilityStrategy.ValueString())
+		if err := addon.Topics().SetSchemaCompatibilityStrategyWithContext(ctx, *topic, strategy); err != nil {
+			res.Diagnostics.AddError("failed to set schema compatibility strategy", err.Error())
+			return
+		}
+	}
+
+	uploadSchema(ctx, addon, topic, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(plan.PulsarID.ValueString() + "/" + topic.String())
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Read resource information
+func (r *ResourcePulsarSchema) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	state := helper.StateFrom[TopicSchema](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := addon.Schemas().GetSchemaInfo(topic.String())
+	if pulsar.IsCode(err, http.StatusNotFound) {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		res.Diagnostics.AddError("failed to read schema", err.Error())
+		return
+	}
+
+	state.Type = types.StringValue(info.Type)
+	// keep the configured formatting when the definition did not change
+	if definition := string(info.Schema); !pulsar.SameDefinition(state.Definition.ValueString(), definition) {
+		state.Definition = types.StringValue(definition)
+	}
+	if len(info.Properties) > 0 || !state.Properties.IsNull() {
+		properties, diags := types.MapValueFrom(ctx, types.StringType, info.Properties)
+		res.Diagnostics.Append(diags...)
+		state.Properties = properties
+	}
+
+	if !state.CompatibilityStrategy.IsNull() {
+		strategy, err := addon.Topics().GetSchemaCompatibilityStrategyWithContext(ctx, *topic)
+		if err != nil {
+			res.Diagnostics.AddError("failed to read schema compatibility strategy", err.Error())
+			return
+		}
+		if strategy == "" || strategy == utils.SchemaCompatibilityStrategyUndefined {
+			state.CompatibilityStrategy = types.StringNull()
+		} else {
+			state.CompatibilityStrategy = types.StringValue(strategy.String())
+		}
+	}
+
+	state.ID = types.StringValue(state.PulsarID.ValueString() + "/" + topic.String())
+	res.Diagnostics.Append(res.State.Set(ctx, state)...)
+}
+
+// Update resource, a changed definition is uploaded as a new schema version
+func (r *ResourcePulsarSchema) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	plan := helper.PlanFrom[TopicSchema](ctx, req.Plan, &res.Diagnostics)
+	state := helper.StateFrom[TopicSchema](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &plan, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.CompatibilityStrategy.Equal(state.CompatibilityStrategy) {
+		var err error
+		if plan.CompatibilityStrategy.IsNull() {
+			err = addon.Topics().RemoveSchemaCompatibilityStrategyWithContext(ctx, *topic)
+		} else {
+			strategy := utils.SchemaCompatibilityStrategy(plan.CompatibilityStrategy.ValueString())
+			err = addon.Topics().SetSchemaCompatibilityStrategyWithContext(ctx, *topic, strategy)
+		}
+		if err != nil {
+			res.Diagnostics.AddError("failed to set schema compatibility strategy", err.Error())
+			return
+		}
+	}
+
+	if !pulsar.SameDefinition(plan.Definition.ValueString(), state.Definition.ValueString()) || !plan.Properties.Equal(state.Properties) {
+		uploadSchema(ctx, addon, topic, &plan, &res.Diagnostics)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.ID = state.ID
+	res.Diagnostics.Append(res.State.Set(ctx, plan)...)
+}
+
+// Delete resource, all the schema versions of the topic are deleted
+func (r *ResourcePulsarSchema) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	state := helper.StateFrom[TopicSchema](ctx, req.State, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	addon, topic := r.connect(ctx, &state, &res.Diagnostics)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	err := addon.Schemas().DeleteSchema(topic.String())
+	if err != nil && !pulsar.IsCode(err, http.StatusNotFound) {
+		res.Diagnostics.AddError("failed to delete schema", err.Error())
+		return
+	}
+
+	if !state.CompatibilityStrategy.IsNull() {
+		err := addon.Topics().RemoveSchemaCompatibilityStrategyWithContext(ctx, *topic)
+		if err != nil && !pulsar.IsCode(err, http.StatusNotFound) {
+			res.Diagnostics.AddError("failed to remove schema compatibility strategy", err.Error())
+			return
+		}
+	}
+
+	res.State.RemoveResource(ctx)
+}
+
+func (r *ResourcePulsarSchema) connect(ctx context.Context, topicSchema *TopicSchema, diags *diag.Diagnostics) (*pulsar.Addon, *utils.TopicName) {
+	addon, err := pulsar.Connect(ctx, r.Client(), r.Organization(), topicSchema.PulsarID.ValueString())
+	if err != nil {
+		diags.AddError("failed to connect to Pulsar addon", err.Error())
+		return nil, nil
+	}
+
+	topic, err := addon.Topic(topicSchema.Topic.ValueString())
+	if err != nil {
+		diags.AddError("invalid topic", err.Error())
+		return nil, nil
+	}
+
+	return addon, topic
+}
+
+// uploadSchema registers the definition, Pulsar rejects it when the compatibility strategy forbids the change
+func uploadSchema(ctx context.Context, addon *pulsar.Addon, topic *utils.TopicName, topicSchema *TopicSchema, diags *diag.Diagnostics) {
+	properties := map[string]string{}
+	if !topicSchema.Properties.IsNull() {
+		diags.Append(topicSchema.Properties.ElementsAs(ctx, &properties, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	err := addon.Schemas().CreateSchemaByPayload(topic.String(), utils.PostSchemaPayload{
+		SchemaType: topicSchema.Type.ValueString(),
+		Schema:     topicSchema.Definition.ValueString(),
+		Properties: properties,
+	})
+	if err != nil {
+		diags.AddError("failed to upload schema", err.Error())
+	}
+}
@@ -0,0 +1,104 @@
+package topicschema
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type TopicSchema struct {
+	ID                    types.String `tfsdk:"id"`
+	PulsarID              types.String `tfsdk:"pulsar_id"`
+	Topic                 types.String `tfsdk:"topic"`
+	Type                  types.String `tfsdk:"type"`
+	Definition            types.String `tfsdk:"definition"`
+	Properties            types.Map    `tfsdk:"properties"`
+	CompatibilityStrategy types.String `tfsdk:"compatibility_strategy"`
+}
+
+// schema types with a JSON definition
+var Types = []string{"AVRO", "JSON", "PROTOBUF"}
+
+var CompatibilityStrategies = []string{
+	"ALWAYS_INCOMPATIBLE",
+	"ALWAYS_COMPATIBLE",
+	"BACKWARD",
+	"FORWARD",
+	"FULL",
+	"BACKWARD_TRANSITIVE",
+	"FORWARD_TRANSITIVE",
+	"FULL_TRANSITIVE",
+}
+
+//go:embed doc.md
+var resourcePulsarSchemaDoc string
+
+func (r ResourcePulsarSchema) Schema(_ context.Context, req resource.SchemaRequest, res *resource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Version:             0,
+		MarkdownDescription: resourcePulsarSchemaDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Schema identifier: <pulsar_id>/<topic full name>",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"pulsar_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Pulsar addon ID of the topic",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"topic": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Topic the schema applies to: a full name (`clevercloud_pulsar_topic.x.full_name`) or the name of a persistent topic",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Schema type: `AVRO`, `JSON` or `PROTOBUF`",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf(Types...)},
+			},
+			"definition": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Schema definition, as an Avro JSON record (`jsonencode({ type = \"record\", name = \"Order\", fields = [...] })`)",
+			},
+			"properties": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Properties attached to the schema version",
+			},
+			"compatibility_strategy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Which schema changes are accepted on the topic (`BACKWARD`, `FULL`...), defaults to the namespace strategy",
+				Validators:          []validator.String{stringvalidator.OneOf(CompatibilityStrategies...)},
+			},
+		},
+	}
+}
+
+func (r ResourcePulsarSchema) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	topicSchema := TopicSchema{}
+	res.Diagnostics.Append(req.Config.Get(ctx, &topicSchema)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// unknown values are checked once known
+	if topicSchema.Definition.IsNull() || topicSchema.Definition.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(topicSchema.Definition.ValueString())) {
+		res.Diagnostics.AddAttributeError(path.Root("definition"), "invalid definition", "definition must be a JSON document")
+	}
+}
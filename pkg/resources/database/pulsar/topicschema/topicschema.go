@@ -0,0 +1,34 @@
+package topicschema
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"go.clever-cloud.com/terraform-provider/pkg/helper"
+)
+
+type ResourcePulsarSchema struct {
+	helper.Configurer
+}
+
+func NewResourcePulsarSchema() resource.Resource {
+	return &ResourcePulsarSchema{}
+}
+
+func (r *ResourcePulsarSchema) Metadata(ctx context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_pulsar_schema"
+}
+
+// ImportState expects <pulsar_id>/<topic>, the topic being a local or a full name
+func (r *ResourcePulsarSchema) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	pulsarID, topic, ok := strings.Cut(req.ID, "/")
+	if !ok || pulsarID == "" || topic == "" {
+		res.Diagnostics.AddError("invalid import ID", "expect <pulsar_id>/<topic>")
+		return
+	}
+
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("pulsar_id"), pulsarID)...)
+	res.Diagnostics.Append(res.State.SetAttribute(ctx, path.Root("topic"), topic)...)
+}
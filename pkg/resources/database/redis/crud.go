@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -125,31 +126,14 @@ func (r *ResourceRedis) Read(ctx context.Context, req resource.ReadRequest, resp
 	addonRD := addonRes.Payload()
 	tflog.Debug(ctx, "redis", map[string]any{"payload": fmt.Sprintf("%+v", addonRD)})
 
-	envRes := tmp.GetAddonEnv(ctx, r.Client(), r.Organization(), rd.ID.ValueString())
-	if envRes.HasError() {
-		resp.Diagnostics.AddError("failed to get Redis connection infos", envRes.Error().Error())
+	r.readFromEnv(ctx, &rd, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	env := *envRes.Payload()
-	envAsMap := pkg.Reduce(env, map[string]types.String{}, func(acc map[string]types.String, v tmp.EnvVar) map[string]types.String {
-		acc[v.Name] = pkg.FromStr(v.Value)
-		return acc
-	})
-	tflog.Debug(ctx, "API response", map[string]any{
-		"payload": fmt.Sprintf("%+v", envAsMap),
-	})
-	port, err := strconv.ParseInt(envAsMap["REDIS_PORT"].ValueString(), 10, 64)
-	if err != nil {
-		resp.Diagnostics.AddError("invalid port received", "expect REDIS_PORT to be an Integer")
-	}
-
 	rd.Name = pkg.FromStr(addonRD.Name)
-	rd.Host = envAsMap["REDIS_HOST"]
 	rd.Plan = pkg.FromStr(addonRD.Plan.Slug)
-	rd.Port = pkg.FromI(port)
 	rd.Region = pkg.FromStr(addonRD.Region)
-	rd.Token = envAsMap["REDIS_PASSWORD"]
 
 	rd.Networkgroups = resources.ReadNetworkGroups(ctx, r, rd.ID.ValueString(), &resp.Diagnostics)
 
@@ -194,6 +178,55 @@ func (r *ResourceRedis) Update(ctx context.Context, req resource.UpdateRequest,
 	)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+	// Handle plan or region changes via migration
+	if !plan.Plan.Equal(state.Plan) || !plan.Region.Equal(state.Region) {
+		r.migrate(ctx, plan, &state, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+func (r *ResourceRedis) migrate(ctx context.Context, plan Redis, state *Redis, diags *diag.Diagnostics) {
+	billingPlan := addon.Migrate(ctx, r.Client(), r.Organization(), state.ID.ValueString(), addon.MigrationRequest{
+		ProviderID: "redis-addon",
+		Plan:       plan.Plan.ValueString(),
+		Region:     plan.Region.ValueString(),
+	}, diags)
+	if billingPlan == nil {
+		return
+	}
+
+	state.Plan = pkg.FromStr(billingPlan.Slug)
+	state.Region = plan.Region
+
+	// A migration provisions a new instance: connection details change
+	r.readFromEnv(ctx, state, diags)
+}
+
+// readFromEnv reads the connection details exposed to linked applications
+func (r *ResourceRedis) readFromEnv(ctx context.Context, rd *Redis, diags *diag.Diagnostics) {
+	envRes := tmp.GetAddonEnv(ctx, r.Client(), r.Organization(), rd.ID.ValueString())
+	if envRes.HasError() {
+		diags.AddError("failed to get Redis connection infos", envRes.Error().Error())
+		return
+	}
+
+	env := *envRes.Payload()
+	envAsMap := pkg.Reduce(env, map[string]types.String{}, func(acc map[string]types.String, v tmp.EnvVar) map[string]types.String {
+		acc[v.Name] = pkg.FromStr(v.Value)
+		return acc
+	})
+	tflog.Debug(ctx, "API response", map[string]any{
+		"payload": fmt.Sprintf("%+v", envAsMap),
+	})
+	port, err := strconv.ParseInt(envAsMap["REDIS_PORT"].ValueString(), 10, 64)
+	if err != nil {
+		diags.AddError("invalid port received", "expect REDIS_PORT to be an Integer")
+	}
+
+	rd.Host = envAsMap["REDIS_HOST"]
+	rd.Port = pkg.FromI(port)
+	rd.Token = envAsMap["REDIS_PASSWORD"]
 }
 
 // Delete resource
@@ -113,3 +113,34 @@ func WithRetryConfig[T any](
 
 	return lastResponse
 }
+
+// Connect calls connect every second until it succeeds or the context expires.
+// Returns the last error encountered if the context expires.
+func Connect[T any](ctx context.Context, name string, connect func() (T, error)) (T, error) {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	conn, lastErr := connect()
+	if lastErr == nil {
+		return conn, nil
+	}
+	i := 1
+
+	for {
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, fmt.Errorf("context expired: %w", lastErr)
+		case <-ticker.C:
+			conn, lastErr = connect()
+			if lastErr == nil {
+				return conn, nil
+			}
+			tflog.Debug(ctx, "retrying connection to "+name, map[string]any{
+				"error": lastErr.Error(),
+				"retry": i,
+			})
+			i++
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package retry
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -75,3 +77,26 @@ func TestDefaultConfig(t *testing.T) {
 		t.Errorf("DefaultConfig().Multiplier = %f, want 2.0", config.Multiplier)
 	}
 }
+
+func TestConnect(t *testing.T) {
+	attempts := 0
+	conn, err := Connect(context.Background(), "test", func() (string, error) {
+		attempts++
+		if attempts < 2 {
+			return "", errors.New("connection refused")
+		}
+		return "conn", nil
+	})
+	if err != nil || conn != "conn" || attempts != 2 {
+		t.Errorf("Connect() = %q, %v after %d attempts", conn, err, attempts)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = Connect(ctx, "test", func() (string, error) {
+		return "", errors.New("connection refused")
+	})
+	if err == nil {
+		t.Error("Connect() must fail once the context expired")
+	}
+}
@@ -1,9 +1,13 @@
 package s3
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"go.clever-cloud.com/terraform-provider/pkg/tmp"
+	"go.clever-cloud.dev/client"
 )
 
 func minioClientFor(endpoint, id, secret string) (*minio.Client, error) {
@@ -42,3 +46,19 @@ func MinioClientFromEnvsFor(envVars tmp.EnvVars) (*minio.Client, error) {
 	creds := FromEnvVars(envVars)
 	return minioClientFor(creds.Host, creds.KeyID, creds.KeySecret)
 }
+
+// Connect looks up the Cellar addon credentials and returns a client on it
+func Connect(ctx context.Context, cc *client.Client, organisation, cellarID string) (*minio.Client, *CellarCreds, error) {
+	cellarEnvRes := tmp.GetAddonEnv(ctx, cc, organisation, cellarID)
+	if cellarEnvRes.HasError() {
+		return nil, nil, fmt.Errorf("failed to get cellar env %s: %w", cellarID, cellarEnvRes.Error())
+	}
+
+	creds := FromEnvVars(*cellarEnvRes.Payload())
+	minioClient, err := minioClientFor(creds.Host, creds.KeyID, creds.KeySecret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to setup S3 client: %w", err)
+	}
+
+	return minioClient, creds, nil
+}
@@ -0,0 +1,125 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+// Cellar only checks the signature region
+const signatureRegion = "us-east-1"
+
+// WebsiteConfiguration serves the bucket objects as a static website
+type WebsiteConfiguration struct {
+	XMLName       xml.Name       `xml:"WebsiteConfiguration"`
+	XMLNS         string         `xml:"xmlns,attr,omitempty"`
+	IndexDocument *IndexDocument `xml:"IndexDocument,omitempty"`
+	ErrorDocument *ErrorDocument `xml:"ErrorDocument,omitempty"`
+}
+
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// SetBucketWebsite configures the website of the bucket, minio-go has no website API
+func (c *CellarCreds) SetBucketWebsite(ctx context.Context, bucket string, config *WebsiteConfiguration) error {
+	config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	body, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.websiteRequest(ctx, http.MethodPut, bucket, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res, bucket)
+	}
+	return nil
+}
+
+// GetBucketWebsite returns the website configuration of the bucket, nil when it has none
+func (c *CellarCreds) GetBucketWebsite(ctx context.Context, bucket string) (*WebsiteConfiguration, error) {
+	res, err := c.websiteRequest(ctx, http.MethodGet, bucket, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode == http.StatusNotFound {
+		err := responseError(res, bucket)
+		if minio.ToErrorResponse(err).Code == "NoSuchWebsiteConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res, bucket)
+	}
+
+	config := &WebsiteConfiguration{}
+	if err := xml.NewDecoder(res.Body).Decode(config); err != nil {
+		return nil, fmt.Errorf("failed to parse website configuration: %w", err)
+	}
+	return config, nil
+}
+
+// DeleteBucketWebsite stops serving the bucket as a website
+func (c *CellarCreds) DeleteBucketWebsite(ctx context.Context, bucket string) error {
+	res, err := c.websiteRequest(ctx, http.MethodDelete, bucket, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK {
+		return responseError(res, bucket)
+	}
+	return nil
+}
+
+// websiteRequest sends a signed request on the ?website sub-resource of the bucket
+func (c *CellarCreds) websiteRequest(ctx context.Context, method, bucket string, body []byte) (*http.Response, error) {
+	url := fmt.Sprintf("https://%s/%s?website=", c.Host, bucket)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	sha := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha[:]))
+	if len(body) > 0 {
+		md5Sum := md5.Sum(body)
+		req.Header.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum[:]))
+		req.Header.Set("Content-Type", "application/xml")
+	}
+
+	return http.DefaultClient.Do(signer.SignV4(*req, c.KeyID, c.KeySecret, "", signatureRegion))
+}
+
+// responseError parses the S3 error of the response
+func responseError(res *http.Response, bucket string) error {
+	errResponse := minio.ErrorResponse{StatusCode: res.StatusCode, BucketName: bucket}
+	data, _ := io.ReadAll(res.Body)
+	if err := xml.Unmarshal(data, &errResponse); err != nil || errResponse.Code == "" {
+		return fmt.Errorf("unexpected response %s: %s", res.Status, bytes.TrimSpace(data))
+	}
+	return errResponse
+}
@@ -0,0 +1,396 @@
+// Package sops decrypts SOPS encrypted environment files with age identities.
+//
+// Only flat files are supported (dotenv, JSON object or YAML mapping of scalars),
+// as produced by `sops --encrypt --age <recipient> .env`. The file MAC is verified
+// before any value is returned.
+package sops
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"go.clever-cloud.com/terraform-provider/pkg/envfile"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SOPS_AGE_KEY      = "SOPS_AGE_KEY"
+	SOPS_AGE_KEY_FILE = "SOPS_AGE_KEY_FILE"
+
+	metadataKey    = "sops"
+	dotenvMetadata = "sops_"
+)
+
+var encRegExp = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.*),iv:(.*),tag:(.*),type:(.*)\]$`)
+
+type ageRecipient struct {
+	Recipient string `yaml:"recipient" json:"recipient"`
+	Enc       string `yaml:"enc" json:"enc"`
+}
+
+type metadata struct {
+	Age              []ageRecipient `yaml:"age" json:"age"`
+	LastModified     string         `yaml:"lastmodified" json:"lastmodified"`
+	MAC              string         `yaml:"mac" json:"mac"`
+	MACOnlyEncrypted bool           `yaml:"mac_only_encrypted" json:"mac_only_encrypted"`
+}
+
+// entry is a top level value, in file order (required to compute the MAC)
+type entry struct {
+	key   string
+	value any
+}
+
+// File holds the decrypted variables
+type File struct {
+	Values  map[string]string
+	dataKey []byte
+}
+
+// Digest returns a short fingerprint of a variable value, keyed with the file
+// data key so it can be shown in plans without leaking the value
+func (f *File) Digest(key, value string) string {
+	mac := hmac.New(sha256.New, f.dataKey)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// Identities resolves age identities, from the given key material first, then
+// from SOPS_AGE_KEY, SOPS_AGE_KEY_FILE and the sops default keys file
+func Identities(configured string) ([]age.Identity, error) {
+	if configured != "" {
+		return parseIdentities(configured, "provider configuration")
+	}
+
+	if key := os.Getenv(SOPS_AGE_KEY); key != "" {
+		return parseIdentities(key, SOPS_AGE_KEY)
+	}
+
+	file := os.Getenv(SOPS_AGE_KEY_FILE)
+	if file == "" {
+		if dir, err := os.UserConfigDir(); err == nil {
+			file = filepath.Join(dir, "sops", "age", "keys.txt")
+		}
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("no age identity found: set the provider sops_age_key, %s or %s (%w)", SOPS_AGE_KEY, SOPS_AGE_KEY_FILE, err)
+	}
+
+	return parseIdentities(string(content), file)
+}
+
+func parseIdentities(content, source string) ([]age.Identity, error) {
+	identities, err := age.ParseIdentities(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid age identity from %s: %w", source, err)
+	}
+
+	return identities, nil
+}
+
+// DecryptFile decrypts the file at path, its format is guessed from the extension
+func DecryptFile(path string, identities []age.Identity) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := Decrypt(envfile.FormatOf(path), content, identities)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// Decrypt decrypts a SOPS document
+func Decrypt(format envfile.Format, content []byte, identities []age.Identity) (*File, error) {
+	var entries []entry
+	var meta *metadata
+	var err error
+
+	switch format {
+	case envfile.FormatJSON:
+		entries, meta, err = loadJSON(content)
+	case envfile.FormatYAML:
+		entries, meta, err = loadYAML(content)
+	default:
+		entries, meta, err = loadDotenv(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if meta == nil {
+		return nil, errors.New("not a SOPS encrypted file, metadata are missing")
+	}
+
+	dataKey, err := meta.dataKey(identities)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{Values: map[string]string{}, dataKey: dataKey}
+	hash := sha512.New()
+
+	for _, e := range entries {
+		raw, isString := e.value.(string)
+		if isString && encRegExp.MatchString(raw) {
+			plaintext, valueType, err := decryptValue(raw, dataKey, e.key+":")
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt '%s': %w", e.key, err)
+			}
+			if valueType == "comment" {
+				continue
+			}
+
+			hash.Write(plaintext)
+			if valueType == "bool" {
+				file.Values[e.key] = strings.ToLower(string(plaintext))
+			} else {
+				file.Values[e.key] = string(plaintext)
+			}
+			continue
+		}
+
+		value, err := toBytes(e.value)
+		if err != nil {
+			return nil, fmt.Errorf("variable '%s': %w", e.key, err)
+		}
+		if !meta.MACOnlyEncrypted {
+			hash.Write(value)
+		}
+		if b, ok := e.value.(bool); ok {
+			file.Values[e.key] = strconv.FormatBool(b)
+		} else {
+			file.Values[e.key] = string(value)
+		}
+	}
+
+	// the MAC covers every value, it is encrypted with the last modification date
+	mac, _, err := decryptValue(meta.MAC, dataKey, meta.LastModified)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt MAC: %w", err)
+	}
+	if !strings.EqualFold(string(mac), fmt.Sprintf("%X", hash.Sum(nil))) {
+		return nil, errors.New("MAC mismatch, the file has been modified without sops")
+	}
+
+	return file, nil
+}
+
+// dataKey tries every age stanza with every identity
+func (meta *metadata) dataKey(identities []age.Identity) ([]byte, error) {
+	if len(meta.Age) == 0 {
+		return nil, errors.New("the file is not encrypted for any age recipient")
+	}
+
+	var lastErr error
+	for _, recipient := range meta.Age {
+		reader, err := age.Decrypt(armor.NewReader(strings.NewReader(recipient.Enc)), identities...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return io.ReadAll(reader)
+	}
+
+	return nil, fmt.Errorf("none of the age identities can decrypt the data key: %w", lastErr)
+}
+
+// decryptValue decrypts an ENC[AES256_GCM,...] value, additionalData is the value path
+func decryptValue(value string, key []byte, additionalData string) ([]byte, string, error) {
+	matches := encRegExp.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, "", errors.New("invalid encrypted value format")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(matches[1])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid data: %w", err)
+	}
+	iv, err := base64.StdEncoding.DecodeString(matches[2])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid iv: %w", err)
+	}
+	tag, err := base64.StdEncoding.DecodeString(matches[3])
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid tag: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, "", err
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, "", err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, "", err
+	}
+
+	return plaintext, matches[4], nil
+}
+
+// toBytes mimics sops serialization of unencrypted values for the MAC
+func toBytes(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case int:
+		return []byte(strconv.Itoa(v)), nil
+	case int64:
+		return []byte(strconv.FormatInt(v, 10)), nil
+	case uint64:
+		return []byte(strconv.FormatUint(v, 10)), nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case json.Number:
+		return []byte(v.String()), nil
+	case bool:
+		if v {
+			return []byte("True"), nil
+		}
+		return []byte("False"), nil
+	default:
+		return nil, fmt.Errorf("must be a scalar, got %T", value)
+	}
+}
+
+func loadJSON(content []byte) ([]entry, *metadata, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, errors.New("invalid JSON: expect an object")
+	}
+
+	entries := []entry{}
+	var meta *metadata
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		key, _ := token.(string)
+
+		if key == metadataKey {
+			meta = &metadata{}
+			if err := decoder.Decode(meta); err != nil {
+				return nil, nil, fmt.Errorf("invalid SOPS metadata: %w", err)
+			}
+			continue
+		}
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+		entries = append(entries, entry{key, value})
+	}
+
+	return entries, meta, nil
+}
+
+func loadYAML(content []byte) ([]entry, *metadata, error) {
+	document := yaml.Node{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(document.Content) != 1 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, nil, errors.New("invalid YAML: expect a mapping")
+	}
+
+	entries := []entry{}
+	var meta *metadata
+	nodes := document.Content[0].Content
+	for i := 0; i+1 < len(nodes); i += 2 {
+		key, valueNode := nodes[i].Value, nodes[i+1]
+
+		if key == metadataKey {
+			meta = &metadata{}
+			if err := valueNode.Decode(meta); err != nil {
+				return nil, nil, fmt.Errorf("invalid SOPS metadata: %w", err)
+			}
+			continue
+		}
+
+		var value any
+		if err := valueNode.Decode(&value); err != nil {
+			return nil, nil, fmt.Errorf("invalid YAML value for '%s': %w", key, err)
+		}
+		entries = append(entries, entry{key, value})
+	}
+
+	return entries, meta, nil
+}
+
+// loadDotenv reads the sops dotenv layout: one KEY=value per line, multiline
+// values are escaped, metadata are flattened with the "sops_" prefix
+func loadDotenv(content []byte) ([]entry, *metadata, error) {
+	entries := []entry{}
+	flatMeta := map[string]string{}
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, nil, fmt.Errorf("line %d: expect KEY=value", i+1)
+		}
+
+		if name, isMeta := strings.CutPrefix(key, dotenvMetadata); isMeta {
+			flatMeta[name] = strings.ReplaceAll(value, `\n`, "\n")
+			continue
+		}
+
+		entries = append(entries, entry{key, strings.ReplaceAll(value, `\n`, "\n")})
+	}
+
+	if len(flatMeta) == 0 {
+		return entries, nil, nil
+	}
+
+	meta := &metadata{
+		LastModified:     flatMeta["lastmodified"],
+		MAC:              flatMeta["mac"],
+		MACOnlyEncrypted: flatMeta["mac_only_encrypted"] == "true",
+	}
+	for i := 0; ; i++ {
+		prefix := fmt.Sprintf("age__list_%d__map_", i)
+		enc, ok := flatMeta[prefix+"enc"]
+		if !ok {
+			break
+		}
+		meta.Age = append(meta.Age, ageRecipient{Recipient: flatMeta[prefix+"recipient"], Enc: enc})
+	}
+
+	return entries, meta, nil
+}
@@ -505,7 +505,7 @@ func GetPostgresInfos(ctx context.Context, cc *client.Client) client.Response[Po
 type AddonMigrationRequest struct {
 	PlanID  string  `json:"planId"`
 	Region  string  `json:"region"`
-	Version *string `json:"version"`
+	Version *string `json:"version,omitempty"`
 }
 
 type AddonMigrationResponse struct {
@@ -150,6 +150,27 @@ func (mv *mapValidator) ValidateMap(ctx context.Context, req validator.MapReques
 	mv.fn(ctx, req, res)
 }
 
+type listValidator struct {
+	description string
+	fn          func(context.Context, validator.ListRequest, *validator.ListResponse)
+}
+
+func NewListValidator(description string, fn func(context.Context, validator.ListRequest, *validator.ListResponse)) validator.List {
+	return &listValidator{description, fn}
+}
+
+func (lv *listValidator) Description(context.Context) string {
+	return lv.description
+}
+
+func (lv *listValidator) MarkdownDescription(ctx context.Context) string {
+	return lv.Description(ctx)
+}
+
+func (lv *listValidator) ValidateList(ctx context.Context, req validator.ListRequest, res *validator.ListResponse) {
+	lv.fn(ctx, req, res)
+}
+
 // NoNullMapValuesValidator creates a validator that rejects maps with null values
 func NoNullMapValuesValidator() validator.Map {
 	return NewMapValidator(
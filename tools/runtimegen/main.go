@@ -0,0 +1,540 @@
+// runtimegen generates the typed CC_ variables of the application runtimes
+// from a declarative spec (YAML or JSON).
+//
+// For each runtime, it writes <package>/zz_generated.go next to the spec, with:
+//   - the Variables struct, to embed in the runtime plan (empty when the
+//     runtime has no specific variable)
+//   - the schema attributes of those variables
+//   - the Variables <-> environment mapping
+//   - the resource GetVariantSlug() when `variant` is set
+//   - the schema v0 state upgrader when `upgrade_v0` is set
+//
+// Usage: go run ./tools/runtimegen -spec pkg/resources/application/runtimes.yaml
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Spec struct {
+	Runtimes []Runtime `yaml:"runtimes" json:"runtimes"`
+}
+
+type Runtime struct {
+	Package   string     `yaml:"package" json:"package"`
+	Type      string     `yaml:"type" json:"type"`
+	Resource  string     `yaml:"resource" json:"resource"`
+	Doc       string     `yaml:"doc" json:"doc"`
+	Variant   string     `yaml:"variant" json:"variant"`
+	UpgradeV0 bool       `yaml:"upgrade_v0" json:"upgrade_v0"`
+	Variables []Variable `yaml:"variables" json:"variables"`
+}
+
+type Variable struct {
+	Attribute        string   `yaml:"attribute" json:"attribute"`
+	Field            string   `yaml:"field" json:"field"`
+	Env              string   `yaml:"env" json:"env"`
+	Type             string   `yaml:"type" json:"type"`
+	Description      string   `yaml:"description" json:"description"`
+	PlainDescription bool     `yaml:"plain_description" json:"plain_description"`
+	Deprecation      string   `yaml:"deprecation" json:"deprecation"`
+	Sensitive        bool     `yaml:"sensitive" json:"sensitive"`
+	Default          *bool    `yaml:"default" json:"default"`
+	TrueValue        string   `yaml:"true_value" json:"true_value"`
+	Separator        string   `yaml:"separator" json:"separator"`
+	OneOf            []string `yaml:"one_of" json:"one_of"`
+	Pattern          string   `yaml:"pattern" json:"pattern"`
+	Min              *int64   `yaml:"min" json:"min"`
+	Max              *int64   `yaml:"max" json:"max"`
+	Validators       []string `yaml:"validators" json:"validators"`
+	V0               bool     `yaml:"v0" json:"v0"`
+}
+
+var (
+	identifierRegExp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	// validators are expressions of the runtime package, like ipv6CIDRValidator
+	validatorRegExp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*(\(.*\))?$`)
+	attributeRegExp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	envRegExp       = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+func main() {
+	specPath := flag.String("spec", "runtimes.yaml", "path to the runtimes spec, YAML or JSON")
+	flag.Parse()
+
+	if err := run(*specPath); err != nil {
+		fmt.Fprintf(os.Stderr, "runtimegen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath string) error {
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	for _, runtime := range spec.Runtimes {
+		code, err := Generate(runtime)
+		if err != nil {
+			return fmt.Errorf("%s: %w", runtime.Package, err)
+		}
+
+		output := filepath.Join(filepath.Dir(specPath), runtime.Package, "zz_generated.go")
+		if err := os.WriteFile(output, code, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadSpec reads and validates a spec, JSON when the extension is .json, YAML otherwise
+func LoadSpec(specPath string) (*Spec, error) {
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	if strings.EqualFold(filepath.Ext(specPath), ".json") {
+		err = json.Unmarshal(content, spec)
+	} else {
+		err = yaml.Unmarshal(content, spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec %s: %w", specPath, err)
+	}
+
+	return spec, spec.Validate()
+}
+
+func (spec *Spec) Validate() error {
+	packages := map[string]bool{}
+	for _, runtime := range spec.Runtimes {
+		if runtime.Package == "" || packages[runtime.Package] {
+			return fmt.Errorf("runtime package '%s' is empty or duplicated", runtime.Package)
+		}
+		packages[runtime.Package] = true
+
+		if !identifierRegExp.MatchString(runtime.Type) || !identifierRegExp.MatchString(runtime.Resource) {
+			return fmt.Errorf("%s: type and resource must be exported identifiers", runtime.Package)
+		}
+		if runtime.UpgradeV0 && runtime.Doc == "" {
+			return fmt.Errorf("%s: doc is required to generate the v0 schema", runtime.Package)
+		}
+
+		seen := map[string]bool{}
+		for _, v := range runtime.Variables {
+			if !attributeRegExp.MatchString(v.Attribute) || !identifierRegExp.MatchString(v.Field) {
+				return fmt.Errorf("%s: invalid variable %+v", runtime.Package, v)
+			}
+			// a deprecated attribute can be kept in the schema without a variable behind it
+			if (v.Env == "" && v.Deprecation == "") || (v.Env != "" && !envRegExp.MatchString(v.Env)) {
+				return fmt.Errorf("%s.%s: invalid env '%s', only deprecated attributes can omit it", runtime.Package, v.Attribute, v.Env)
+			}
+			keys := []string{"attribute:" + v.Attribute, "field:" + v.Field}
+			if v.Env != "" {
+				keys = append(keys, "env:"+v.Env)
+			}
+			for _, key := range keys {
+				if seen[key] {
+					return fmt.Errorf("%s: duplicated %s", runtime.Package, key)
+				}
+				seen[key] = true
+			}
+			for _, expr := range v.Validators {
+				if !validatorRegExp.MatchString(expr) {
+					return fmt.Errorf("%s.%s: invalid validator expression '%s'", runtime.Package, v.Attribute, expr)
+				}
+			}
+
+			switch v.Type {
+			case "string":
+				if v.Min != nil || v.Max != nil || v.TrueValue != "" || v.Default != nil || v.Separator != "" {
+					return fmt.Errorf("%s.%s: min, max, true_value, default and separator are not allowed on strings", runtime.Package, v.Attribute)
+				}
+				if v.Pattern != "" {
+					if _, err := regexp.Compile(v.Pattern); err != nil {
+						return fmt.Errorf("%s.%s: invalid pattern: %w", runtime.Package, v.Attribute, err)
+					}
+				}
+			case "int":
+				if len(v.OneOf) > 0 || v.Pattern != "" || v.TrueValue != "" || v.Default != nil || v.Separator != "" {
+					return fmt.Errorf("%s.%s: one_of, pattern, true_value, default and separator are not allowed on integers", runtime.Package, v.Attribute)
+				}
+			case "bool":
+				if len(v.OneOf) > 0 || v.Pattern != "" || v.Min != nil || v.Max != nil || v.Separator != "" {
+					return fmt.Errorf("%s.%s: one_of, pattern, min, max and separator are not allowed on booleans", runtime.Package, v.Attribute)
+				}
+			case "set":
+				if v.Separator == "" {
+					return fmt.Errorf("%s.%s: separator is required on sets", runtime.Package, v.Attribute)
+				}
+				if len(v.OneOf) > 0 || v.Pattern != "" || v.Min != nil || v.Max != nil || v.TrueValue != "" || v.Default != nil {
+					return fmt.Errorf("%s.%s: one_of, pattern, min, max, true_value and default are not allowed on sets", runtime.Package, v.Attribute)
+				}
+			default:
+				return fmt.Errorf("%s.%s: unsupported type '%s', expect string, int, bool or set", runtime.Package, v.Attribute, v.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Generate returns the formatted source of a runtime zz_generated.go
+func Generate(runtime Runtime) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := codeTemplate.Execute(buf, runtime); err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not compile: %w\n%s", err, buf.String())
+	}
+
+	return code, nil
+}
+
+func (r Runtime) has(fn func(Variable) bool) bool {
+	for _, v := range r.Variables {
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Runtime) NeedsStrconv() bool {
+	return r.has(func(v Variable) bool {
+		return v.Env != "" && (v.Type == "int" || (v.Type == "bool" && v.TrueValue == ""))
+	})
+}
+
+func (r Runtime) NeedsStrings() bool {
+	return r.has(func(v Variable) bool { return v.Env != "" && v.Type == "set" })
+}
+
+func (r Runtime) NeedsPkg() bool {
+	return r.has(func(v Variable) bool { return v.Env != "" || v.Pattern != "" })
+}
+
+func (r Runtime) NeedsValidator() bool {
+	return r.has(func(v Variable) bool { return v.HasValidators() })
+}
+
+func (r Runtime) NeedsStringValidator() bool {
+	return r.has(func(v Variable) bool { return len(v.OneOf) > 0 })
+}
+
+func (r Runtime) NeedsInt64Validator() bool {
+	return r.has(func(v Variable) bool { return v.Min != nil || v.Max != nil })
+}
+
+func (r Runtime) NeedsBoolDefault() bool {
+	return r.has(func(v Variable) bool { return v.Default != nil })
+}
+
+func (r Runtime) NeedsRegexp() bool {
+	return r.has(func(v Variable) bool { return v.Pattern != "" })
+}
+
+// Imports lists the generated file imports, groups are separated by an empty string
+func (r Runtime) Imports() []string {
+	std := []string{`"context"`}
+	if r.NeedsRegexp() {
+		std = append(std, `"regexp"`)
+	}
+	if r.NeedsStrconv() {
+		std = append(std, `"strconv"`)
+	}
+	if r.NeedsStrings() {
+		std = append(std, `"strings"`)
+	}
+
+	others := []string{}
+	if r.NeedsStringValidator() {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"`)
+	}
+	if r.NeedsInt64Validator() {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"`)
+	}
+	others = append(others, `"github.com/hashicorp/terraform-plugin-framework/diag"`)
+	if r.UpgradeV0 {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework/resource"`)
+	}
+	others = append(others, `"github.com/hashicorp/terraform-plugin-framework/resource/schema"`)
+	if r.NeedsBoolDefault() {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"`)
+	}
+	if r.NeedsValidator() {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework/schema/validator"`)
+	}
+	if len(r.Variables) > 0 {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-framework/types"`)
+	}
+	if r.UpgradeV0 {
+		others = append(others, `"github.com/hashicorp/terraform-plugin-log/tflog"`)
+	}
+	others = append(others, `helperMaps "github.com/miton18/helper/maps"`)
+	if r.NeedsPkg() {
+		others = append(others, `"go.clever-cloud.com/terraform-provider/pkg"`)
+	}
+	if r.UpgradeV0 {
+		others = append(others,
+			`"go.clever-cloud.com/terraform-provider/pkg/attributes"`,
+			`"go.clever-cloud.com/terraform-provider/pkg/helper"`,
+			`"go.clever-cloud.com/terraform-provider/pkg/resources/application"`,
+		)
+	}
+
+	return append(append(std, ""), others...)
+}
+
+// Mapped lists the variables backed by an environment variable
+func (r Runtime) Mapped() []Variable {
+	variables := []Variable{}
+	for _, v := range r.Variables {
+		if v.Env != "" {
+			variables = append(variables, v)
+		}
+	}
+	return variables
+}
+
+func (r Runtime) V0Variables() []Variable {
+	variables := []Variable{}
+	for _, v := range r.Variables {
+		if v.V0 {
+			variables = append(variables, v)
+		}
+	}
+	return variables
+}
+
+func (v Variable) HasValidators() bool {
+	return len(v.OneOf) > 0 || v.Pattern != "" || v.Min != nil || v.Max != nil || len(v.Validators) > 0
+}
+
+func (v Variable) GoType() string {
+	return map[string]string{"string": "types.String", "int": "types.Int64", "bool": "types.Bool", "set": "types.Set"}[v.Type]
+}
+
+func (v Variable) NullValue() string {
+	if v.Type == "set" {
+		return "types.SetNull(types.StringType)"
+	}
+	return v.GoType() + "Null()"
+}
+
+func (v Variable) SchemaType() string {
+	return map[string]string{"string": "StringAttribute", "int": "Int64Attribute", "bool": "BoolAttribute", "set": "SetAttribute"}[v.Type]
+}
+
+func (v Variable) ValidatorType() string {
+	return map[string]string{"string": "validator.String", "int": "validator.Int64", "bool": "validator.Bool", "set": "validator.Set"}[v.Type]
+}
+
+func (v Variable) DefaultValue() bool {
+	return v.Default != nil && *v.Default
+}
+
+var codeTemplate = template.Must(template.New("runtime").Parse(`// Code generated by runtimegen from runtimes.yaml. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- range .Imports }}
+{{ if . }}	{{ . }}{{ end }}
+{{- end }}
+)
+
+// Variables holds the typed environment variables of the {{ .Package }} runtime
+type Variables struct {
+{{- range .Variables }}
+	{{ .Field }} {{ .GoType }} ` + "`" + `tfsdk:"{{ .Attribute }}"` + "`" + `
+{{- end }}
+}
+
+var variablesAttributes = map[string]schema.Attribute{
+{{- range .Variables }}
+	{{ template "attribute" . }}
+{{- end }}
+}
+
+// ToEnv writes the set variables into env
+func (v Variables) ToEnv(ctx context.Context, env map[string]string, diags *diag.Diagnostics) {
+{{- range .Mapped }}
+{{- if eq .Type "string" }}
+	pkg.IfIsSetStr(v.{{ .Field }}, func(s string) { env[{{ printf "%q" .Env }}] = s })
+{{- else if eq .Type "int" }}
+	pkg.IfIsSetI(v.{{ .Field }}, func(i int64) { env[{{ printf "%q" .Env }}] = strconv.FormatInt(i, 10) })
+{{- else if eq .Type "set" }}
+	if !v.{{ .Field }}.IsNull() && !v.{{ .Field }}.IsUnknown() {
+		elements := []string{}
+		diags.Append(v.{{ .Field }}.ElementsAs(ctx, &elements, true)...)
+		if len(elements) > 0 {
+			env[{{ printf "%q" .Env }}] = strings.Join(elements, {{ printf "%q" .Separator }})
+		}
+	}
+{{- else if .TrueValue }}
+	pkg.IfIsSetB(v.{{ .Field }}, func(b bool) {
+		if b {
+			env[{{ printf "%q" .Env }}] = {{ printf "%q" .TrueValue }}
+		}
+	})
+{{- else }}
+	pkg.IfIsSetB(v.{{ .Field }}, func(b bool) { env[{{ printf "%q" .Env }}] = strconv.FormatBool(b) })
+{{- end }}
+{{- end }}
+}
+
+// FromEnv pops the variables from env
+func (v *Variables) FromEnv(ctx context.Context, env *helperMaps.Map[string, string], diags *diag.Diagnostics) {
+{{- range .Mapped }}
+{{- if eq .Type "string" }}
+	v.{{ .Field }} = pkg.FromStrPtr(env.PopPtr({{ printf "%q" .Env }}))
+{{- else if eq .Type "int" }}
+	v.{{ .Field }} = pkg.FromIntPtr(env.PopPtr({{ printf "%q" .Env }}))
+{{- else if eq .Type "set" }}
+	v.{{ .Field }} = pkg.FromSetSplit(env.PopPtr({{ printf "%q" .Env }}), {{ printf "%q" .Separator }}, diags)
+{{- else if .TrueValue }}
+	pkg.SetBoolIf(&v.{{ .Field }}, env.PopPtr({{ printf "%q" .Env }}), {{ printf "%q" .TrueValue }})
+{{- else }}
+	v.{{ .Field }} = pkg.FromBoolPtr(env.PopPtr({{ printf "%q" .Env }}))
+{{- end }}
+{{- end }}
+}
+
+// EnvAttributes maps the environment variables written by ToEnv to their attribute
+func (Variables) EnvAttributes() map[string]string {
+	return map[string]string{
+{{- range .Mapped }}
+		{{ printf "%q" .Env }}: {{ printf "%q" .Attribute }},
+{{- end }}
+	}
+}
+{{- if .Variant }}
+
+func (r *{{ .Resource }}) GetVariantSlug() string {
+	return {{ printf "%q" .Variant }}
+}
+{{- end }}
+{{- if .UpgradeV0 }}
+
+type VariablesV0 struct {
+{{- range .V0Variables }}
+	{{ .Field }} {{ .GoType }} ` + "`" + `tfsdk:"{{ .Attribute }}"` + "`" + `
+{{- end }}
+}
+
+type stateV0 struct {
+	application.RuntimeV0
+	VariablesV0
+}
+
+var schemaV0 = schema.Schema{
+	Version:             0,
+	MarkdownDescription: {{ .Doc }},
+	Attributes: application.WithRuntimeCommonsV0(map[string]schema.Attribute{
+{{- range .V0Variables }}
+		{{ template "attribute" . }}
+{{- end }}
+	}),
+	Blocks: attributes.WithBlockRuntimeCommons(map[string]schema.Block{}),
+}
+
+// UpgradeState implements state migration from version 0 to 1 for vhosts attribute
+func (r *{{ .Resource }}) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, res *resource.UpgradeStateResponse) {
+				tflog.Info(ctx, "Upgrading {{ .Type }} resource state from version 0 to 1")
+
+				old := helper.StateFrom[stateV0](ctx, *req.State, &res.Diagnostics)
+				if res.Diagnostics.HasError() {
+					return
+				}
+
+				newState := {{ .Type }}{
+					Runtime: application.UpgradeRuntimeV0(ctx, old.RuntimeV0, &res.Diagnostics),
+					Variables: Variables{
+{{- range .V0Variables }}
+						{{ .Field }}: old.{{ .Field }},
+{{- end }}
+					},
+				}
+{{- range .Variables }}
+{{- if not .V0 }}
+				newState.{{ .Field }} = {{ .NullValue }}
+{{- end }}
+{{- end }}
+
+				res.Diagnostics.Append(res.State.Set(ctx, newState)...)
+			},
+		},
+	}
+}
+{{- end }}
+
+{{ define "attribute" -}}
+{{ printf "%q" .Attribute }}: schema.{{ .SchemaType }}{
+{{- if eq .Type "set" }}
+		ElementType:         types.StringType,
+{{- end }}
+		Optional:            true,
+{{- if .Default }}
+		Computed:            true,
+{{- end }}
+{{- if .Sensitive }}
+		Sensitive:           true,
+{{- end }}
+{{- if not .Description }}
+{{- else if .PlainDescription }}
+		Description:         {{ printf "%q" .Description }},
+{{- else }}
+		MarkdownDescription: {{ printf "%q" .Description }},
+{{- end }}
+{{- if .Deprecation }}
+		DeprecationMessage:  {{ printf "%q" .Deprecation }},
+{{- end }}
+{{- if .Default }}
+		Default:             booldefault.StaticBool({{ .DefaultValue }}),
+{{- end }}
+{{- if .HasValidators }}
+		Validators: []{{ .ValidatorType }}{
+{{- if .OneOf }}
+			stringvalidator.OneOf({{ range $i, $v := .OneOf }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end }}),
+{{- end }}
+{{- if .Pattern }}
+			pkg.NewValidatorRegex({{ printf "%q" (print "must match " .Pattern) }}, regexp.MustCompile({{ printf "%q" .Pattern }})),
+{{- end }}
+{{- if and .Min .Max }}
+			int64validator.Between({{ .Min }}, {{ .Max }}),
+{{- else if .Min }}
+			int64validator.AtLeast({{ .Min }}),
+{{- else if .Max }}
+			int64validator.AtMost({{ .Max }}),
+{{- end }}
+{{- range .Validators }}
+			{{ . }},
+{{- end }}
+		},
+{{- end }}
+	},
+{{- end }}
+`))
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestGenerate(t *testing.T) {
+	runtime := Runtime{
+		Package:   "sample",
+		Type:      "Sample",
+		Resource:  "ResourceSample",
+		Doc:       "sampleDoc",
+		Variant:   "sample",
+		UpgradeV0: true,
+		Variables: []Variable{
+			{Attribute: "version", Field: "Version", Env: "CC_SAMPLE_VERSION", Type: "string", OneOf: []string{"1", "2"}, V0: true},
+			{Attribute: "target", Field: "Target", Env: "CC_SAMPLE_TARGET", Type: "string", Pattern: `^[a-z]+"$`},
+			{Attribute: "workers", Field: "Workers", Env: "CC_SAMPLE_WORKERS", Type: "int", Min: int64Ptr(1), Max: int64Ptr(8)},
+			{Attribute: "debug", Field: "Debug", Env: "CC_SAMPLE_DEBUG", Type: "bool"},
+			{Attribute: "dev", Field: "Dev", Env: "CC_SAMPLE_DEV", Type: "bool", TrueValue: "install", Default: boolPtr(false)},
+			{Attribute: "features", Field: "Features", Env: "CC_SAMPLE_FEATURES", Type: "set", Separator: ","},
+			{Attribute: "home", Field: "Home", Env: "CC_SAMPLE_HOME", Type: "string", Description: "Home directory.", PlainDescription: true, Validators: []string{"homeValidator"}},
+			{Attribute: "legacy", Field: "Legacy", Type: "bool", Deprecation: "use `target`", V0: true},
+		},
+	}
+	if err := (&Spec{Runtimes: []Runtime{runtime}}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	code, err := Generate(runtime)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "zz_generated.go", code, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+	if len(file.Imports) != 18 {
+		t.Errorf("expect 18 imports, got %d", len(file.Imports))
+	}
+
+	for _, expected := range []string{
+		`env["CC_SAMPLE_WORKERS"] = strconv.FormatInt(i, 10)`,
+		`env["CC_SAMPLE_DEV"] = "install"`,
+		`pkg.SetBoolIf(&v.Dev, env.PopPtr("CC_SAMPLE_DEV"), "install")`,
+		`Default: booldefault.StaticBool(false)`,
+		`env["CC_SAMPLE_FEATURES"] = strings.Join(elements, ",")`,
+		`v.Features = pkg.FromSetSplit(env.PopPtr("CC_SAMPLE_FEATURES"), ",", diags)`,
+		` Description: "Home directory."`,
+		`homeValidator,`,
+		`DeprecationMessage: "use ` + "`target`" + `"`,
+		`stringvalidator.OneOf("1", "2")`,
+		`int64validator.Between(1, 8)`,
+		`regexp.MustCompile("^[a-z]+\"$")`,
+		`newState.Debug = types.BoolNull()`,
+		`newState.Features = types.SetNull(types.StringType)`,
+		`return "sample"`,
+		`"CC_SAMPLE_WORKERS": "workers",`,
+	} {
+		// ignore the alignment of gofmt
+		if !strings.Contains(strings.Join(strings.Fields(string(code)), " "), expected) {
+			t.Errorf("expect generated code to contain %s", expected)
+		}
+	}
+
+	// a deprecated attribute without variable is kept out of the mapping
+	if strings.Contains(string(code), "v.Legacy") || strings.Contains(string(code), `: "legacy"`) {
+		t.Errorf("expect the legacy attribute to be left out of the environment mapping")
+	}
+}
+
+func TestGenerateWithoutVariables(t *testing.T) {
+	runtime := Runtime{Package: "empty", Type: "Empty", Resource: "ResourceEmpty", Doc: "emptyDoc", UpgradeV0: true}
+	if err := (&Spec{Runtimes: []Runtime{runtime}}).Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// format.Source does not check imports, the package must not import what it does not use
+	code, err := Generate(runtime)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, unused := range []string{`"github.com/hashicorp/terraform-plugin-framework/types"`, `"go.clever-cloud.com/terraform-provider/pkg"` + "\n"} {
+		if strings.Contains(string(code), unused) {
+			t.Errorf("expect generated code not to import %s", unused)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, variable := range map[string]Variable{
+		"unknown type":      {Attribute: "a", Field: "A", Env: "A", Type: "float"},
+		"invalid attribute": {Attribute: "A", Field: "A", Env: "A", Type: "string"},
+		"invalid pattern":   {Attribute: "a", Field: "A", Env: "A", Type: "string", Pattern: "("},
+		"bool with one_of":  {Attribute: "a", Field: "A", Env: "A", Type: "bool", OneOf: []string{"x"}},
+		"string default":    {Attribute: "a", Field: "A", Env: "A", Type: "string", Default: boolPtr(true)},
+		"set separator":     {Attribute: "a", Field: "A", Env: "A", Type: "set"},
+		"missing env":       {Attribute: "a", Field: "A", Type: "string"},
+		"invalid validator": {Attribute: "a", Field: "A", Env: "A", Type: "string", Validators: []string{"x; y"}},
+	} {
+		spec := &Spec{Runtimes: []Runtime{{Package: "p", Type: "P", Resource: "R", Variables: []Variable{variable}}}}
+		if err := spec.Validate(); err == nil {
+			t.Errorf("%s: expect an error", name)
+		}
+	}
+}
+
+// the committed files must be up to date with the spec
+func TestSpecIsGenerated(t *testing.T) {
+	specPath := filepath.Join("..", "..", "pkg", "resources", "application", "runtimes.yaml")
+	spec, err := LoadSpec(specPath)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+
+	for _, runtime := range spec.Runtimes {
+		code, err := Generate(runtime)
+		if err != nil {
+			t.Fatalf("%s: Generate() error = %v", runtime.Package, err)
+		}
+
+		committed, err := os.ReadFile(filepath.Join(filepath.Dir(specPath), runtime.Package, "zz_generated.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(committed) != string(code) {
+			t.Errorf("%s/zz_generated.go is outdated, run go generate ./pkg/resources/application", runtime.Package)
+		}
+	}
+}